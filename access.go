@@ -0,0 +1,135 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+var ErrAccessDenied = errors.New("access denied by policy")
+
+// AccessRequest is what an AccessPolicy decides on.
+type AccessRequest struct {
+	// SecretRef is the item the request is about. Selector is set when a
+	// single value is resolved, such as by Resolve or Decode, and empty when
+	// the whole item is read or written. ItemID is empty for CreateItem.
+	SecretRef
+	// Item is the item with its folder, collections and organization, or
+	// nil while only its ID is known.
+	Item *Item
+}
+
+// AccessPolicy decides whether an item may be read or written, returning an
+// error to refuse it.
+//
+// It is first asked with only the ID, before anything is requested from bw
+// serve, so an allowlist of IDs never reads other items. Once the item is
+// known it is asked again with the Item, before the item is returned or
+// changed: reads ask about the fetched item, and deletes, edits and
+// attachment and collection changes fetch the stored item to ask about it.
+// EditItem and CreateItem also ask about the item as it will be written.
+// Cached items and the items of lists are asked about once, with the Item.
+// A policy that decides on the folder, collections or organization should
+// therefore allow requests without an Item.
+type AccessPolicy func(req AccessRequest) error
+
+// AccessError is returned when the access policy refuses a request. It
+// matches ErrAccessDenied and the error of the policy with errors.Is.
+type AccessError struct {
+	Ref SecretRef
+	err error
+}
+
+func (e *AccessError) Error() string {
+	item := "new item"
+	if e.Ref.ItemID != "" {
+		item = "item " + e.Ref.ItemID
+	}
+	if e.Ref.Selector != "" {
+		item += " " + e.Ref.Selector
+	}
+	return fmt.Sprintf("%s: %s: %s", ErrAccessDenied, item, e.err)
+}
+
+func (e *AccessError) Unwrap() []error {
+	return []error{ErrAccessDenied, e.err}
+}
+
+// WithAccessPolicy consults p before any item is read or written, including
+// items served from the cache, so an application can enforce an allowlist
+// and fail with an AccessError instead of reading more than it should.
+// Items that p refuses are left out of lists, as if they did not exist.
+func WithAccessPolicy(p AccessPolicy) Option {
+	return func(b *BitwardenServer) { b.policy = p }
+}
+
+type accessKey struct{}
+
+// withAccess marks ctx as authorized for the item of ref, so the requests
+// made to resolve ref are not checked again without the selector, and the
+// item is checked with the selector once it is known. A ctx that is already
+// authorized for the item keeps its selector.
+func withAccess(ctx context.Context, ref SecretRef) context.Context {
+	if authorized, ok := ctx.Value(accessKey{}).(SecretRef); ok && authorized.ItemID == ref.ItemID {
+		return ctx
+	}
+	return context.WithValue(ctx, accessKey{}, ref)
+}
+
+// authorize asks the access policy about ref while only its ID is known.
+func (b *BitwardenServer) authorize(ctx context.Context, ref SecretRef) error {
+	if b.policy == nil {
+		return nil
+	}
+	if authorized, ok := ctx.Value(accessKey{}).(SecretRef); ok && authorized.ItemID == ref.ItemID {
+		return nil
+	}
+	return b.ask(AccessRequest{SecretRef: ref})
+}
+
+// authorizeItem asks the access policy about the item with the ID id, with
+// the selector of the reference ctx was authorized for.
+func (b *BitwardenServer) authorizeItem(ctx context.Context, id string, item *Item) error {
+	if b.policy == nil {
+		return nil
+	}
+	ref := SecretRef{ItemID: id}
+	if authorized, ok := ctx.Value(accessKey{}).(SecretRef); ok && authorized.ItemID == id {
+		ref = authorized
+	}
+	return b.ask(AccessRequest{SecretRef: ref, Item: item})
+}
+
+func (b *BitwardenServer) ask(req AccessRequest) error {
+	if err := b.policy(req); err != nil {
+		return &AccessError{Ref: req.SecretRef, err: err}
+	}
+	return nil
+}
+
+// allowed is a list filter that drops the items the policy refuses.
+func (b *BitwardenServer) allowed(item *Item) bool {
+	return b.policy(AccessRequest{SecretRef: SecretRef{ItemID: item.ID}, Item: item}) == nil
+}
+
+// endpointItem returns the ID of the item that a request to endpoint reads
+// or writes, and false for endpoints that are not about a single item.
+func endpointItem(endpoint string) (string, bool) {
+	path, query, _ := strings.Cut(endpoint, "?")
+	switch {
+	case path == "/object/item":
+		return "", true
+	case strings.HasPrefix(path, "/object/item/"):
+		id, _ := url.PathUnescape(strings.TrimPrefix(path, "/object/item/"))
+		return id, true
+	case strings.HasPrefix(path, "/object/item-collections/"):
+		id, _ := url.PathUnescape(strings.TrimPrefix(path, "/object/item-collections/"))
+		return id, true
+	case path == "/attachment" || strings.HasPrefix(path, "/object/attachment/"):
+		values, _ := url.ParseQuery(query)
+		return values.Get("itemid"), true
+	}
+	return "", false
+}
@@ -0,0 +1,187 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAccessPolicy(t *testing.T) {
+	allowedID := "382a9d7b-f6b5-4eaa-92a1-1f3c7d89e48f"
+	deniedID := "1d4cf845-8012-4b2d-a924-f9d8c9b7c44a"
+	errNotAllowed := errors.New("not on the allowlist")
+	allowlist := func(reqs *[]AccessRequest) AccessPolicy {
+		return func(req AccessRequest) error {
+			*reqs = append(*reqs, req)
+			if req.ItemID != allowedID {
+				return errNotAllowed
+			}
+			return nil
+		}
+	}
+
+	t.Run("Should refuse items without a request", func(t *testing.T) {
+		var reqs []AccessRequest
+		bw, client := newTestBitwarden(WithAccessPolicy(allowlist(&reqs)))
+
+		_, err := bw.GetItem(context.Background(), deniedID)
+
+		client.AssertNotCalled(t, "Do", mock.Anything)
+		assert.ErrorIs(t, err, ErrAccessDenied)
+		assert.ErrorIs(t, err, errNotAllowed)
+		var accessErr *AccessError
+		if assert.ErrorAs(t, err, &accessErr) {
+			assert.Equal(t, SecretRef{ItemID: deniedID}, accessErr.Ref)
+		}
+	})
+
+	t.Run("Should ask with the ID and then with the fetched item", func(t *testing.T) {
+		var reqs []AccessRequest
+		bw, client := newTestBitwarden(WithAccessPolicy(allowlist(&reqs)))
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+allowedID, ``))).
+			Return(itemResponse(allowedID), nil).
+			Once()
+
+		_, err := bw.GetItem(context.Background(), allowedID)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		if assert.Len(t, reqs, 2) {
+			assert.Equal(t, AccessRequest{SecretRef: SecretRef{ItemID: allowedID}}, reqs[0])
+			assert.Equal(t, SecretRef{ItemID: allowedID}, reqs[1].SecretRef)
+			assert.Equal(t, allowedID, reqs[1].Item.ID)
+		}
+	})
+
+	t.Run("Should check cached items", func(t *testing.T) {
+		allowed := true
+		bw, client := newTestBitwarden(WithCache(time.Hour), WithAccessPolicy(func(AccessRequest) error {
+			if !allowed {
+				return errNotAllowed
+			}
+			return nil
+		}))
+
+		client.On("Do", mock.Anything).Return(itemResponse(allowedID), nil).Once()
+
+		_, err := bw.GetItem(context.Background(), allowedID)
+		assert.NoError(t, err)
+		allowed = false
+		_, err = bw.GetItem(context.Background(), allowedID)
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrAccessDenied)
+	})
+
+	t.Run("Should pass the selector of resolved references", func(t *testing.T) {
+		var reqs []AccessRequest
+		bw, client := newTestBitwarden(WithAccessPolicy(allowlist(&reqs)))
+
+		client.On("Do", mock.Anything).Return(itemResponse(allowedID), nil).Once()
+
+		_, err := bw.Resolve(context.Background(), SecretRef{ItemID: allowedID, Selector: "notes"})
+		assert.NoError(t, err)
+		_, err = bw.Resolve(context.Background(), SecretRef{ItemID: deniedID, Selector: "password"})
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrAccessDenied)
+		var refs []SecretRef
+		for _, req := range reqs {
+			refs = append(refs, req.SecretRef)
+		}
+		assert.Equal(t, []SecretRef{{ItemID: allowedID, Selector: "notes"}, {ItemID: allowedID, Selector: "notes"}, {ItemID: deniedID, Selector: "password"}}, refs)
+	})
+
+	t.Run("Should refuse writes", func(t *testing.T) {
+		var reqs []AccessRequest
+		bw, client := newTestBitwarden(WithAccessPolicy(allowlist(&reqs)))
+		name := "ENV"
+
+		_, err := bw.EditItem(context.Background(), &Item{ID: deniedID, Type: TypeSecureNote, Name: &name})
+		assert.ErrorIs(t, err, ErrAccessDenied)
+		assert.ErrorIs(t, bw.DeleteItem(context.Background(), deniedID), ErrAccessDenied)
+		_, err = bw.DownloadAttachment(context.Background(), deniedID, "att1")
+		assert.ErrorIs(t, err, ErrAccessDenied)
+		_, err = bw.CreateItem(context.Background(), &Item{Type: TypeSecureNote, Name: &name})
+		assert.ErrorIs(t, err, ErrAccessDenied)
+
+		client.AssertNotCalled(t, "Do", mock.Anything)
+		assert.Equal(t, SecretRef{}, reqs[len(reqs)-1].SecretRef)
+		assert.Equal(t, &Item{Type: TypeSecureNote, Name: &name, SecureNote: &SecureNote{}}, reqs[len(reqs)-1].Item)
+	})
+
+	t.Run("Should decide on the folder of the item", func(t *testing.T) {
+		errOtherFolder := errors.New("not in the folder")
+		bw, client := newTestBitwarden(WithAccessPolicy(func(req AccessRequest) error {
+			if req.Item == nil || (req.Item.FolderID != nil && *req.Item.FolderID == "f1") {
+				return nil
+			}
+			return errOtherFolder
+		}))
+		inFolder := func(id, folder string) func(*http.Request) (*http.Response, error) {
+			return func(*http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(`{"data":{"id":"` + id + `","type":2,"name":"ENV","folderId":"` + folder + `"}}`))}, nil
+			}
+		}
+		name, folder := "ENV", "f2"
+
+		client.On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+allowedID, ``))).Return(inFolder(allowedID, "f1")).Once()
+		client.On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+deniedID, ``))).Return(inFolder(deniedID, "f2")).Twice()
+
+		_, err := bw.GetItem(context.Background(), allowedID)
+		assert.NoError(t, err)
+		_, err = bw.GetItem(context.Background(), deniedID)
+		assert.ErrorIs(t, err, errOtherFolder)
+		assert.ErrorIs(t, bw.DeleteItem(context.Background(), deniedID), errOtherFolder)
+		_, err = bw.CreateItem(context.Background(), &Item{Type: TypeSecureNote, Name: &name, FolderID: &folder})
+		assert.ErrorIs(t, err, errOtherFolder)
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("Should leave refused items out of lists", func(t *testing.T) {
+		var reqs []AccessRequest
+		bw, client := newTestBitwarden(WithAccessPolicy(allowlist(&reqs)))
+
+		client.
+			On("Do", mock.Anything).
+			Return(listResponse(`{"id":"`+deniedID+`","type":2},{"id":"`+allowedID+`","type":2}`), nil).
+			Once()
+
+		items, err := bw.ListItems(context.Background())
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		if assert.Len(t, items, 1) {
+			assert.Equal(t, allowedID, items[0].ID)
+		}
+	})
+}
+
+func TestEndpointItem(t *testing.T) {
+	for endpoint, expected := range map[string]string{
+		"/object/item":                       "",
+		"/object/item/abc":                   "abc",
+		"/object/item/abc?permanent=true":    "abc",
+		"/object/item-collections/abc":       "abc",
+		"/object/attachment/att1?itemid=abc": "abc",
+		"/attachment?itemid=abc":             "abc",
+	} {
+		id, ok := endpointItem(endpoint)
+		assert.True(t, ok, endpoint)
+		assert.Equal(t, expected, id, endpoint)
+	}
+	for _, endpoint := range []string{"/list/object/items", "/object/folder", "/status"} {
+		_, ok := endpointItem(endpoint)
+		assert.False(t, ok, endpoint)
+	}
+}
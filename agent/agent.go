@@ -0,0 +1,252 @@
+// Package agent implements an SSH agent that serves the keys of SSH key
+// items in the vault, so ssh and git can use them without the keys ever
+// being written to disk.
+//
+// The agent is read-only: keys are added and removed in the vault, not
+// through the agent protocol.
+package agent
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+	"golang.org/x/crypto/ssh"
+	sshagent "golang.org/x/crypto/ssh/agent"
+)
+
+var (
+	ErrReadOnly        = errors.New("agent is read-only")
+	ErrLocked          = errors.New("agent is locked")
+	ErrNotLocked       = errors.New("agent is not locked")
+	ErrWrongPassphrase = errors.New("incorrect passphrase")
+	ErrKeyNotFound     = errors.New("key not found")
+	ErrDenied          = errors.New("use of key was denied")
+	ErrNotSupported    = errors.New("operation not supported")
+)
+
+type Client interface {
+	ListItems(ctx context.Context, opts ...bitwarden.ListOption) ([]bitwarden.Item, error)
+}
+
+// Option configures the agent.
+type Option func(*Agent)
+
+// WithConfirm makes the agent call confirm before a key of an item with
+// master password re-prompt enabled is used. The key is only used if
+// confirm returns true. Without it the re-prompt setting is ignored.
+func WithConfirm(confirm func(item *bitwarden.Item) bool) Option {
+	return func(a *Agent) { a.confirm = confirm }
+}
+
+// WithListOptions limits the items the agent serves keys from, for example
+// to a single folder.
+func WithListOptions(opts ...bitwarden.ListOption) Option {
+	return func(a *Agent) { a.listOpts = opts }
+}
+
+// Agent is an ssh agent.ExtendedAgent backed by the vault. Keys are read
+// from the vault on every request, so changes take effect immediately.
+type Agent struct {
+	ctx      context.Context
+	client   Client
+	confirm  func(item *bitwarden.Item) bool
+	listOpts []bitwarden.ListOption
+
+	mu         sync.Mutex
+	passphrase []byte
+}
+
+var _ sshagent.ExtendedAgent = (*Agent)(nil)
+
+// New returns an agent that uses ctx for its vault requests.
+func New(ctx context.Context, c Client, opts ...Option) *Agent {
+	a := &Agent{ctx: ctx, client: c}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+type key struct {
+	item   bitwarden.Item
+	signer ssh.Signer
+}
+
+func (a *Agent) keys() ([]key, error) {
+	items, err := a.client.ListItems(a.ctx, a.listOpts...)
+	if err != nil {
+		return nil, err
+	}
+	var keys []key
+	for _, i := range items {
+		if i.Type != bitwarden.TypeSSHKey || i.SSHKey == nil || i.SSHKey.PrivateKey == nil {
+			continue
+		}
+		signer, err := ssh.ParsePrivateKey([]byte(*i.SSHKey.PrivateKey))
+		if err != nil {
+			continue // a single broken item should not take down the agent
+		}
+		keys = append(keys, key{item: i, signer: signer})
+	}
+	return keys, nil
+}
+
+func (a *Agent) locked() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.passphrase != nil
+}
+
+// List returns the public keys of all SSH key items, with the item name as
+// comment.
+func (a *Agent) List() ([]*sshagent.Key, error) {
+	if a.locked() {
+		return nil, nil
+	}
+	keys, err := a.keys()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*sshagent.Key, 0, len(keys))
+	for _, k := range keys {
+		pub := k.signer.PublicKey()
+		comment := ""
+		if k.item.Name != nil {
+			comment = *k.item.Name
+		}
+		list = append(list, &sshagent.Key{Format: pub.Type(), Blob: pub.Marshal(), Comment: comment})
+	}
+	return list, nil
+}
+
+func (a *Agent) Sign(key ssh.PublicKey, data []byte) (*ssh.Signature, error) {
+	return a.SignWithFlags(key, data, 0)
+}
+
+func (a *Agent) SignWithFlags(pub ssh.PublicKey, data []byte, flags sshagent.SignatureFlags) (*ssh.Signature, error) {
+	if a.locked() {
+		return nil, ErrLocked
+	}
+	k, err := a.find(pub)
+	if err != nil {
+		return nil, err
+	}
+	if k.item.Reprompt == bitwarden.RepromptYes && a.confirm != nil && !a.confirm(&k.item) {
+		return nil, ErrDenied
+	}
+
+	algorithm := ""
+	switch {
+	case flags&sshagent.SignatureFlagRsaSha256 != 0:
+		algorithm = ssh.KeyAlgoRSASHA256
+	case flags&sshagent.SignatureFlagRsaSha512 != 0:
+		algorithm = ssh.KeyAlgoRSASHA512
+	}
+	if algorithm == "" {
+		return k.signer.Sign(nil, data)
+	}
+	as, ok := k.signer.(ssh.AlgorithmSigner)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s signatures with %s keys", ErrNotSupported, algorithm, pub.Type())
+	}
+	return as.SignWithAlgorithm(nil, data, algorithm)
+}
+
+func (a *Agent) find(pub ssh.PublicKey) (key, error) {
+	keys, err := a.keys()
+	if err != nil {
+		return key{}, err
+	}
+	want := pub.Marshal()
+	for _, k := range keys {
+		if bytes.Equal(k.signer.PublicKey().Marshal(), want) {
+			return k, nil
+		}
+	}
+	return key{}, ErrKeyNotFound
+}
+
+// Signers returns signers for all SSH key items. They sign through the
+// agent like SignWithFlags, so they fail while it is locked and ask the
+// WithConfirm callback before using the key of a re-prompt item.
+func (a *Agent) Signers() ([]ssh.Signer, error) {
+	if a.locked() {
+		return nil, ErrLocked
+	}
+	keys, err := a.keys()
+	if err != nil {
+		return nil, err
+	}
+	signers := make([]ssh.Signer, 0, len(keys))
+	for _, k := range keys {
+		signers = append(signers, agentSigner{a: a, pub: k.signer.PublicKey()})
+	}
+	return signers, nil
+}
+
+// agentSigner signs with a key of the agent.
+type agentSigner struct {
+	a   *Agent
+	pub ssh.PublicKey
+}
+
+func (s agentSigner) PublicKey() ssh.PublicKey {
+	return s.pub
+}
+
+func (s agentSigner) Sign(_ io.Reader, data []byte) (*ssh.Signature, error) {
+	return s.a.SignWithFlags(s.pub, data, 0)
+}
+
+func (s agentSigner) SignWithAlgorithm(_ io.Reader, data []byte, algorithm string) (*ssh.Signature, error) {
+	var flags sshagent.SignatureFlags
+	switch algorithm {
+	case "", s.pub.Type():
+	case ssh.KeyAlgoRSASHA256:
+		flags = sshagent.SignatureFlagRsaSha256
+	case ssh.KeyAlgoRSASHA512:
+		flags = sshagent.SignatureFlagRsaSha512
+	default:
+		return nil, fmt.Errorf("%w: %s signatures with %s keys", ErrNotSupported, algorithm, s.pub.Type())
+	}
+	return s.a.SignWithFlags(s.pub, data, flags)
+}
+
+func (a *Agent) Add(sshagent.AddedKey) error { return ErrReadOnly }
+func (a *Agent) Remove(ssh.PublicKey) error  { return ErrReadOnly }
+func (a *Agent) RemoveAll() error            { return ErrReadOnly }
+
+func (a *Agent) Extension(string, []byte) ([]byte, error) {
+	return nil, sshagent.ErrExtensionUnsupported
+}
+
+// Lock stops the agent from listing keys or signing until Unlock is called
+// with the same passphrase.
+func (a *Agent) Lock(passphrase []byte) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.passphrase != nil {
+		return ErrLocked
+	}
+	a.passphrase = append([]byte{}, passphrase...)
+	return nil
+}
+
+func (a *Agent) Unlock(passphrase []byte) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.passphrase == nil {
+		return ErrNotLocked
+	}
+	if subtle.ConstantTimeCompare(a.passphrase, passphrase) != 1 {
+		return ErrWrongPassphrase
+	}
+	a.passphrase = nil
+	return nil
+}
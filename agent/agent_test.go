@@ -0,0 +1,148 @@
+package agent
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"net"
+	"testing"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+	sshagent "golang.org/x/crypto/ssh/agent"
+)
+
+var _ Client = (*bitwarden.BitwardenServer)(nil)
+
+type fakeClient struct {
+	items []bitwarden.Item
+}
+
+func (f *fakeClient) ListItems(context.Context, ...bitwarden.ListOption) ([]bitwarden.Item, error) {
+	return f.items, nil
+}
+
+func ptr(s string) *string { return &s }
+
+func newKeyItem(t *testing.T, name string, reprompt bitwarden.Reprompt) (bitwarden.Item, ssh.PublicKey) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	assert.NoError(t, err)
+	sshPub, err := ssh.NewPublicKey(pub)
+	assert.NoError(t, err)
+
+	return bitwarden.Item{
+		Type:     bitwarden.TypeSSHKey,
+		Name:     ptr(name),
+		Reprompt: reprompt,
+		SSHKey:   &bitwarden.SSHKey{PrivateKey: ptr(string(pem.EncodeToMemory(block)))},
+	}, sshPub
+}
+
+func TestAgent(t *testing.T) {
+	deploy, deployPub := newKeyItem(t, "deploy", bitwarden.RepromptNo)
+	admin, adminPub := newKeyItem(t, "admin", bitwarden.RepromptYes)
+	client := &fakeClient{items: []bitwarden.Item{
+		deploy,
+		admin,
+		{Type: bitwarden.TypeLogin, Name: ptr("login")},
+		{Type: bitwarden.TypeSSHKey, Name: ptr("broken"), SSHKey: &bitwarden.SSHKey{PrivateKey: ptr("garbage")}},
+	}}
+
+	t.Run("Should list the keys of ssh key items", func(t *testing.T) {
+		a := New(context.Background(), client)
+
+		keys, err := a.List()
+
+		assert.NoError(t, err)
+		assert.Len(t, keys, 2)
+		assert.Equal(t, "deploy", keys[0].Comment)
+		assert.Equal(t, deployPub.Marshal(), keys[0].Blob)
+	})
+
+	t.Run("Should sign over the agent protocol", func(t *testing.T) {
+		a := New(context.Background(), client)
+
+		serverConn, clientConn := net.Pipe()
+		defer clientConn.Close()
+		go sshagent.ServeAgent(a, serverConn)
+
+		remote := sshagent.NewClient(clientConn)
+		sig, err := remote.Sign(deployPub, []byte("data"))
+
+		assert.NoError(t, err)
+		assert.NoError(t, deployPub.Verify([]byte("data"), sig))
+	})
+
+	t.Run("Should ask for confirmation of re-prompt items", func(t *testing.T) {
+		var asked []string
+		allow := false
+		a := New(context.Background(), client, WithConfirm(func(i *bitwarden.Item) bool {
+			asked = append(asked, *i.Name)
+			return allow
+		}))
+
+		_, err := a.Sign(deployPub, []byte("data"))
+		assert.NoError(t, err)
+		_, err = a.Sign(adminPub, []byte("data"))
+		assert.ErrorIs(t, err, ErrDenied)
+		allow = true
+		_, err = a.Sign(adminPub, []byte("data"))
+		assert.NoError(t, err)
+
+		assert.Equal(t, []string{"admin", "admin"}, asked)
+	})
+
+	t.Run("Should ask for confirmation in signers of re-prompt items", func(t *testing.T) {
+		a := New(context.Background(), client, WithConfirm(func(*bitwarden.Item) bool { return false }))
+
+		signers, err := a.Signers()
+
+		assert.NoError(t, err)
+		if assert.Len(t, signers, 2) {
+			_, err = signers[0].Sign(nil, []byte("data"))
+			assert.NoError(t, err)
+			_, err = signers[1].Sign(nil, []byte("data"))
+			assert.ErrorIs(t, err, ErrDenied)
+			_, err = signers[1].(ssh.AlgorithmSigner).SignWithAlgorithm(nil, []byte("data"), adminPub.Type())
+			assert.ErrorIs(t, err, ErrDenied)
+		}
+	})
+
+	t.Run("Should report unknown keys", func(t *testing.T) {
+		a := New(context.Background(), client)
+		_, otherPub := newKeyItem(t, "other", bitwarden.RepromptNo)
+
+		_, err := a.Sign(otherPub, []byte("data"))
+
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+	})
+
+	t.Run("Should refuse to change keys", func(t *testing.T) {
+		a := New(context.Background(), client)
+
+		assert.ErrorIs(t, a.Add(sshagent.AddedKey{}), ErrReadOnly)
+		assert.ErrorIs(t, a.Remove(deployPub), ErrReadOnly)
+		assert.ErrorIs(t, a.RemoveAll(), ErrReadOnly)
+	})
+
+	t.Run("Should lock and unlock", func(t *testing.T) {
+		a := New(context.Background(), client)
+
+		assert.ErrorIs(t, a.Unlock([]byte("pass")), ErrNotLocked)
+		assert.NoError(t, a.Lock([]byte("pass")))
+		keys, err := a.List()
+		assert.NoError(t, err)
+		assert.Empty(t, keys)
+		_, err = a.Sign(deployPub, []byte("data"))
+		assert.ErrorIs(t, err, ErrLocked)
+
+		assert.ErrorIs(t, a.Unlock([]byte("wrong")), ErrWrongPassphrase)
+		assert.NoError(t, a.Unlock([]byte("pass")))
+		_, err = a.Sign(deployPub, []byte("data"))
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,135 @@
+package bitwarden
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// maxErrorBody limits how much of a failed response is kept.
+const maxErrorBody = 64 << 10
+
+// messageErrors maps the messages of bw to typed errors.
+var messageErrors = map[string]error{
+	"You are not logged in.": ErrNotLoggedIn,
+	"Vault is locked.":       ErrVaultLocked,
+}
+
+// APIError is returned for requests that bw serve answered with a status
+// other than 200 OK. It matches ErrNotFound, ErrBadRequest or
+// ErrUnexpectedStatusCode with errors.Is, depending on the status, or the
+// error of WithErrorMapper, and typed errors such as ErrVaultLocked for well
+// known messages.
+type APIError struct {
+	Method     string
+	Endpoint   string
+	StatusCode int
+	// Message is the error message of the server, if any.
+	Message string
+	// Body is the response body with sensitive fields redacted, see Redact.
+	Body []byte
+
+	err   error
+	typed error
+}
+
+// NewAPIError returns the error for a response with a status other than
+// 200 OK, reading the message from its body. The clients of the Bitwarden
+// cloud APIs in the subpackages use it too, so their errors are handled like
+// those of bw serve.
+func NewAPIError(method, endpoint string, r *http.Response) *APIError {
+	e := &APIError{Method: method, Endpoint: endpoint, StatusCode: r.StatusCode}
+	switch r.StatusCode {
+	case http.StatusNotFound:
+		e.err = ErrNotFound
+	case http.StatusBadRequest:
+		e.err = ErrBadRequest
+	default:
+		e.err = ErrUnexpectedStatusCode
+	}
+	if r.Body != nil {
+		body, _ := io.ReadAll(io.LimitReader(r.Body, maxErrorBody))
+		e.Body = Redact(body)
+		e.Message = serverMessage(e.Body)
+	}
+	e.typed = messageErrors[e.Message]
+	return e
+}
+
+// serverMessage returns the message of a {"success": false, "message": ...}
+// body of bw serve or a {"message": ..., "errors": {...}} body of the cloud
+// APIs, or else the body itself.
+func serverMessage(body []byte) string {
+	var envelope struct {
+		Message *string             `json:"message"`
+		Errors  map[string][]string `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil || envelope.Message == nil {
+		return strings.TrimSpace(string(body))
+	}
+	msg := *envelope.Message
+	fields := make([]string, 0, len(envelope.Errors))
+	for field := range envelope.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		msg += fmt.Sprintf("; %s: %s", field, strings.Join(envelope.Errors[field], ", "))
+	}
+	return msg
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s %s: %s", e.Method, e.Endpoint, e.err)
+	if e.err == ErrUnexpectedStatusCode {
+		msg += fmt.Sprintf(": %d", e.StatusCode)
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+func (e *APIError) Unwrap() []error {
+	if e.typed != nil {
+		return []error{e.err, e.typed}
+	}
+	return []error{e.err}
+}
+
+// ErrorMapper returns the error for a response with a status other than
+// 200 OK, or nil to keep the error of this package.
+type ErrorMapper func(e *APIError) error
+
+// WithErrorMapper maps the responses of a reverse proxy in front of bw serve
+// to the errors of this package, for example its 401 or 503 pages, which
+// otherwise match ErrUnexpectedStatusCode. The mappers are called in order
+// and the first error returned replaces ErrNotFound, ErrBadRequest or
+// ErrUnexpectedStatusCode. Using the option more than once appends mappers.
+func WithErrorMapper(m ...ErrorMapper) Option {
+	return func(b *BitwardenServer) { b.errorMappers = append(b.errorMappers, m...) }
+}
+
+// MapStatus maps responses with the status code to err, for example
+// MapStatus(http.StatusProxyAuthRequired, ErrNotLoggedIn).
+func MapStatus(status int, err error) ErrorMapper {
+	return func(e *APIError) error {
+		if e.StatusCode == status {
+			return err
+		}
+		return nil
+	}
+}
+
+// mapError applies the error mappers to e.
+func (b *BitwardenServer) mapError(e *APIError) {
+	for _, m := range b.errorMappers {
+		if err := m(e); err != nil {
+			e.err = err
+			return
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAPIError(t *testing.T) {
+	t.Run("Should describe the failed request", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodPost, "http://localhost/sync", `{}`))).
+			Return(&http.Response{StatusCode: 500, Body: io.NopCloser(bytes.NewBufferString("Internal Server Error\n"))}, nil).
+			Once()
+
+		err := bw.Sync(context.Background())
+
+		client.AssertExpectations(t)
+		var apiErr *APIError
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.MethodPost, apiErr.Method)
+		assert.Equal(t, "/sync", apiErr.Endpoint)
+		assert.Equal(t, 500, apiErr.StatusCode)
+		assert.Equal(t, "Internal Server Error", apiErr.Message)
+		assert.Equal(t, []byte("Internal Server Error\n"), apiErr.Body)
+		assert.ErrorIs(t, err, ErrUnexpectedStatusCode)
+		assert.EqualError(t, err, "POST /sync: unexpected status code: 500: Internal Server Error")
+	})
+
+	t.Run("Should match the sentinel for the status", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 404}, nil).
+			Once()
+
+		_, err := bw.GetItem(context.Background(), "missing")
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.NotErrorIs(t, err, ErrBadRequest)
+		assert.EqualError(t, err, "GET /object/item/missing: item not found")
+	})
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	t.Run("Should surface the message of the envelope", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 400, Body: io.NopCloser(bytes.NewBufferString(`{"success":false,"message":"Vault is locked."}`))}, nil).
+			Once()
+
+		_, err := bw.GetItem(context.Background(), "db")
+
+		client.AssertExpectations(t)
+		var apiErr *APIError
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, "Vault is locked.", apiErr.Message)
+		assert.ErrorIs(t, err, ErrBadRequest)
+		assert.ErrorIs(t, err, ErrVaultLocked)
+		assert.EqualError(t, err, "GET /object/item/db: bad request: Vault is locked.")
+	})
+
+	t.Run("Should map not logged in", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 400, Body: io.NopCloser(bytes.NewBufferString(`{"success":false,"message":"You are not logged in."}`))}, nil).
+			Once()
+
+		err := bw.Sync(context.Background())
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrNotLoggedIn)
+		assert.NotErrorIs(t, err, ErrVaultLocked)
+	})
+
+	t.Run("Should keep other JSON bodies as is", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 400, Body: io.NopCloser(bytes.NewBufferString(`{"error":"oops"}`))}, nil).
+			Once()
+
+		err := bw.Sync(context.Background())
+
+		client.AssertExpectations(t)
+		assert.EqualError(t, err, `POST /sync: bad request: {"error":"oops"}`)
+	})
+}
+
+func TestWithErrorMapper(t *testing.T) {
+	t.Run("Should map proxy responses to the errors of the package", func(t *testing.T) {
+		bw, client := newTestBitwarden(
+			WithErrorMapper(MapStatus(http.StatusProxyAuthRequired, ErrNotLoggedIn)),
+			WithErrorMapper(func(e *APIError) error {
+				if e.StatusCode == http.StatusServiceUnavailable && e.Message == "maintenance" {
+					return ErrServeNotRunning
+				}
+				return nil
+			}),
+		)
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: http.StatusProxyAuthRequired, Body: io.NopCloser(bytes.NewBufferString("proxy login required"))}, nil).
+			Once()
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: http.StatusServiceUnavailable, Body: io.NopCloser(bytes.NewBufferString("maintenance"))}, nil).
+			Once()
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: http.StatusServiceUnavailable, Body: io.NopCloser(bytes.NewBufferString("overloaded"))}, nil).
+			Once()
+
+		err := bw.Sync(context.Background())
+		assert.ErrorIs(t, err, ErrNotLoggedIn)
+		assert.NotErrorIs(t, err, ErrUnexpectedStatusCode)
+		assert.EqualError(t, err, "POST /sync: not logged in: proxy login required")
+
+		err = bw.Sync(context.Background())
+		assert.ErrorIs(t, err, ErrServeNotRunning)
+		var apiErr *APIError
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+
+		err = bw.Sync(context.Background())
+		assert.ErrorIs(t, err, ErrUnexpectedStatusCode)
+
+		client.AssertExpectations(t)
+	})
+}
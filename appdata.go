@@ -0,0 +1,36 @@
+package bitwarden
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// WithIsolatedAppData makes New run bw serve with its own app data
+// directory, a new temporary directory that Close removes, instead of the
+// one shared with interactive bw sessions, so the client never logs out or
+// locks the session of the user. The directory starts without a login: if
+// BW_CLIENTID and BW_CLIENTSECRET are set, bw login --apikey runs in it
+// before bw serve starts; otherwise bw serve reports ErrNotLoggedIn.
+func WithIsolatedAppData() Option {
+	return func(b *BitwardenServer) { b.isolateAppData = true }
+}
+
+// isolate points bw serve at a new app data directory and logs in there.
+func (b *BitwardenServer) isolate(ctx context.Context) error {
+	dir, err := os.MkdirTemp("", "bw-appdata-*")
+	if err != nil {
+		return fmt.Errorf("creating isolated app data: %w", err)
+	}
+	b.appDataDir = dir
+	env := append(os.Environ(), "BITWARDENCLI_APPDATA_DIR="+dir)
+	b.cmd.Env = env
+
+	if os.Getenv("BW_CLIENTID") != "" && os.Getenv("BW_CLIENTSECRET") != "" {
+		login := exec.CommandContext(ctx, "bw", "login", "--apikey", "--nointeraction")
+		login.Env = env
+		login.Run() // a failed login shows as ErrNotLoggedIn on the first request
+	}
+	return nil
+}
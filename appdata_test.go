@@ -0,0 +1,42 @@
+package bitwarden
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsolatedAppData(t *testing.T) {
+	t.Run("Should log in and serve from a private app data directory", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("needs a POSIX shell")
+		}
+		bin := t.TempDir()
+		log := filepath.Join(bin, "calls")
+		script := "#!/bin/sh\necho \"$BITWARDENCLI_APPDATA_DIR $*\" >> " + log + "\n"
+		assert.NoError(t, os.WriteFile(filepath.Join(bin, "bw"), []byte(script), 0o700))
+		t.Setenv("PATH", bin)
+		t.Setenv("BW_CLIENTID", "user.id")
+		t.Setenv("BW_CLIENTSECRET", "secret")
+
+		bw, _ := newTestBitwarden(WithIsolatedAppData())
+		bw.cmd = exec.Command("bw", "serve")
+		assert.NoError(t, bw.isolate(context.Background()))
+
+		assert.DirExists(t, bw.appDataDir)
+		assert.Contains(t, bw.cmd.Env, "BITWARDENCLI_APPDATA_DIR="+bw.appDataDir)
+		calls, err := os.ReadFile(log)
+		assert.NoError(t, err)
+		assert.Equal(t, bw.appDataDir+" login --apikey --nointeraction", strings.TrimSpace(string(calls)))
+
+		bw.cmd = nil // never started
+		bw.Close()
+		assert.NoDirExists(t, bw.appDataDir)
+	})
+}
@@ -0,0 +1,392 @@
+package bitwarden
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+var (
+	ErrInvalidSpec         = errors.New("invalid item spec")
+	ErrInvalidApplyOptions = errors.New("invalid apply options")
+)
+
+// ItemSpec is the desired state of one item for Apply.
+type ItemSpec struct {
+	// Item is the desired content. Items are matched to the vault by name;
+	// the ID, dates, folder ID, organization, collections, attachments and
+	// password history of Item are ignored. A login without a password
+	// leaves the password of an existing item unchanged, so specs need not
+	// hold secrets.
+	Item Item
+	// Folder is the name of the folder the item belongs in, which Apply
+	// creates if it does not exist. Empty means no folder, or the folder of
+	// WithApplyScope if it names one.
+	Folder string
+}
+
+type ApplyAction string
+
+const (
+	ApplyCreateFolder ApplyAction = "create folder"
+	ApplyCreate       ApplyAction = "create"
+	ApplyUpdate       ApplyAction = "update"
+	ApplyDelete       ApplyAction = "delete"
+)
+
+// ApplyChange is a change made, or planned, by Apply.
+type ApplyChange struct {
+	Action ApplyAction
+	// Name is the name of the item or folder.
+	Name string
+	// ID is the ID of the item or folder, or empty if it is not created yet.
+	ID string
+}
+
+func (c ApplyChange) String() string {
+	return fmt.Sprintf("%s %q", c.Action, c.Name)
+}
+
+// ApplyResult lists the changes made by Apply, in the order they were made,
+// or with WithDryRun the changes it would make.
+type ApplyResult struct {
+	Changes   []ApplyChange
+	Unchanged int
+}
+
+type applyOptions struct {
+	prune  bool
+	dryRun bool
+	scope  []ListOption
+}
+
+// ApplyOption configures Apply.
+type ApplyOption func(*applyOptions)
+
+// WithPrune makes Apply delete items in scope that are not in the desired
+// state. Without it, Apply only creates and updates. Folders are never
+// deleted. It requires WithApplyScope, so a spec can never empty the whole
+// vault by accident.
+func WithPrune() ApplyOption {
+	return func(o *applyOptions) { o.prune = true }
+}
+
+// WithDryRun makes Apply report the changes without making them.
+func WithDryRun() ApplyOption {
+	return func(o *applyOptions) { o.dryRun = true }
+}
+
+// WithApplyScope limits the items Apply manages to those listed with the
+// given options, for example InFolder or InOrganization. Items outside the
+// scope are neither matched nor pruned. Created items are put in the folder,
+// collection and organization of the scope, so the next Apply finds them; a
+// spec whose Folder lies outside the scope is refused with ErrInvalidSpec.
+// Creating items in a collection needs InOrganization as well.
+func WithApplyScope(opts ...ListOption) ApplyOption {
+	return func(o *applyOptions) { o.scope = opts }
+}
+
+// Apply converges the vault to the desired items: missing items and folders
+// are created, items that differ are updated, and with WithPrune, items in
+// scope that are not desired are deleted. Items are matched by name, so
+// names must be unique among the desired items and the items in scope.
+//
+// Changes are made one at a time. If one fails, Apply stops and returns the
+// changes made so far along with the error.
+func (b *BitwardenServer) Apply(ctx context.Context, desired []ItemSpec, opts ...ApplyOption) (*ApplyResult, error) {
+	o := applyOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.prune && len(o.scope) == 0 {
+		return nil, fmt.Errorf("%w: WithPrune needs WithApplyScope", ErrInvalidApplyOptions)
+	}
+	scope, err := newApplyScope(o.scope)
+	if err != nil {
+		return nil, err
+	}
+	ctx, span := b.startSpan(ctx, "Apply")
+	result, err := b.apply(ctx, desired, o, scope)
+	return result, endSpan(span, err)
+}
+
+// applyScope is where WithApplyScope puts items: the IDs, or "null" or
+// "notnull", of its folder, collection and organization, if it names them.
+type applyScope struct {
+	folderID, collectionID, organizationID string
+}
+
+func newApplyScope(opts []ListOption) (applyScope, error) {
+	lo, err := newListOptions(opts)
+	if err != nil {
+		return applyScope{}, fmt.Errorf("%w: %w", ErrInvalidApplyOptions, err)
+	}
+	return applyScope{
+		folderID:       lo.query.Get("folderid"),
+		collectionID:   lo.query.Get("collectionid"),
+		organizationID: lo.query.Get("organizationid"),
+	}, nil
+}
+
+// folder returns the ID of the folder of the spec, which must be in the
+// scope. folderIDs maps the names of the folders to their IDs.
+func (s applyScope) folder(spec ItemSpec, folderIDs map[string]string) (*string, error) {
+	if spec.Folder == "" {
+		switch s.folderID {
+		case "", "null":
+			return nil, nil
+		case "notnull":
+			return nil, fmt.Errorf("%w: %q needs a folder to stay in scope", ErrInvalidSpec, *spec.Item.Name)
+		}
+		return &s.folderID, nil
+	}
+	id := folderIDs[spec.Folder]
+	if s.folderID == "null" || (s.folderID != "" && s.folderID != "notnull" && id != s.folderID) {
+		return nil, fmt.Errorf("%w: folder %q of %q is outside the scope", ErrInvalidSpec, spec.Folder, *spec.Item.Name)
+	}
+	return &id, nil
+}
+
+// place puts a new item in the collection and organization of the scope.
+func (s applyScope) place(item *Item) error {
+	if s.collectionID == "notnull" || s.organizationID == "notnull" {
+		return fmt.Errorf("%w: cannot create items in any collection or organization, name one", ErrInvalidApplyOptions)
+	}
+	if s.collectionID != "" && s.collectionID != "null" {
+		if s.organizationID == "" || s.organizationID == "null" {
+			return fmt.Errorf("%w: creating items in a collection needs InOrganization", ErrInvalidApplyOptions)
+		}
+		item.CollectionIDs = []string{s.collectionID}
+	}
+	if s.organizationID != "" && s.organizationID != "null" {
+		item.OrganizationID = &s.organizationID
+	}
+	return nil
+}
+
+// newItem returns the item to create for the spec: its content, without the
+// fields ItemSpec ignores, in the scope.
+func (s applyScope) newItem(spec ItemSpec, folderID *string) (*Item, error) {
+	item := spec.Item
+	item.ID = ""
+	item.CreationDate = time.Time{}
+	item.RevisionDate = nil
+	item.DeletedDate = nil
+	item.OrganizationID = nil
+	item.CollectionID = nil
+	item.CollectionIDs = nil
+	item.Attachments = nil
+	item.PasswordHistory = nil
+	item.FolderID = folderID
+	if err := s.place(&item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (b *BitwardenServer) apply(ctx context.Context, desired []ItemSpec, o applyOptions, scope applyScope) (*ApplyResult, error) {
+	wanted := map[string]bool{}
+	for _, spec := range desired {
+		if spec.Item.Name == nil || *spec.Item.Name == "" {
+			return nil, fmt.Errorf("%w: item without a name", ErrInvalidSpec)
+		}
+		if wanted[*spec.Item.Name] {
+			return nil, fmt.Errorf("%w: duplicate name %q", ErrInvalidSpec, *spec.Item.Name)
+		}
+		wanted[*spec.Item.Name] = true
+	}
+
+	items, err := b.ListItems(ctx, o.scope...)
+	if err != nil {
+		return nil, err
+	}
+	live := map[string]*Item{}
+	for i := range items {
+		if items[i].Name == nil {
+			continue
+		}
+		name := *items[i].Name
+		if _, ok := live[name]; ok && (wanted[name] || o.prune) {
+			return nil, fmt.Errorf("%w: vault has more than one item named %q", ErrInvalidSpec, name)
+		}
+		live[name] = &items[i]
+	}
+
+	folders, err := b.ListFolders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	folderIDs := map[string]string{}
+	for _, f := range folders {
+		folderIDs[f.Name] = f.ID
+	}
+	for _, spec := range desired {
+		if _, err := scope.folder(spec, folderIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &ApplyResult{}
+	for _, spec := range desired {
+		if spec.Folder == "" {
+			continue
+		}
+		if _, ok := folderIDs[spec.Folder]; ok {
+			continue
+		}
+		change := ApplyChange{Action: ApplyCreateFolder, Name: spec.Folder}
+		if !o.dryRun {
+			f, err := b.CreateFolder(ctx, spec.Folder)
+			if err != nil {
+				return result, fmt.Errorf("%s: %w", change, err)
+			}
+			change.ID = f.ID
+		}
+		folderIDs[spec.Folder] = change.ID
+		result.Changes = append(result.Changes, change)
+	}
+
+	for _, spec := range desired {
+		folderID, err := scope.folder(spec, folderIDs)
+		if err != nil {
+			return result, err
+		}
+		current, ok := live[*spec.Item.Name]
+		if !ok {
+			change := ApplyChange{Action: ApplyCreate, Name: *spec.Item.Name}
+			item, err := scope.newItem(spec, folderID)
+			if err != nil {
+				return result, err
+			}
+			if !o.dryRun {
+				created, err := b.CreateItem(ctx, item)
+				if err != nil {
+					return result, fmt.Errorf("%s: %w", change, err)
+				}
+				change.ID = created.ID
+			}
+			result.Changes = append(result.Changes, change)
+			continue
+		}
+
+		merged, err := mergeSpec(current, spec.Item, folderID, b.clock.Now())
+		if err != nil {
+			return result, err
+		}
+		if sameContent(current, merged) {
+			result.Unchanged++
+			continue
+		}
+		change := ApplyChange{Action: ApplyUpdate, Name: *spec.Item.Name, ID: current.ID}
+		if !o.dryRun {
+			if _, err := b.EditItem(ctx, merged); err != nil {
+				return result, fmt.Errorf("%s: %w", change, err)
+			}
+		}
+		result.Changes = append(result.Changes, change)
+	}
+
+	if !o.prune {
+		return result, nil
+	}
+	names := make([]string, 0, len(live))
+	for name := range live {
+		if !wanted[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		change := ApplyChange{Action: ApplyDelete, Name: name, ID: live[name].ID}
+		if !o.dryRun {
+			if err := b.DeleteItem(ctx, change.ID); err != nil {
+				return result, fmt.Errorf("%s: %w", change, err)
+			}
+		}
+		result.Changes = append(result.Changes, change)
+	}
+	return result, nil
+}
+
+// mergeSpec returns current with the content of desired, in the folder with
+// the ID folderID and in its own organization and collections. A changed
+// password goes through SetPasswordAt, so the old one ends up in the password
+// history; a nil password keeps the current one.
+func mergeSpec(current *Item, desired Item, folderID *string, now time.Time) (*Item, error) {
+	m := *current
+	m.Type = desired.Type
+	m.Name = desired.Name
+	m.Notes = desired.Notes
+	m.Favorite = desired.Favorite
+	m.Fields = desired.Fields
+	m.Reprompt = desired.Reprompt
+	m.SecureNote = desired.SecureNote
+	if m.Type == TypeSecureNote && m.SecureNote == nil {
+		m.SecureNote = &SecureNote{} // as CreateItem stores it
+	}
+	m.Card = desired.Card
+	m.Identity = desired.Identity
+	m.SSHKey = desired.SSHKey
+	m.FolderID = folderID
+
+	m.Login = nil
+	if desired.Login == nil {
+		return &m, nil
+	}
+	login := *desired.Login
+	login.Password = nil
+	login.PasswordRevisionDate = nil
+	if current.Login != nil {
+		login.Password = current.Login.Password
+		login.PasswordRevisionDate = current.Login.PasswordRevisionDate
+	}
+	m.Login = &login
+	if desired.Login.Password == nil {
+		return &m, nil
+	}
+	if err := m.SetPasswordAt(*desired.Login.Password, now); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// sameContent reports whether a and b are equal when null values and empty
+// lists are treated as missing.
+func sameContent(a, b *Item) bool {
+	return reflect.DeepEqual(normalizedJSON(a), normalizedJSON(b))
+}
+
+func normalizedJSON(item *Item) any {
+	data, _ := json.Marshal(item)
+	var v any
+	json.Unmarshal(data, &v)
+	return dropEmpty(v)
+}
+
+func dropEmpty(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, e := range t {
+			if e = dropEmpty(e); e == nil {
+				delete(t, k)
+			} else {
+				t[k] = e
+			}
+		}
+		if len(t) == 0 {
+			return nil
+		}
+	case []any:
+		for i := range t {
+			t[i] = dropEmpty(t[i])
+		}
+		if len(t) == 0 {
+			return nil
+		}
+	}
+	return v
+}
@@ -0,0 +1,185 @@
+package bitwarden
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestApply(t *testing.T) {
+	name := func(s string) *string { return &s }
+	password := "hunter2"
+
+	t.Run("Should plan changes without making them on a dry run", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items?folderid=6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a", ``))).
+			Return(listResponse(
+				`{"id":"a","type":1,"name":"same","folderId":"6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a","login":{"username":"u","uris":[]}},`+
+					`{"id":"b","type":1,"name":"changed","folderId":"6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a","login":{"password":"old"}},`+
+					`{"id":"c","type":1,"name":"stale","folderId":"6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a","login":{}}`), nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/folders", ``))).
+			Return(listResponse(``), nil).
+			Once()
+
+		result, err := bw.Apply(context.Background(), []ItemSpec{
+			{Item: Item{Type: TypeLogin, Name: name("same"), Login: &Login{Username: name("u")}}},
+			{Item: Item{Type: TypeLogin, Name: name("changed"), Login: &Login{Password: &password}}},
+			{Item: Item{Type: TypeSecureNote, Name: name("new")}},
+		}, WithDryRun(), WithPrune(), WithApplyScope(InFolder("6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a")))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []ApplyChange{
+			{Action: ApplyUpdate, Name: "changed", ID: "b"},
+			{Action: ApplyCreate, Name: "new"},
+			{Action: ApplyDelete, Name: "stale", ID: "c"},
+		}, result.Changes)
+		assert.Equal(t, 1, result.Unchanged)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("Should plan missing folders", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items", ``))).
+			Return(listResponse(``), nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/folders", ``))).
+			Return(listResponse(``), nil).
+			Once()
+
+		result, err := bw.Apply(context.Background(), []ItemSpec{
+			{Item: Item{Type: TypeSecureNote, Name: name("new")}, Folder: "infra"},
+		}, WithDryRun())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []ApplyChange{
+			{Action: ApplyCreateFolder, Name: "infra"},
+			{Action: ApplyCreate, Name: "new"},
+		}, result.Changes)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("Should create items in the scope", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+		orgID, collectionID := "0b7e5a4c-3d2f-4e1a-9c8b-7a6f5e4d3c2b", "b4d2c1e0-8f7a-4b6c-9d5e-3f2a1b0c9d8e"
+		otherOrg := "8f1c2d3e-4b5a-4c6d-9e8f-7a6b5c4d3e2f"
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items?collectionid="+collectionID+"&organizationid="+orgID, ``))).
+			Return(listResponse(``), nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/folders", ``))).
+			Return(listResponse(``), nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequestJSON(http.MethodPost, "http://localhost/object/item?organizationid="+orgID, func(body map[string]any) bool {
+				return body["organizationId"] == orgID && reflect.DeepEqual(body["collectionIds"], []any{collectionID}) && body["id"] == nil
+			}))).
+			Return(itemResponse("a"), nil).
+			Once()
+
+		_, err := bw.Apply(context.Background(), []ItemSpec{
+			{Item: Item{ID: "x", Type: TypeSecureNote, Name: name("new"), OrganizationID: &otherOrg, CollectionIDs: []string{"c"}}},
+		}, WithApplyScope(InOrganization(orgID), InCollection(collectionID)))
+
+		assert.NoError(t, err)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("Should refuse folders outside the scope", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items?folderid=6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a", ``))).
+			Return(listResponse(``), nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/folders", ``))).
+			Return(listResponse(``), nil).
+			Once()
+
+		_, err := bw.Apply(context.Background(), []ItemSpec{
+			{Item: Item{Type: TypeSecureNote, Name: name("new")}, Folder: "infra"},
+		}, WithApplyScope(InFolder("6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a")))
+
+		assert.ErrorIs(t, err, ErrInvalidSpec)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("Should not prune without WithPrune", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items?folderid=6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a", ``))).
+			Return(listResponse(`{"id":"c","type":1,"name":"stale","login":{}}`), nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/folders", ``))).
+			Return(listResponse(``), nil).
+			Once()
+
+		result, err := bw.Apply(context.Background(), nil, WithApplyScope(InFolder("6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a")))
+
+		assert.NoError(t, err)
+		assert.Empty(t, result.Changes)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("Should refuse to prune without a scope", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		_, err := bw.Apply(context.Background(), nil, WithPrune())
+
+		assert.ErrorIs(t, err, ErrInvalidApplyOptions)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("Should keep the password when the spec has none", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items", ``))).
+			Return(listResponse(`{"id":"a","type":1,"name":"db","login":{"username":"old","password":"hunter2"}}`), nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/folders", ``))).
+			Return(listResponse(``), nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequestJSON(http.MethodPut, "http://localhost/object/item/a", func(body map[string]any) bool {
+				login := body["login"].(map[string]any)
+				return login["username"] == "new" && login["password"] == "hunter2"
+			}))).
+			Return(itemResponse("a"), nil).
+			Once()
+
+		result, err := bw.Apply(context.Background(), []ItemSpec{
+			{Item: Item{Type: TypeLogin, Name: name("db"), Login: &Login{Username: name("new")}}},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []ApplyChange{{Action: ApplyUpdate, Name: "db", ID: "a"}}, result.Changes)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("Should reject duplicate and missing names", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		_, err := bw.Apply(context.Background(), []ItemSpec{{Item: Item{Name: name("a")}}, {Item: Item{Name: name("a")}}})
+		assert.ErrorIs(t, err, ErrInvalidSpec)
+		_, err = bw.Apply(context.Background(), []ItemSpec{{}})
+		assert.ErrorIs(t, err, ErrInvalidSpec)
+		client.AssertExpectations(t)
+	})
+}
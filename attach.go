@@ -0,0 +1,36 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	neturl "net/url"
+)
+
+// Attach connects to a bw serve that is already running at url and that
+// the caller, not this client, starts and stops: Close does not touch it.
+// Unlike NewFromURL, it checks up front that the serve answers and that
+// somebody is logged in, so a wrong URL or port fails here instead of at
+// the first secret. A locked vault is accepted; call Unlock before use.
+//
+// The version of bw is probed with the bw on the PATH if the serve runs on
+// this machine and WithCLIVersion is not set. If that fails, the version
+// stays unknown and features that need a newer bw are refused.
+func Attach(ctx context.Context, url string, opts ...Option) (*BitwardenServer, error) {
+	b := NewFromURL(url, opts...)
+	if b.urlErr != nil {
+		return nil, b.urlErr
+	}
+	status, err := b.Status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w at %s: %w", ErrServeNotRunning, url, err)
+	}
+	if err := status.err(); err != nil && !errors.Is(err, ErrVaultLocked) {
+		return nil, err
+	}
+	if u, err := neturl.Parse(url); err == nil && b.version == nil && isLoopback(u) {
+		b.version = &cliVersion{path: "bw"}
+	}
+	b.version.get(ctx) // probe once now; the error is returned again when a feature needs the version
+	return b, nil
+}
@@ -0,0 +1,55 @@
+package bitwarden
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttach(t *testing.T) {
+	serve := func(status string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"success":true,"data":{"object":"template","template":{"status":"` + status + `"}}}`))
+		}))
+	}
+
+	t.Run("Should attach to a running serve with a locked vault", func(t *testing.T) {
+		srv := serve("locked")
+		defer srv.Close()
+
+		bw, err := Attach(context.Background(), srv.URL, WithCLIVersion(Version{2024, 9, 0}))
+
+		assert.NoError(t, err)
+		assert.Nil(t, bw.cmd)
+		bw.Close()
+		_, err = bw.Status(context.Background())
+		assert.NoError(t, err, "Close must not stop an attached serve")
+	})
+
+	t.Run("Should refuse a serve without login", func(t *testing.T) {
+		srv := serve("unauthenticated")
+		defer srv.Close()
+
+		_, err := Attach(context.Background(), srv.URL)
+
+		assert.ErrorIs(t, err, ErrNotLoggedIn)
+	})
+
+	t.Run("Should fail when nothing is listening", func(t *testing.T) {
+		srv := serve("unlocked")
+		srv.Close()
+
+		_, err := Attach(context.Background(), srv.URL)
+
+		assert.ErrorIs(t, err, ErrServeNotRunning)
+	})
+
+	t.Run("Should refuse insecure remote URLs", func(t *testing.T) {
+		_, err := Attach(context.Background(), "http://vault.example.com:8087")
+
+		assert.ErrorIs(t, err, ErrInsecureRemote)
+	})
+}
@@ -0,0 +1,289 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var ErrAttachmentCorrupt = errors.New("attachment is corrupt")
+
+type downloadOptions struct {
+	verifySize bool
+	hash       crypto.Hash
+	sum        []byte
+	buf        []byte
+}
+
+// DownloadOption configures DownloadAttachment.
+type DownloadOption func(*downloadOptions)
+
+// VerifySize checks that the downloaded attachment matches the size declared
+// in the item, which costs a lookup of the item. The declared size is that
+// of the encrypted attachment, so the downloaded plaintext is checked
+// against it with EncryptedAttachmentSize.
+func VerifySize() DownloadOption {
+	return func(o *downloadOptions) { o.verifySize = true }
+}
+
+// VerifyChecksum checks that the downloaded attachment has the given
+// checksum, for example VerifyChecksum(crypto.SHA256, sum). The hash must
+// be linked into the binary.
+func VerifyChecksum(h crypto.Hash, sum []byte) DownloadOption {
+	return func(o *downloadOptions) { o.hash, o.sum = h, sum }
+}
+
+// WithCopyBuffer makes DownloadAttachmentTo and DownloadAttachmentToFile
+// copy the attachment in chunks through buf, so concurrent downloads can
+// reuse buffers and their memory is bounded by the size of buf. Without it,
+// the attachment is copied with io.Copy, which lets a destination that
+// implements io.ReaderFrom copy in its own way.
+func WithCopyBuffer(buf []byte) DownloadOption {
+	if len(buf) == 0 {
+		buf = nil // io.CopyBuffer refuses empty buffers
+	}
+	return func(o *downloadOptions) { o.buf = buf }
+}
+
+// DownloadAttachment returns the contents of an attachment. The caller must
+// close the returned reader.
+//
+// With VerifySize or VerifyChecksum, reading the attachment fails with
+// ErrAttachmentCorrupt instead of io.EOF when it does not match, so the
+// contents must not be used before the reader returned io.EOF.
+func (b *BitwardenServer) DownloadAttachment(ctx context.Context, itemID string, attachmentID string, opts ...DownloadOption) (io.ReadCloser, error) {
+	o := downloadOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.hash != 0 && !o.hash.Available() {
+		return nil, fmt.Errorf("hash %s is not available", o.hash)
+	}
+	size := int64(-1)
+	if o.verifySize {
+		var err error
+		if size, err = b.attachmentSize(ctx, itemID, attachmentID); err != nil {
+			return nil, err
+		}
+	}
+
+	endpoint := "/object/attachment/" + url.PathEscape(attachmentID) + "?itemid=" + url.QueryEscape(itemID)
+	ctx, span := b.startSpan(ctx, "DownloadAttachment", attribute.String("bitwarden.item_id", itemID))
+	r, err := b.do(ctx, http.MethodGet, endpoint, nil)
+	b.record(ctx, AuditRead, itemID, nil, err)
+	if endSpan(span, err) != nil {
+		return nil, err
+	}
+	if size < 0 && o.hash == 0 {
+		return r.Body, nil
+	}
+	v := &verifyingReader{ReadCloser: r.Body, size: size, sum: o.sum}
+	if o.hash != 0 {
+		v.hash = o.hash.New()
+	}
+	return v, nil
+}
+
+// DownloadAttachmentTo copies an attachment to w and returns the number of
+// bytes copied. opts are passed to DownloadAttachment; if a verification
+// fails, w may hold a partial or corrupt attachment.
+func (b *BitwardenServer) DownloadAttachmentTo(ctx context.Context, itemID, attachmentID string, w io.Writer, opts ...DownloadOption) (int64, error) {
+	o := downloadOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	r, err := b.DownloadAttachment(ctx, itemID, attachmentID, opts...)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+	return copyBuffer(w, r, o.buf)
+}
+
+// EncryptedAttachmentSize returns the size Bitwarden stores and declares for
+// an attachment of n plaintext bytes: one byte for the encryption type, a
+// 16 byte IV, a 32 byte MAC and the AES-CBC ciphertext, which pads n to the
+// next multiple of 16.
+func EncryptedAttachmentSize(n int64) int64 {
+	return 1 + 16 + 32 + (n/16+1)*16
+}
+
+// attachmentSize returns the declared size of an attachment.
+func (b *BitwardenServer) attachmentSize(ctx context.Context, itemID string, attachmentID string) (int64, error) {
+	attachments, err := b.ListAttachments(ctx, itemID)
+	if err != nil {
+		return 0, err
+	}
+	for _, a := range attachments {
+		if a.ID == attachmentID {
+			size, err := strconv.ParseInt(a.Size, 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("%w: invalid size %q", ErrAttachmentCorrupt, a.Size)
+			}
+			return size, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: attachment %s", ErrNotFound, attachmentID)
+}
+
+// verifyingReader checks the size and checksum of an attachment when it
+// reaches the end. size is the declared encrypted size; a negative size or
+// nil hash is not checked.
+type verifyingReader struct {
+	io.ReadCloser
+	size int64
+	read int64
+	hash hash.Hash
+	sum  []byte
+}
+
+func (r *verifyingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.read += int64(n)
+	if r.hash != nil {
+		r.hash.Write(p[:n])
+	}
+	if r.size >= 0 && EncryptedAttachmentSize(r.read) > r.size {
+		return n, fmt.Errorf("%w: more than the declared %d encrypted bytes", ErrAttachmentCorrupt, r.size)
+	}
+	if err == io.EOF {
+		if r.size >= 0 && EncryptedAttachmentSize(r.read) != r.size {
+			return n, fmt.Errorf("%w: got %d bytes, which encrypt to %d, declared %d", ErrAttachmentCorrupt, r.read, EncryptedAttachmentSize(r.read), r.size)
+		}
+		if r.hash != nil && !bytes.Equal(r.hash.Sum(nil), r.sum) {
+			return n, fmt.Errorf("%w: checksum mismatch", ErrAttachmentCorrupt)
+		}
+	}
+	return n, err
+}
+
+// ListAttachments returns the metadata of the attachments of an item, such
+// as the IDs to pass to DownloadAttachment.
+func (b *BitwardenServer) ListAttachments(ctx context.Context, itemID string) ([]Attachment, error) {
+	item, err := b.GetItem(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	return item.Attachments, nil
+}
+
+// DownloadAttachmentToFile writes an attachment to path with the given mode,
+// or 0600 if mode is 0. The attachment is written to a temporary file next
+// to path, synced and then renamed, so path never holds a partial or
+// world-readable secret. opts are passed to DownloadAttachment; if a
+// verification fails, path is left alone.
+func (b *BitwardenServer) DownloadAttachmentToFile(ctx context.Context, itemID, attachmentID, path string, mode fs.FileMode, opts ...DownloadOption) error {
+	if mode == 0 {
+		mode = 0o600
+	}
+	o := downloadOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	r, err := b.DownloadAttachment(ctx, itemID, attachmentID, opts...)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // fails harmlessly after the rename
+	if err := writeFile(tmp, r, mode, o.buf); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+	if d, err := os.Open(dir); err == nil { // persist the rename
+		d.Sync()
+		d.Close()
+	}
+	return nil
+}
+
+// writeFile sets the mode of f, copies r into it through buf, syncs and
+// closes it.
+func writeFile(f *os.File, r io.Reader, mode fs.FileMode, buf []byte) error {
+	err := f.Chmod(mode)
+	if err == nil {
+		_, err = copyBuffer(f, r, buf)
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// UploadAttachment attaches the contents of r to the item as fileName and
+// returns the updated item. r is streamed to the server, so the attachment
+// is never held in memory as a whole; as a consequence the upload is not
+// retried.
+func (b *BitwardenServer) UploadAttachment(ctx context.Context, itemID, fileName string, r io.Reader) (*Item, error) {
+	pr, pw := io.Pipe()
+	defer pr.Close() // stops the writer if the request fails before reading it all
+	mw := multipart.NewWriter(pw)
+	go func() {
+		part, err := mw.CreateFormFile("file", fileName)
+		if err == nil {
+			_, err = io.Copy(part, r)
+		}
+		if err == nil {
+			err = mw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	ctx, span := b.startSpan(ctx, "UploadAttachment", attribute.String("bitwarden.item_id", itemID))
+	resp, err := b.send(ctx, http.MethodPost, "/attachment?itemid="+url.QueryEscape(itemID), mw.FormDataContentType(), pr)
+	if err != nil {
+		b.record(ctx, AuditUpdate, itemID, nil, err)
+		return nil, endSpan(span, err)
+	}
+	defer closeBody(resp)
+	data := struct {
+		Data Item `json:"data"`
+	}{}
+	raw, err := io.ReadAll(resp.Body)
+	if err == nil {
+		err = b.payloads.unmarshal(raw, &data)
+	}
+	b.record(ctx, AuditUpdate, itemID, &data.Data, err)
+	if err != nil {
+		return nil, endSpan(span, err)
+	}
+	b.cache.put(itemID, &data.Data)
+	return &data.Data, endSpan(span, nil)
+}
+
+// DeleteAttachment removes an attachment from the item.
+func (b *BitwardenServer) DeleteAttachment(ctx context.Context, itemID, attachmentID string) error {
+	endpoint := "/object/attachment/" + url.PathEscape(attachmentID) + "?itemid=" + url.QueryEscape(itemID)
+	ctx, span := b.startSpan(ctx, "DeleteAttachment", attribute.String("bitwarden.item_id", itemID))
+	err := endSpan(span, b.request(ctx, http.MethodDelete, endpoint, nil, nil))
+	b.record(ctx, AuditUpdate, itemID, nil, err)
+	if err != nil {
+		return err
+	}
+	b.cache.remove(itemID)
+	return nil
+}
@@ -0,0 +1,305 @@
+package bitwarden
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDownloadAttachment(t *testing.T) {
+	t.Run("Should return the attachment contents", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/attachment/att1?itemid=item1", ``))).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader("-----BEGIN CERTIFICATE-----"))}, nil).
+			Once()
+
+		r, err := bw.DownloadAttachment(context.Background(), "item1", "att1")
+		assert.NoError(t, err)
+		data, err := io.ReadAll(r)
+		r.Close()
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "-----BEGIN CERTIFICATE-----", string(data))
+	})
+
+	t.Run("Should return request errors", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 404}, nil).
+			Once()
+
+		_, err := bw.DownloadAttachment(context.Background(), "item1", "att1")
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+// chunkWriter records the size of every write.
+type chunkWriter struct {
+	data   []byte
+	chunks []int
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	w.data = append(w.data, p...)
+	w.chunks = append(w.chunks, len(p))
+	return len(p), nil
+}
+
+func TestDownloadAttachmentTo(t *testing.T) {
+	t.Run("Should copy the attachment through the buffer", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+		contents := strings.Repeat("0123456789", 10)
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/attachment/att1?itemid=item1", ``))).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(contents))}, nil).
+			Once()
+
+		var w chunkWriter
+		n, err := bw.DownloadAttachmentTo(context.Background(), "item1", "att1", &w, WithCopyBuffer(make([]byte, 16)))
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(100), n)
+		assert.Equal(t, contents, string(w.data))
+		for _, size := range w.chunks {
+			assert.LessOrEqual(t, size, 16)
+		}
+	})
+
+	t.Run("Should fail on a verification mismatch", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+		sum := sha256.Sum256([]byte("expected"))
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader("tampered"))}, nil).
+			Once()
+
+		_, err := bw.DownloadAttachmentTo(context.Background(), "item1", "att1", io.Discard, VerifyChecksum(crypto.SHA256, sum[:]))
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrAttachmentCorrupt)
+	})
+}
+
+func TestListAttachments(t *testing.T) {
+	t.Run("Should return the attachments of the item", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/item1", ``))).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(`{"data":{"id":"item1","type":2,"attachments":[{"id":"att1","fileName":"cert.pem","size":"1024","sizeName":"1 KB","url":"https://cdn.example.com/att1"}]}}`))}, nil).
+			Once()
+
+		attachments, err := bw.ListAttachments(context.Background(), "item1")
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, []Attachment{{ID: "att1", FileName: "cert.pem", Size: "1024", SizeName: "1 KB", URL: "https://cdn.example.com/att1"}}, attachments)
+	})
+}
+
+func TestDownloadAttachmentVerification(t *testing.T) {
+	itemRequest := mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/item1", ``))
+	itemWithAttachment := func(size string) *http.Response {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(`{"data":{"id":"item1","type":2,"attachments":[{"id":"att1","fileName":"cert.pem","size":"` + size + `"}]}}`))}
+	}
+	attachmentRequest := mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/attachment/att1?itemid=item1", ``))
+	attachment := func() *http.Response {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader("config"))}
+	}
+	sum := sha256.Sum256([]byte("config"))
+
+	t.Run("Should accept attachments of the declared size and checksum", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.On("Do", itemRequest).Return(itemWithAttachment("65"), nil).Once() // 6 bytes encrypted
+		client.On("Do", attachmentRequest).Return(attachment(), nil).Once()
+
+		r, err := bw.DownloadAttachment(context.Background(), "item1", "att1", VerifySize(), VerifyChecksum(crypto.SHA256, sum[:]))
+		assert.NoError(t, err)
+		data, err := io.ReadAll(r)
+		r.Close()
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "config", string(data))
+	})
+
+	t.Run("Should fail on a size mismatch", func(t *testing.T) {
+		for _, size := range []string{"6", "49", "81"} {
+			bw, client := newTestBitwarden()
+
+			client.On("Do", itemRequest).Return(itemWithAttachment(size), nil).Once()
+			client.On("Do", attachmentRequest).Return(attachment(), nil).Once()
+
+			r, err := bw.DownloadAttachment(context.Background(), "item1", "att1", VerifySize())
+			assert.NoError(t, err)
+			_, err = io.ReadAll(r)
+			r.Close()
+
+			client.AssertExpectations(t)
+			assert.ErrorIs(t, err, ErrAttachmentCorrupt, size)
+		}
+	})
+
+	t.Run("Should fail on a checksum mismatch", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.On("Do", attachmentRequest).Return(attachment(), nil).Once()
+
+		other := sha256.Sum256([]byte("other"))
+		r, err := bw.DownloadAttachment(context.Background(), "item1", "att1", VerifyChecksum(crypto.SHA256, other[:]))
+		assert.NoError(t, err)
+		_, err = io.ReadAll(r)
+		r.Close()
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrAttachmentCorrupt)
+	})
+
+	t.Run("Should fail for unknown attachments", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.On("Do", itemRequest).Return(itemWithAttachment("65"), nil).Once()
+
+		_, err := bw.DownloadAttachment(context.Background(), "item1", "att2", VerifySize())
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestDownloadAttachmentToFile(t *testing.T) {
+	attachmentRequest := mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/attachment/att1?itemid=item1", ``))
+	attachment := func() *http.Response {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader("config"))}
+	}
+
+	t.Run("Should write the attachment with restrictive permissions", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+		path := filepath.Join(t.TempDir(), "app.conf")
+
+		client.On("Do", attachmentRequest).Return(attachment(), nil).Once()
+
+		err := bw.DownloadAttachmentToFile(context.Background(), "item1", "att1", path, 0)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		data, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, "config", string(data))
+		if runtime.GOOS != "windows" {
+			info, err := os.Stat(path)
+			assert.NoError(t, err)
+			assert.Equal(t, fs.FileMode(0o600), info.Mode().Perm())
+		}
+	})
+
+	t.Run("Should leave the file alone if verification fails", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+		dir := t.TempDir()
+		path := filepath.Join(dir, "app.conf")
+		assert.NoError(t, os.WriteFile(path, []byte("old"), 0o600))
+
+		client.On("Do", attachmentRequest).Return(attachment(), nil).Once()
+
+		sum := sha256.Sum256([]byte("other"))
+		err := bw.DownloadAttachmentToFile(context.Background(), "item1", "att1", path, 0o640, VerifyChecksum(crypto.SHA256, sum[:]))
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrAttachmentCorrupt)
+		data, _ := os.ReadFile(path)
+		assert.Equal(t, "old", string(data))
+		entries, _ := os.ReadDir(dir)
+		assert.Len(t, entries, 1, "temporary file is removed")
+	})
+}
+
+func TestUploadAttachment(t *testing.T) {
+	t.Run("Should post the file as multipart form data", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(func(req *http.Request) bool {
+				if req.Method != http.MethodPost || req.URL.String() != "http://localhost/attachment?itemid=item1" {
+					return false
+				}
+				file, header, err := req.FormFile("file")
+				if err != nil {
+					return false
+				}
+				data, _ := io.ReadAll(file)
+				return header.Filename == "tls.crt" && string(data) == "certificate"
+			})).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(`{"data":{"id":"item1","attachments":[{"id":"att1","fileName":"tls.crt","size":"11"}]}}`))}, nil).
+			Once()
+
+		item, err := bw.UploadAttachment(context.Background(), "item1", "tls.crt", strings.NewReader("certificate"))
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, []Attachment{{ID: "att1", FileName: "tls.crt", Size: "11"}}, item.Attachments)
+	})
+
+	t.Run("Should stream the file and fail when reading it fails", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+		readErr := errors.New("disk on fire")
+
+		client.
+			On("Do", mock.Anything).
+			Return(func(req *http.Request) (*http.Response, error) {
+				_, err := io.ReadAll(req.Body)
+				return nil, err
+			}).
+			Once()
+
+		_, err := bw.UploadAttachment(context.Background(), "item1", "tls.crt", iotest.ErrReader(readErr))
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, readErr)
+	})
+}
+
+func TestDeleteAttachment(t *testing.T) {
+	t.Run("Should delete the attachment of the item", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodDelete, "http://localhost/object/attachment/att1?itemid=item1", ``))).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(`{"success":true}`))}, nil).
+			Once()
+
+		err := bw.DeleteAttachment(context.Background(), "item1", "att1")
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+}
+
+func TestEncryptedAttachmentSize(t *testing.T) {
+	for n, want := range map[int64]int64{0: 65, 6: 65, 15: 65, 16: 81, 26: 81, 1024: 1089} {
+		assert.Equal(t, want, EncryptedAttachmentSize(n), n)
+	}
+}
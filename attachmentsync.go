@@ -0,0 +1,159 @@
+package bitwarden
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// AttachmentChecksumField is the prefix of the hidden fields in which
+// SyncAttachments records, per file name, the ID of the attachment and the
+// SHA-256 checksum of the file it was uploaded from, as "<id> <checksum>".
+const AttachmentChecksumField = "attachment-sha256:"
+
+// SyncAttachments mirrors the regular files directly in dir to the
+// attachments of the item, which makes the item a small bucket for files
+// such as certificates. Bitwarden only knows the encrypted size of an
+// attachment, so the checksum of every uploaded file is kept in a field of
+// the item (see AttachmentChecksumField). Files without an attachment of the
+// same name and checksum are uploaded, and then attachments without such a
+// file are deleted, so a changed file is never missing. Subdirectories are
+// ignored.
+//
+// It stops at the first error; running it again continues where it left
+// off, although a file whose checksum was not yet recorded is uploaded
+// again.
+func (b *BitwardenServer) SyncAttachments(ctx context.Context, itemID, dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	files := map[string]string{}
+	var names []string
+	for _, e := range entries {
+		if !e.Type().IsRegular() {
+			continue
+		}
+		sum, err := fileChecksum(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return err
+		}
+		files[e.Name()] = sum
+		names = append(names, e.Name())
+	}
+
+	item, err := b.fetchItem(ctx, itemID)
+	if err != nil {
+		return err
+	}
+	recorded := attachmentChecksums(item)
+	synced := map[string]bool{}
+	known := map[string]bool{}
+	var stale []Attachment
+	for _, a := range item.Attachments {
+		known[a.ID] = true
+		sum, ok := files[a.FileName]
+		if ok && !synced[a.FileName] && recorded[a.FileName] == a.ID+" "+sum {
+			synced[a.FileName] = true
+			continue
+		}
+		stale = append(stale, a)
+	}
+
+	uploaded := map[string]string{}
+	for _, name := range names {
+		if synced[name] {
+			continue
+		}
+		id, err := b.uploadFile(ctx, itemID, filepath.Join(dir, name), known)
+		if err != nil {
+			return fmt.Errorf("upload %s: %w", name, err)
+		}
+		uploaded[name] = id + " " + files[name]
+	}
+	for _, a := range stale {
+		if err := b.DeleteAttachment(ctx, itemID, a.ID); err != nil {
+			return fmt.Errorf("delete %s: %w", a.FileName, err)
+		}
+	}
+	return b.recordChecksums(ctx, itemID, files, uploaded)
+}
+
+// recordChecksums stores the checksums of the uploaded files and removes
+// those of files that no longer exist.
+func (b *BitwardenServer) recordChecksums(ctx context.Context, itemID string, files, uploaded map[string]string) error {
+	if len(uploaded) == 0 {
+		return nil
+	}
+	item, err := b.fetchItem(ctx, itemID)
+	if err != nil {
+		return err
+	}
+	for name := range attachmentChecksums(item) {
+		if _, ok := files[name]; !ok {
+			item.DeleteField(AttachmentChecksumField + name)
+		}
+	}
+	for name, value := range uploaded {
+		item.setField(AttachmentChecksumField+name, value, FieldHidden, b.clock.Now())
+	}
+	_, err = b.EditItem(ctx, item)
+	return err
+}
+
+// attachmentChecksums returns the recorded "<id> <checksum>" per file name.
+func attachmentChecksums(item *Item) map[string]string {
+	sums := map[string]string{}
+	for _, f := range item.Fields {
+		if name, ok := strings.CutPrefix(f.Name, AttachmentChecksumField); ok {
+			sums[name] = f.Value
+		}
+	}
+	return sums
+}
+
+// fileChecksum returns the hex encoded SHA-256 checksum of a file.
+func fileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// uploadFile uploads a file and returns the ID of the new attachment, the
+// one of that name that is not in known. known is updated with the
+// attachments of the item.
+func (b *BitwardenServer) uploadFile(ctx context.Context, itemID, path string, known map[string]bool) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	name := filepath.Base(path)
+	item, err := b.UploadAttachment(ctx, itemID, name, f)
+	if err != nil {
+		return "", err
+	}
+	id := ""
+	for _, a := range item.Attachments {
+		if !known[a.ID] && a.FileName == name {
+			id = a.ID
+		}
+		known[a.ID] = true
+	}
+	if id == "" {
+		return "", fmt.Errorf("%w: attachment %s", ErrNotFound, name)
+	}
+	return id, nil
+}
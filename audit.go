@@ -0,0 +1,109 @@
+package bitwarden
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	AuditRead   AuditAction = "read"
+	AuditCreate AuditAction = "create"
+	AuditUpdate AuditAction = "update"
+	AuditDelete AuditAction = "delete"
+)
+
+// AuditEntry records an access to a vault item. It never contains secrets.
+type AuditEntry struct {
+	Time     time.Time   `json:"time"`
+	Action   AuditAction `json:"action"`
+	ItemID   string      `json:"itemId"`
+	ItemName string      `json:"itemName,omitempty"`
+	// Caller is the label set with ContextWithCaller, if any.
+	Caller string `json:"caller,omitempty"`
+	// Error is the error of a failed access.
+	Error string `json:"error,omitempty"`
+}
+
+// AuditSink receives audit entries. Record is called synchronously for every
+// access, so slow sinks should buffer. Implementations must be safe for
+// concurrent use.
+type AuditSink interface {
+	Record(ctx context.Context, e AuditEntry) error
+}
+
+// AuditFunc makes a function an AuditSink, for example to send entries to an
+// HTTP endpoint.
+type AuditFunc func(ctx context.Context, e AuditEntry) error
+
+func (f AuditFunc) Record(ctx context.Context, e AuditEntry) error {
+	return f(ctx, e)
+}
+
+// WithAudit records every read and write of an item to sink: items returned
+// by GetItem, from the cache or the server, and by ListItems, items handed
+// out by Watch and to OnItemChange callbacks, downloaded attachments and
+// created, edited and deleted items. The snapshots Watch takes to detect
+// changes are not recorded, only the items it delivers. Errors of the sink
+// are logged if a logger is set, but never fail the access.
+func WithAudit(sink AuditSink) Option {
+	return func(b *BitwardenServer) { b.audit = sink }
+}
+
+// NewAuditWriter returns a sink that writes entries to w as JSON lines, for
+// example to an append-only file.
+func NewAuditWriter(w io.Writer) AuditSink {
+	return &auditWriter{enc: json.NewEncoder(w)}
+}
+
+type auditWriter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func (a *auditWriter) Record(_ context.Context, e AuditEntry) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.enc.Encode(e)
+}
+
+// NewAuditChannel returns a sink that sends entries to ch. Record blocks
+// until the entry is received or ctx is done.
+func NewAuditChannel(ch chan<- AuditEntry) AuditSink {
+	return AuditFunc(func(ctx context.Context, e AuditEntry) error {
+		select {
+		case ch <- e:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// record sends an audit entry for the access to the item with the given ID.
+// item may be nil if the access failed.
+func (b *BitwardenServer) record(ctx context.Context, action AuditAction, id string, item *Item, err error) {
+	if b.audit == nil {
+		return
+	}
+	e := AuditEntry{Time: b.clock.Now(), Action: action, ItemID: id}
+	if item != nil {
+		if item.ID != "" {
+			e.ItemID = item.ID
+		}
+		if item.Name != nil {
+			e.ItemName = *item.Name
+		}
+	}
+	e.Caller = CallerFromContext(ctx)
+	if err != nil {
+		e.Error = err.Error()
+	}
+	if err := b.audit.Record(ctx, e); err != nil && b.logger != nil {
+		b.logger.ErrorContext(ctx, "bitwarden audit failed", "action", action, "item", e.ItemID, "error", err)
+	}
+}
@@ -0,0 +1,193 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type auditLog struct {
+	mu      sync.Mutex
+	entries []AuditEntry
+}
+
+func (a *auditLog) Record(_ context.Context, e AuditEntry) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	e.Time = time.Time{}
+	a.entries = append(a.entries, e)
+	return nil
+}
+
+func TestWithAudit(t *testing.T) {
+	itemID := "382a9d7b-f6b5-4eaa-92a1-1f3c7d89e48f"
+
+	t.Run("Should record reads from the server and the cache with the caller", func(t *testing.T) {
+		log := &auditLog{}
+		bw, client := newTestBitwarden(WithAudit(log), WithCache(time.Minute))
+
+		client.
+			On("Do", mock.Anything).
+			Return(itemResponse(itemID), nil).
+			Once()
+
+		ctx := ContextWithCaller(context.Background(), "billing")
+		for i := 0; i < 2; i++ {
+			_, err := bw.GetSecureNote(ctx, itemID)
+			assert.NoError(t, err)
+		}
+
+		client.AssertExpectations(t)
+		entry := AuditEntry{Action: AuditRead, ItemID: itemID, ItemName: "ENV", Caller: "billing"}
+		assert.Equal(t, []AuditEntry{entry, entry}, log.entries)
+	})
+
+	t.Run("Should record failed reads", func(t *testing.T) {
+		log := &auditLog{}
+		bw, client := newTestBitwarden(WithAudit(log))
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 404}, nil).
+			Once()
+
+		_, err := bw.GetItem(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrNotFound)
+		if assert.Len(t, log.entries, 1) {
+			assert.Equal(t, itemID, log.entries[0].ItemID)
+			assert.Contains(t, log.entries[0].Error, "item not found")
+		}
+	})
+
+	t.Run("Should record writes", func(t *testing.T) {
+		log := &auditLog{}
+		bw, client := newTestBitwarden(WithAudit(log))
+
+		client.
+			On("Do", mock.MatchedBy(func(req *http.Request) bool { return req.Method == http.MethodPost })).
+			Return(itemResponse(itemID), nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(func(req *http.Request) bool { return req.Method == http.MethodDelete })).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{}`))}, nil).
+			Once()
+
+		name := "ENV"
+		_, err := bw.CreateItem(context.Background(), &Item{Type: TypeSecureNote, Name: &name})
+		assert.NoError(t, err)
+		assert.NoError(t, bw.DeleteItem(context.Background(), itemID))
+
+		client.AssertExpectations(t)
+		assert.Equal(t, []AuditEntry{
+			{Action: AuditCreate, ItemID: itemID, ItemName: "ENV"},
+			{Action: AuditDelete, ItemID: itemID},
+		}, log.entries)
+	})
+
+	t.Run("Should record every listed item", func(t *testing.T) {
+		log := &auditLog{}
+		bw, client := newTestBitwarden(WithAudit(log))
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"data":{"data":[{"id":"1"},{"id":"2"}]}}`))}, nil).
+			Once()
+
+		_, err := bw.ListItems(context.Background())
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, []AuditEntry{{Action: AuditRead, ItemID: "1"}, {Action: AuditRead, ItemID: "2"}}, log.entries)
+	})
+
+	t.Run("Should record items delivered by Watch and OnItemChange", func(t *testing.T) {
+		log := &auditLog{}
+		clock := newFakeClock()
+		bw, client := newTestBitwarden(WithAudit(log), WithClock(clock))
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodPost, "http://localhost/sync", `{}`))).
+			Return(func(*http.Request) (*http.Response, error) { return &http.Response{StatusCode: 200}, nil })
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items", ``))).
+			Return(listResponse(`{"id":"a","revisionDate":"2023-01-01T00:00:00Z"}`), nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items", ``))).
+			Return(listResponse(`{"id":"a","revisionDate":"2023-02-01T00:00:00Z"},{"id":"b","revisionDate":"2023-01-01T00:00:00Z"}`), nil).
+			Once()
+
+		bw.OnItemChange("a", func(*Item) {})
+		ctx, cancel := context.WithCancel(ContextWithCaller(context.Background(), "watcher"))
+		events, err := bw.Watch(ctx, time.Minute)
+		assert.NoError(t, err)
+		clock.Advance(time.Minute)
+		<-events
+		<-events
+		cancel()
+		for range events {
+		}
+
+		client.AssertExpectations(t)
+		assert.ElementsMatch(t, []AuditEntry{
+			{Action: AuditRead, ItemID: "a", Caller: "watcher"},
+			{Action: AuditRead, ItemID: "a", Caller: "watcher"},
+			{Action: AuditRead, ItemID: "b", Caller: "watcher"},
+		}, log.entries)
+	})
+
+	t.Run("Should not fail the access when the sink fails", func(t *testing.T) {
+		sink := AuditFunc(func(context.Context, AuditEntry) error { return errors.New("disk full") })
+		bw, client := newTestBitwarden(WithAudit(sink))
+
+		client.
+			On("Do", mock.Anything).
+			Return(itemResponse(itemID), nil).
+			Once()
+
+		_, err := bw.GetItem(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+}
+
+func TestAuditSinks(t *testing.T) {
+	entry := AuditEntry{Time: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC), Action: AuditRead, ItemID: "1", Caller: "job"}
+
+	t.Run("Should write JSON lines", func(t *testing.T) {
+		var buf bytes.Buffer
+		sink := NewAuditWriter(&buf)
+
+		assert.NoError(t, sink.Record(context.Background(), entry))
+		assert.NoError(t, sink.Record(context.Background(), entry))
+
+		line, _ := json.Marshal(entry)
+		assert.Equal(t, string(line)+"\n"+string(line)+"\n", buf.String())
+		assert.JSONEq(t, `{"time":"2023-01-02T03:04:05Z","action":"read","itemId":"1","caller":"job"}`, string(line))
+	})
+
+	t.Run("Should send to a channel until the context is done", func(t *testing.T) {
+		ch := make(chan AuditEntry, 1)
+		sink := NewAuditChannel(ch)
+
+		assert.NoError(t, sink.Record(context.Background(), entry))
+		assert.Equal(t, entry, <-ch)
+
+		ch <- entry
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.ErrorIs(t, sink.Record(ctx, entry), context.Canceled)
+	})
+}
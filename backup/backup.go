@@ -0,0 +1,299 @@
+// Package backup exports the vault on a schedule. Every run writes one
+// export to a Destination, such as a directory or an uploader to object
+// storage, and removes the oldest exports beyond the retention count when the
+// Destination can list them.
+package backup
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+)
+
+// Exporter is implemented by bitwarden.CLI.
+type Exporter interface {
+	Export(ctx context.Context, format bitwarden.ExportFormat, w io.Writer, opts ...bitwarden.ExportOption) error
+}
+
+// Destination stores exports.
+type Destination interface {
+	// Create returns a writer for the export with the given name. The export
+	// is complete when the writer is closed without an error.
+	Create(ctx context.Context, name string) (io.WriteCloser, error)
+}
+
+// Pruner can be implemented by a Destination to apply the retention count.
+type Pruner interface {
+	// List returns the names of the stored exports.
+	List(ctx context.Context) ([]string, error)
+	Remove(ctx context.Context, name string) error
+}
+
+// Schedule returns the time of the next run after the given time.
+type Schedule interface {
+	Next(after time.Time) time.Time
+}
+
+type every time.Duration
+
+func (e every) Next(after time.Time) time.Time { return after.Add(time.Duration(e)) }
+
+// Every runs a backup every d.
+func Every(d time.Duration) Schedule { return every(d) }
+
+// Result describes one run.
+type Result struct {
+	// Name is the name of the export in the Destination.
+	Name     string
+	Time     time.Time
+	Size     int64
+	Duration time.Duration
+	// Removed lists the exports that were removed by the retention count.
+	Removed []string
+	Err     error
+}
+
+// namePrefix starts the names of all exports, so Pruner only considers the
+// exports of this package.
+const namePrefix = "bitwarden-export-"
+
+// Backup runs exports on a schedule.
+type Backup struct {
+	exporter   Exporter
+	dest       Destination
+	schedule   Schedule
+	retention  int
+	format     bitwarden.ExportFormat
+	exportOpts []bitwarden.ExportOption
+	onSuccess  func(Result)
+	onFailure  func(Result)
+	now        func() time.Time
+}
+
+// Option configures optional behaviour of a Backup.
+type Option func(*Backup)
+
+// WithSchedule sets when backups run. Defaults to Every(24 * time.Hour).
+func WithSchedule(s Schedule) Option {
+	return func(b *Backup) { b.schedule = s }
+}
+
+// WithRetention keeps only the newest n exports. Defaults to 0, which keeps
+// all of them. It is only applied if the Destination implements Pruner.
+func WithRetention(n int) Option {
+	return func(b *Backup) { b.retention = n }
+}
+
+// WithFormat sets the export format and options. Defaults to
+// bitwarden.ExportEncryptedJSON, so the backups are never in plain text.
+func WithFormat(format bitwarden.ExportFormat, opts ...bitwarden.ExportOption) Option {
+	return func(b *Backup) {
+		b.format = format
+		b.exportOpts = opts
+	}
+}
+
+// OnSuccess calls f after every successful run.
+func OnSuccess(f func(Result)) Option {
+	return func(b *Backup) { b.onSuccess = f }
+}
+
+// OnFailure calls f after every failed run.
+func OnFailure(f func(Result)) Option {
+	return func(b *Backup) { b.onFailure = f }
+}
+
+func New(e Exporter, dest Destination, opts ...Option) *Backup {
+	b := &Backup{
+		exporter: e,
+		dest:     dest,
+		schedule: Every(24 * time.Hour),
+		format:   bitwarden.ExportEncryptedJSON,
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// Run backs up the vault on the schedule until ctx is done and returns the
+// error of ctx. The first backup runs immediately. A failed run does not stop
+// the next ones; use OnFailure to be told about them.
+func (b *Backup) Run(ctx context.Context) error {
+	for {
+		res := b.RunOnce(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		timer := time.NewTimer(time.Until(b.schedule.Next(res.Time)))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// RunOnce writes one export to the Destination and applies the retention
+// count. Nothing is written if the export fails.
+func (b *Backup) RunOnce(ctx context.Context) Result {
+	start := b.now()
+	res := Result{Name: b.name(start), Time: start}
+	res.Removed, res.Err = b.run(ctx, &res)
+	res.Duration = b.now().Sub(start)
+
+	if res.Err != nil {
+		if b.onFailure != nil {
+			b.onFailure(res)
+		}
+	} else if b.onSuccess != nil {
+		b.onSuccess(res)
+	}
+	return res
+}
+
+func (b *Backup) run(ctx context.Context, res *Result) ([]string, error) {
+	var buf bytes.Buffer
+	if err := b.exporter.Export(ctx, b.format, &buf, b.exportOpts...); err != nil {
+		return nil, err
+	}
+	w, err := b.dest.Create(ctx, res.Name)
+	if err != nil {
+		return nil, err
+	}
+	res.Size, err = io.Copy(w, &buf)
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return b.prune(ctx)
+}
+
+// prune removes the oldest exports beyond the retention count. Names sort by
+// the time of the export.
+func (b *Backup) prune(ctx context.Context) ([]string, error) {
+	p, ok := b.dest.(Pruner)
+	if !ok || b.retention <= 0 {
+		return nil, nil
+	}
+	names, err := p.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var exports []string
+	for _, name := range names {
+		if strings.HasPrefix(name, namePrefix) {
+			exports = append(exports, name)
+		}
+	}
+	sort.Strings(exports)
+
+	var removed []string
+	for len(exports) > b.retention {
+		if err := p.Remove(ctx, exports[0]); err != nil {
+			return removed, err
+		}
+		removed = append(removed, exports[0])
+		exports = exports[1:]
+	}
+	return removed, nil
+}
+
+func (b *Backup) name(t time.Time) string {
+	ext := ".json"
+	if b.format == bitwarden.ExportCSV {
+		ext = ".csv"
+	}
+	return namePrefix + t.UTC().Format("20060102T150405.000Z") + ext
+}
+
+// Dir is a Destination that stores exports as files in a directory that
+// only the current user can read. An export is written to a hidden
+// temporary file that is renamed to its name when it is closed, so a failed
+// or partial export never looks complete.
+type Dir string
+
+var _ Pruner = Dir("")
+
+func (d Dir) Create(_ context.Context, name string) (io.WriteCloser, error) {
+	if err := os.MkdirAll(string(d), 0o700); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(string(d), name)
+	if _, err := os.Lstat(path); err == nil {
+		return nil, &fs.PathError{Op: "create", Path: path, Err: fs.ErrExist}
+	}
+	f, err := os.CreateTemp(string(d), "."+name+".tmp-*") // mode 0600
+	if err != nil {
+		return nil, err
+	}
+	return &dirFile{f: f, path: path}, nil
+}
+
+// dirFile is an export being written to a temporary file.
+type dirFile struct {
+	f    *os.File
+	path string
+	err  error // of the first failed write
+}
+
+func (w *dirFile) Write(p []byte) (int, error) {
+	n, err := w.f.Write(p)
+	if err != nil && w.err == nil {
+		w.err = err
+	}
+	return n, err
+}
+
+// Close syncs the temporary file and renames it to the name of the export,
+// or removes it if a write failed.
+func (w *dirFile) Close() error {
+	err := w.err
+	if err == nil {
+		err = w.f.Sync()
+	}
+	if closeErr := w.f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(w.f.Name(), w.path)
+	}
+	if err != nil {
+		os.Remove(w.f.Name())
+	}
+	return err
+}
+
+func (d Dir) List(context.Context) ([]string, error) {
+	entries, err := os.ReadDir(string(d))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.Type().IsRegular() && !strings.HasPrefix(e.Name(), ".") { // skip exports being written
+			names = append(names, e.Name())
+		}
+	}
+	return names, nil
+}
+
+func (d Dir) Remove(_ context.Context, name string) error {
+	return os.Remove(filepath.Join(string(d), name))
+}
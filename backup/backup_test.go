@@ -0,0 +1,162 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Exporter = (*bitwarden.CLI)(nil)
+
+var errExport = errors.New("export failed")
+
+// fakeExporter writes the format and counts the exports.
+type fakeExporter struct {
+	exports int
+	err     error
+}
+
+func (e *fakeExporter) Export(_ context.Context, format bitwarden.ExportFormat, w io.Writer, _ ...bitwarden.ExportOption) error {
+	e.exports++
+	if e.err != nil {
+		return e.err
+	}
+	_, err := io.WriteString(w, string(format))
+	return err
+}
+
+// clock returns times one minute apart.
+func clock() func() time.Time {
+	t := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		t = t.Add(time.Minute)
+		return t
+	}
+}
+
+func TestRunOnce(t *testing.T) {
+	t.Run("Should write the export to the destination", func(t *testing.T) {
+		dir := Dir(t.TempDir())
+		var succeeded []Result
+		b := New(&fakeExporter{}, dir, OnSuccess(func(r Result) { succeeded = append(succeeded, r) }))
+		b.now = clock()
+
+		res := b.RunOnce(context.Background())
+
+		assert.NoError(t, res.Err)
+		assert.Equal(t, "bitwarden-export-20240101T000100.000Z.json", res.Name)
+		assert.Equal(t, int64(len(bitwarden.ExportEncryptedJSON)), res.Size)
+		data, err := os.ReadFile(filepath.Join(string(dir), res.Name))
+		assert.NoError(t, err)
+		assert.Equal(t, "encrypted_json", string(data))
+		assert.Len(t, succeeded, 1)
+	})
+
+	t.Run("Should keep only the newest exports", func(t *testing.T) {
+		dir := Dir(t.TempDir())
+		assert.NoError(t, os.WriteFile(filepath.Join(string(dir), "notes.txt"), nil, 0o600))
+		b := New(&fakeExporter{}, dir, WithRetention(2), WithFormat(bitwarden.ExportCSV))
+		b.now = clock()
+
+		var names []string
+		for i := 0; i < 3; i++ {
+			res := b.RunOnce(context.Background())
+			assert.NoError(t, res.Err)
+			names = append(names, res.Name)
+		}
+
+		stored, err := dir.List(context.Background())
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{names[1], names[2], "notes.txt"}, stored)
+	})
+
+	t.Run("Should write nothing and report failed exports", func(t *testing.T) {
+		dir := Dir(t.TempDir())
+		var failed []Result
+		b := New(&fakeExporter{err: errExport}, dir, OnFailure(func(r Result) { failed = append(failed, r) }))
+
+		res := b.RunOnce(context.Background())
+
+		assert.ErrorIs(t, res.Err, errExport)
+		assert.Len(t, failed, 1)
+		stored, err := dir.List(context.Background())
+		assert.NoError(t, err)
+		assert.Empty(t, stored)
+	})
+}
+
+func TestRun(t *testing.T) {
+	t.Run("Should back up on the schedule until the context is done", func(t *testing.T) {
+		exporter := &fakeExporter{err: errExport}
+		ctx, cancel := context.WithCancel(context.Background())
+		b := New(exporter, Dir(t.TempDir()), WithSchedule(Every(time.Millisecond)), OnFailure(func(Result) {
+			if exporter.exports == 3 {
+				cancel()
+			}
+		}))
+
+		err := b.Run(ctx)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 3, exporter.exports)
+	})
+}
+
+func TestDir(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Should only store the export once it is closed", func(t *testing.T) {
+		dir := Dir(t.TempDir())
+
+		w, err := dir.Create(ctx, "export.json")
+		assert.NoError(t, err)
+		_, err = io.WriteString(w, "{}")
+		assert.NoError(t, err)
+		stored, err := dir.List(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, stored)
+
+		assert.NoError(t, w.Close())
+		stored, err = dir.List(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"export.json"}, stored)
+		info, err := os.Stat(filepath.Join(string(dir), "export.json"))
+		assert.NoError(t, err)
+		if runtime.GOOS != "windows" {
+			assert.Equal(t, fs.FileMode(0o600), info.Mode().Perm())
+		}
+	})
+
+	t.Run("Should remove the export when a write failed", func(t *testing.T) {
+		dir := Dir(t.TempDir())
+
+		w, err := dir.Create(ctx, "export.json")
+		assert.NoError(t, err)
+		w.(*dirFile).f.Close() // make the write fail
+		_, err = io.WriteString(w, "{}")
+		assert.Error(t, err)
+
+		assert.Error(t, w.Close())
+		entries, err := os.ReadDir(string(dir))
+		assert.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("Should not overwrite exports", func(t *testing.T) {
+		dir := Dir(t.TempDir())
+		assert.NoError(t, os.WriteFile(filepath.Join(string(dir), "export.json"), nil, 0o600))
+
+		_, err := dir.Create(ctx, "export.json")
+
+		assert.ErrorIs(t, err, fs.ErrExist)
+	})
+}
@@ -0,0 +1,83 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// benchItem is a login with the fields of a typical secret.
+const benchItem = `{"object":"item","id":"382a9d7b-f6b5-4eaa-92a1-1f3c7d89e48f","organizationId":null,"folderId":null,"type":1,"reprompt":0,"name":"database","notes":"primary database of the billing service","favorite":false,"fields":[{"name":"host","value":"db.internal","type":0},{"name":"port","value":"5432","type":0}],"login":{"uris":[{"match":null,"uri":"postgres://db.internal"}],"username":"billing","password":"correct horse battery staple","totp":null,"passwordRevisionDate":null},"collectionIds":[],"revisionDate":"2023-05-06T07:08:09.000Z","creationDate":"2023-01-01T01:02:03.000Z","deletedDate":null}`
+
+// newBenchBitwarden returns a client whose requests are answered with body
+// without a network round trip, so benchmarks measure the client.
+func newBenchBitwarden(body string, opts ...Option) *BitwardenServer {
+	data := []byte(body)
+	respond := RequestFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Body != nil {
+			io.Copy(io.Discard, req.Body)
+			req.Body.Close()
+		}
+		return &http.Response{StatusCode: http.StatusOK, ContentLength: int64(len(data)), Body: io.NopCloser(bytes.NewReader(data))}, nil
+	})
+	b := new(nil, respond, "http://localhost", opts...)
+	b.unlocked.Store(true)
+	return b
+}
+
+func BenchmarkGetItem(b *testing.B) {
+	bw := newBenchBitwarden(`{"success":true,"data":` + benchItem + `}`)
+	ctx := context.Background()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := bw.GetItem(ctx, "382a9d7b-f6b5-4eaa-92a1-1f3c7d89e48f"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkListItems(b *testing.B) {
+	items := make([]string, 100)
+	for i := range items {
+		items[i] = benchItem
+	}
+	bw := newBenchBitwarden(`{"success":true,"data":{"object":"list","data":[` + strings.Join(items, ",") + `]}}`)
+	ctx := context.Background()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := bw.ListItems(ctx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEditItem(b *testing.B) {
+	bw := newBenchBitwarden(`{"success":true,"data":` + benchItem + `}`)
+	ctx := context.Background()
+	item, err := bw.GetItem(ctx, "382a9d7b-f6b5-4eaa-92a1-1f3c7d89e48f")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := bw.EditItem(ctx, item); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkResolve(b *testing.B) {
+	bw := newBenchBitwarden(`{"success":true,"data":` + benchItem + `}`)
+	ctx := context.Background()
+	ref := SecretRef{ItemID: "382a9d7b-f6b5-4eaa-92a1-1f3c7d89e48f", Selector: "password"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := bw.Resolve(ctx, ref); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -3,22 +3,30 @@ package bitwarden
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"os"
 	"os/exec"
 	"runtime"
+	"strings"
+	"sync/atomic"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 //go:generate go run github.com/vektra/mockery/v2
-//go:generate go run golang.org/x/tools/cmd/stringer -type=ItemType,Reprompt
+//go:generate go run golang.org/x/tools/cmd/stringer -type=ItemType,Reprompt,Strength
 
 type ItemType int
 type Reprompt int
 type FieldType int
+type URIMatch int
 
 const (
 	port = "4628"
@@ -27,9 +35,22 @@ const (
 	TypeSecureNote ItemType = 2
 	TypeCard       ItemType = 3
 	TypeIdentity   ItemType = 4
+	TypeSSHKey     ItemType = 5
 
 	RepromptNo  Reprompt = 0
 	RepromptYes Reprompt = 1
+
+	MatchDomain            URIMatch = 0
+	MatchHost              URIMatch = 1
+	MatchStartsWith        URIMatch = 2
+	MatchExact             URIMatch = 3
+	MatchRegularExpression URIMatch = 4
+	MatchNever             URIMatch = 5
+
+	FieldText    FieldType = 0
+	FieldHidden  FieldType = 1
+	FieldBoolean FieldType = 2
+	FieldLinked  FieldType = 3
 )
 
 var (
@@ -38,23 +59,30 @@ var (
 	ErrUnexpectedStatusCode = errors.New("unexpected status code")
 
 	ErrWrongPassword = errors.New("wrong password")
+	ErrNotLoggedIn   = errors.New("not logged in")
+	ErrVaultLocked   = errors.New("vault is locked")
 
 	ErrNotASecureNote  = errors.New("item is not a secure note")
 	ErrEmptySecureNote = errors.New("secure note is empty")
 
 	ErrNotALogin  = errors.New("item is not a login")
 	ErrEmptyLogin = errors.New("login is empty")
+
+	ErrFieldNotFound = errors.New("field not found")
+
+	ErrNoCollections = errors.New("organization items need at least one collection")
 )
 
 type Field struct {
 	Name  string    `json:"name"`
 	Value string    `json:"value"`
-	Type  FieldType `json:"type"` // TODO: don't know what this is
+	Type  FieldType `json:"type"`
 }
 
 type URI struct {
-	Match *string
-	URI   *string
+	// Match is nil when the default match detection applies.
+	Match *URIMatch `json:"match"`
+	URI   *string   `json:"uri"`
 }
 
 type Login struct {
@@ -62,6 +90,27 @@ type Login struct {
 	Username *string `json:"username"`
 	Password *string `json:"password"`
 	TOTP     *string `json:"totp"`
+	// Fido2Credentials are the passkeys of the login.
+	Fido2Credentials []Fido2Credential `json:"fido2Credentials,omitempty"`
+	// PasswordRevisionDate is when the password last changed, or nil if it
+	// never did.
+	PasswordRevisionDate *time.Time `json:"passwordRevisionDate"`
+}
+
+type Fido2Credential struct {
+	CredentialID    string    `json:"credentialId"`
+	KeyType         string    `json:"keyType"`
+	KeyAlgorithm    string    `json:"keyAlgorithm"`
+	KeyCurve        string    `json:"keyCurve"`
+	KeyValue        string    `json:"keyValue"`
+	RPID            string    `json:"rpId"`
+	RPName          *string   `json:"rpName"`
+	UserHandle      *string   `json:"userHandle"`
+	UserName        *string   `json:"userName"`
+	UserDisplayName *string   `json:"userDisplayName"`
+	Counter         string    `json:"counter"`
+	Discoverable    string    `json:"discoverable"`
+	CreationDate    time.Time `json:"creationDate"`
 }
 
 type Card struct {
@@ -94,109 +143,352 @@ type Identity struct {
 	LicenseNumber  *string `json:"licenseNumber"`
 }
 
+type Attachment struct {
+	ID       string `json:"id"`
+	FileName string `json:"fileName"`
+	Size     string `json:"size"`
+	SizeName string `json:"sizeName"`
+	URL      string `json:"url"`
+}
+
+type SecureNote struct {
+	Type int `json:"type"`
+}
+
+type SSHKey struct {
+	PrivateKey     *string `json:"privateKey"`
+	PublicKey      *string `json:"publicKey"`
+	KeyFingerprint *string `json:"keyFingerprint"`
+}
+
 type Item struct {
-	CreationDate   time.Time  `json:"creationDate"`
-	RevisionDate   *time.Time `json:"revisionDate"`
-	DeletedDate    *time.Time `json:"deletedDate"`
-	OrganizationID *string    `json:"organizationId"`
-	CollectionID   *string    `json:"collectionId"`
-	FolderID       *string    `json:"folderId"`
-	Type           ItemType   `json:"type"`
-	Name           *string    `json:"name"`
-	Notes          *string    `json:"notes"`
-	Favorite       bool       `json:"favorite"`
-	Fields         []Field    `json:"fields"`
-	Login          *Login     `json:"login"`
-	Card           *Card      `json:"card"`
-	Identity       *Identity  `json:"identity"`
-	Reprompt       Reprompt   `json:"reprompt"`
+	ID              string            `json:"id,omitempty"`
+	CreationDate    time.Time         `json:"creationDate"`
+	RevisionDate    *time.Time        `json:"revisionDate"`
+	DeletedDate     *time.Time        `json:"deletedDate"`
+	OrganizationID  *string           `json:"organizationId"`
+	CollectionID    *string           `json:"collectionId"`
+	CollectionIDs   []string          `json:"collectionIds"`
+	FolderID        *string           `json:"folderId"`
+	Type            ItemType          `json:"type"`
+	Name            *string           `json:"name"`
+	Notes           *string           `json:"notes"`
+	Favorite        bool              `json:"favorite"`
+	Fields          []Field           `json:"fields"`
+	Login           *Login            `json:"login"`
+	SecureNote      *SecureNote       `json:"secureNote"`
+	Card            *Card             `json:"card"`
+	Identity        *Identity         `json:"identity"`
+	SSHKey          *SSHKey           `json:"sshKey"`
+	Attachments     []Attachment      `json:"attachments"`
+	PasswordHistory []PasswordHistory `json:"passwordHistory"`
+	Reprompt        Reprompt          `json:"reprompt"`
 }
 
 type BitwardenServer struct {
 	url    string
 	cmd    *exec.Cmd
+	exited chan struct{} // closed when cmd exits
 	client client
+	cache  *itemCache
+	subs   *subscriptions
+
+	middleware []Middleware
+	logger     *slog.Logger
+	tracer     trace.Tracer
+	metrics    Metrics
+	audit      AuditSink
+	trail      *debugTrail
+
+	allowInsecureRemote bool
+	urlErr              error // returned by every request if set
+	flavor              ServerFlavor
+	version             *cliVersion
+	payloads            payloads
+	errorMappers        []ErrorMapper
+	maxIdleConns        int
+	compression         bool
+	isolateAppData      bool
+	detectConflicts     bool
+	limits              *ValidationLimits // DefaultValidationLimits if nil
+	appDataDir          string            // removed on Close, if set
+	clock               Clock
+	misses              *missCache // items not found, see WithNegativeCache
+	policy              AccessPolicy
+	keepAlive           *keepAlive
+	passwords           PasswordProvider
+	unlocking           chan struct{} // held while autoUnlock unlocks the vault
+
+	lifetime context.Context // cancels every request when done, if set
+	unlocked *atomic.Bool    // whether the vault was seen unlocked, see checkStatus
+}
+
+// Option configures optional behaviour of a BitwardenServer.
+type Option func(*BitwardenServer)
+
+// WithHTTPClient sets the client used to talk to bw serve, for example to
+// add a custom transport.
+func WithHTTPClient(c *http.Client) Option {
+	return func(b *BitwardenServer) { b.client = c }
 }
 
 type client interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
-func New() *BitwardenServer {
+func New(opts ...Option) *BitwardenServer {
+	return NewWithContext(context.Background(), opts...)
+}
+
+// NewWithContext is like New, but ties the client to ctx: when ctx is
+// cancelled, bw serve is killed and requests in flight fail with the error
+// of ctx, so the client can run in a run group or errgroup.
+func NewWithContext(ctx context.Context, opts ...Option) *BitwardenServer {
 	var cmd *exec.Cmd
 	switch runtime.GOOS {
 	case "windows":
-		cmd = exec.Command("cmd", "/C", "bw serve --port "+port)
+		cmd = exec.CommandContext(ctx, "cmd", "/C", "bw serve --port "+port)
 	case "darwin", "linux":
-		cmd = exec.Command("bash", "-c", "bw serve --port "+port)
+		cmd = exec.CommandContext(ctx, "bash", "-c", "bw serve --port "+port)
 	default:
 		panic(fmt.Sprintf("Unsuppored os: %s", runtime.GOOS))
 	}
 
-	go func() { cmd.Run() }()
-	time.Sleep(100 * time.Millisecond) // not pretty, but wait some time for process to start
-	return new(cmd, &http.Client{}, "http://localhost:"+port)
+	b := new(cmd, nil, "http://localhost:"+port, opts...)
+	if b.isolateAppData {
+		if err := b.isolate(ctx); err != nil {
+			b.cmd = nil
+			b.urlErr = err
+			b.stopKeepAlive()
+			return b
+		}
+	}
+
+	exited := make(chan struct{})
+	go func() {
+		cmd.Run()
+		close(exited)
+	}()
+	sleep(ctx, b.clock, 100*time.Millisecond) // not pretty, but wait some time for process to start
+	b.exited = exited
+	if b.version == nil {
+		b.version = &cliVersion{path: "bw"}
+	}
+	if ctx.Done() != nil {
+		b.lifetime = ctx
+		context.AfterFunc(ctx, b.stopKeepAlive)
+	}
+	return b
 }
 
-func NewFromURL(url string) *BitwardenServer {
-	return new(nil, &http.Client{}, url)
+// NewFromURL uses a bw serve that is already running at url. Plain http is
+// only used for the loopback address, unless WithAllowInsecureRemote is set.
+func NewFromURL(url string, opts ...Option) *BitwardenServer {
+	return new(nil, nil, url, opts...)
 }
 
-func new(cmd *exec.Cmd, client client, url string) *BitwardenServer {
-	return &BitwardenServer{cmd: cmd, client: client, url: url}
+// new creates the client; a nil client uses an HTTP client tuned for the
+// single bw serve host.
+func new(cmd *exec.Cmd, client client, url string, opts ...Option) *BitwardenServer {
+	b := &BitwardenServer{cmd: cmd, client: client, url: url, subs: &subscriptions{}, trail: newDebugTrail(defaultDebugTrailSize), unlocked: &atomic.Bool{}, maxIdleConns: defaultMaxIdleConns, clock: systemClock{}}
+	for _, opt := range opts {
+		opt(b)
+	}
+	if b.client == nil {
+		b.client = newHTTPClient(b.maxIdleConns)
+	}
+	mw := b.middleware
+	if b.logger != nil {
+		mw = append(mw[:len(mw):len(mw)], logging(b.logger))
+	}
+	b.client = staleConnRetry{client: chain(b.client, mw), transport: b.client}
+	if !b.allowInsecureRemote {
+		b.urlErr = checkURL(url)
+	}
+	if b.cache != nil {
+		b.cache.clock = b.clock
+		b.cache.logger = b.logger
+		b.cache.load()
+	}
+	if b.misses != nil {
+		b.misses.clock = b.clock
+	}
+	b.startKeepAlive()
+	return b
 }
 
+// Close stops the bw serve started by New and removes its isolated app data,
+// see WithIsolatedAppData. For clients created with NewFromURL or Attach it
+// only stops the pings of WithKeepAlive, because they do not own the serve.
 func (b *BitwardenServer) Close() {
+	b.stopKeepAlive()
 	if b.cmd != nil {
 		b.cmd.Process.Kill() // kill bitwarden server
 		b.cmd.Process.Wait() // wait for it to exit (is this needed?)
 	}
+	if b.appDataDir != "" {
+		os.RemoveAll(b.appDataDir)
+	}
 }
 
-func (b BitwardenServer) request(ctx context.Context, method string, endpoint string, req any, resp any) error {
-	url := b.url + endpoint
-	var body io.Reader = http.NoBody
+func (b *BitwardenServer) request(ctx context.Context, method string, endpoint string, req any, resp any) error {
+	r, err := b.do(ctx, method, endpoint, req)
+	if err != nil {
+		return err
+	}
+	defer closeBody(r)
 
-	if req != nil {
-		var err error
-		data, err := json.Marshal(req)
+	if resp != nil {
+		buf, err := readResponse(r)
 		if err != nil {
 			return err
 		}
-		body = bytes.NewBuffer(data)
+		defer putBuffer(buf)
+		return b.payloads.unmarshal(buf.Bytes(), resp)
+	}
+	return nil
+}
+
+// withLifetime returns a context that is also cancelled when the lifetime
+// of the client ends. The caller must call release when done with it.
+func (b *BitwardenServer) withLifetime(ctx context.Context) (_ context.Context, release func()) {
+	if b.lifetime == nil {
+		return ctx, func() {}
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+	stop := context.AfterFunc(b.lifetime, func() { cancel(context.Cause(b.lifetime)) })
+	return ctx, func() {
+		stop()
+		cancel(nil)
+	}
+}
+
+// releaseBody releases the context of a request when its body is closed.
+type releaseBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (r releaseBody) Close() error {
+	err := r.ReadCloser.Close()
+	r.release()
+	return err
+}
+
+// do sends a request and returns the response if its status is OK. The
+// caller must close the response body.
+func (b *BitwardenServer) do(ctx context.Context, method string, endpoint string, req any) (*http.Response, error) {
+	if req == nil {
+		return b.send(ctx, method, endpoint, "", nil)
+	}
+	if b.payloads.codec == nil {
+		body, err := encodeBody(req)
+		if err != nil {
+			return nil, err
+		}
+		defer body.release()
+		return b.send(ctx, method, endpoint, "application/json", &body.first)
+	}
+	data, err := b.payloads.marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	return b.send(ctx, method, endpoint, "application/json", bytes.NewReader(data))
+}
+
+// send sends body, which is nil for requests without one, with the given
+// content type, after checking the URL, the server flavor, the access policy
+// and the vault status. Like do, it returns the response if its status is
+// OK.
+func (b *BitwardenServer) send(ctx context.Context, method string, endpoint string, contentType string, body io.Reader) (*http.Response, error) {
+	if b.urlErr != nil {
+		return nil, b.urlErr
+	}
+	path, _, _ := strings.Cut(endpoint, "?")
+	if !b.flavor.supports(path) {
+		return nil, fmt.Errorf("%s %s: %w", method, endpoint, ErrUnsupportedByServer)
+	}
+	if id, ok := endpointItem(endpoint); ok {
+		if err := b.authorize(ctx, SecretRef{ItemID: id}); err != nil {
+			return nil, err
+		}
+		if b.policy != nil && id != "" && (method != http.MethodGet || !strings.HasPrefix(path, "/object/item/")) {
+			// Let the policy see the stored item; fetchItem checks it.
+			if _, err := b.fetchItem(withAccess(ctx, SecretRef{ItemID: id}), id); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := b.checkStatus(ctx, path); err != nil {
+		return nil, err
+	}
+	url := b.url + endpoint
+	if body == nil {
+		body = http.NoBody
 	}
 
-	request, err := http.NewRequestWithContext(ctx, method, url, body)
+	reqCtx, release := b.withLifetime(b.withAttempts(ctx))
+	request, err := http.NewRequestWithContext(reqCtx, method, url, body)
 	if err != nil {
-		return err
+		release()
+		return nil, err
 	}
 
-	if req != nil {
-		request.Header.Add("Content-Type", "application/json")
+	if r, ok := body.(*bodyReader); ok {
+		request.ContentLength = int64(r.Len())
+		request.GetBody = func() (io.ReadCloser, error) { return r.body.reader(), nil }
+	}
+	if contentType != "" {
+		request.Header.Add("Content-Type", contentType)
 	}
+	b.acceptGzip(request, path)
 
+	start := time.Now()
+	rec := b.trail.start(method, request.URL.Path)
 	r, err := b.client.Do(request)
 	if err != nil {
-		return err
+		release()
+		b.trail.finish(rec, 0, err)
+		b.observeRequest(ctx, method, endpoint, start, err)
+		return nil, err
 	}
-
-	switch r.StatusCode {
-	case http.StatusOK:
-		break
-	case http.StatusNotFound:
-		return ErrNotFound
-	case http.StatusBadRequest:
-		return ErrBadRequest
-	default:
-		return fmt.Errorf("%w: %d", ErrUnexpectedStatusCode, r.StatusCode)
+	if err := gunzip(r); err != nil {
+		release()
+		b.trail.finish(rec, r.StatusCode, err)
+		b.observeRequest(ctx, method, endpoint, start, err)
+		return nil, err
+	}
+	if span := trace.SpanFromContext(ctx); span.IsRecording() {
+		span.SetAttributes(
+			attribute.String("bitwarden.endpoint", request.URL.Path),
+			attribute.Int("http.response.status_code", r.StatusCode),
+		)
 	}
 
-	if resp != nil {
-		if err := json.NewDecoder(r.Body).Decode(resp); err != nil {
-			return err
+	if r.StatusCode == http.StatusOK {
+		b.trail.finish(rec, r.StatusCode, nil)
+		b.observeRequest(ctx, method, endpoint, start, nil)
+		if b.lifetime != nil && r.Body != nil {
+			r.Body = releaseBody{r.Body, release}
+		} else {
+			release()
 		}
+		return r, nil
+	}
+	defer release()
+	defer closeBody(r)
+	apiErr := NewAPIError(method, endpoint, r)
+	b.flavor.adjust(apiErr)
+	b.mapError(apiErr)
+	b.trail.finish(rec, r.StatusCode, apiErr)
+	b.observeRequest(ctx, method, endpoint, start, apiErr)
+	return nil, apiErr
+}
+
+func closeBody(r *http.Response) {
+	if r.Body != nil {
+		r.Body.Close()
 	}
-	return nil
 }
 
 func (b *BitwardenServer) Unlock(ctx context.Context, password string) error {
@@ -204,32 +496,279 @@ func (b *BitwardenServer) Unlock(ctx context.Context, password string) error {
 		Password string `json:"password"`
 	}{Password: password}
 
+	ctx, span := b.startSpan(ctx, "Unlock")
 	err := b.request(ctx, http.MethodPost, "/unlock", req, nil)
 	if errors.Is(err, ErrBadRequest) { // this is a wrong password as far as I know
-		return ErrWrongPassword
+		err = ErrWrongPassword
 	}
-	return err
+	if err == nil {
+		b.unlocked.Store(true)
+	}
+	b.observeUnlock(err)
+	return endSpan(span, err)
 }
 
 func (b *BitwardenServer) Lock(ctx context.Context) error {
-	return b.request(ctx, http.MethodPost, "/lock", struct{}{}, nil)
+	ctx, span := b.startSpan(ctx, "Lock")
+	err := b.request(ctx, http.MethodPost, "/lock", struct{}{}, nil)
+	if err == nil {
+		b.unlocked.Store(false)
+	}
+	return endSpan(span, err)
+}
+
+func (b *BitwardenServer) Sync(ctx context.Context) error {
+	ctx, span := b.startSpan(ctx, "Sync")
+	err := b.sync(ctx)
+	if err == nil {
+		b.refreshCache(ctx)
+	}
+	return endSpan(span, err)
+}
+
+// sync syncs the vault without refreshing the cache, for callers that list
+// the items anyway.
+func (b *BitwardenServer) sync(ctx context.Context) error {
+	err := b.request(ctx, http.MethodPost, "/sync", struct{}{}, nil)
+	if err == nil {
+		// Sync is where writes of other clients come in, so cached items
+		// may be stale and items may have been restored or shared with us.
+		b.misses.purge()
+	}
+	return err
+}
+
+// refreshCache brings the cached items up to date with the vault. If the
+// items cannot be listed, they stay cached until they expire.
+func (b *BitwardenServer) refreshCache(ctx context.Context) {
+	if b.cache.empty() {
+		return
+	}
+	items, err := b.listItems(ctx)
+	if err != nil {
+		b.cache.warn("bitwarden cache not refreshed", "error", err)
+		return
+	}
+	b.cache.refresh(items)
 }
 
 func (b *BitwardenServer) GetItem(ctx context.Context, id string) (*Item, error) {
+	ctx, span := b.startSpan(ctx, "GetItem", attribute.String("bitwarden.item_id", id))
+	if err := b.authorize(ctx, SecretRef{ItemID: id}); err != nil {
+		b.record(ctx, AuditRead, id, nil, err)
+		return nil, endSpan(span, err)
+	}
+	ctx = withAccess(ctx, SecretRef{ItemID: id})
+	if item, ok := b.cache.get(id); ok {
+		span.SetAttributes(attribute.Bool("bitwarden.cache.hit", true))
+		b.observeCache(true)
+		if err := b.authorizeItem(ctx, id, item); err != nil {
+			b.record(ctx, AuditRead, id, nil, err)
+			return nil, endSpan(span, err)
+		}
+		b.record(ctx, AuditRead, id, item, nil)
+		return item, endSpan(span, nil)
+	}
+	if b.cache != nil {
+		span.SetAttributes(attribute.Bool("bitwarden.cache.hit", false))
+		b.observeCache(false)
+	}
+	if err := b.misses.get(id); err != nil {
+		b.record(ctx, AuditRead, id, nil, err)
+		return nil, endSpan(span, err)
+	}
+	item, err := b.fetchItem(ctx, id)
+	return item, endSpan(span, err)
+}
+
+// freshItem gets the item like GetItem, through the access policy and the
+// negative cache, but always from the server.
+func (b *BitwardenServer) freshItem(ctx context.Context, id string) (*Item, error) {
+	ref := SecretRef{ItemID: id}
+	if err := b.authorize(ctx, ref); err != nil {
+		b.record(ctx, AuditRead, id, nil, err)
+		return nil, err
+	}
+	if err := b.misses.get(id); err != nil {
+		b.record(ctx, AuditRead, id, nil, err)
+		return nil, err
+	}
+	return b.fetchItem(withAccess(ctx, ref), id)
+}
+
+// fetchItem gets the item from the server, bypassing and refreshing the cache.
+// Anything that edits an item or checks its membership starts from here: the
+// cache is not told about writes of other clients until the next Sync, and
+// editing a stale copy would silently revert them.
+func (b *BitwardenServer) fetchItem(ctx context.Context, id string) (*Item, error) {
 	resp := struct {
 		Data Item `json:"data"`
 	}{}
 	if err := b.request(ctx, http.MethodGet, "/object/item/"+id, nil, &resp); err != nil {
+		b.record(ctx, AuditRead, id, nil, err)
+		if errors.Is(err, ErrNotFound) {
+			b.misses.put(id)
+		}
+		return nil, err
+	}
+	if err := b.authorizeItem(ctx, id, &resp.Data); err != nil {
+		b.record(ctx, AuditRead, id, nil, err)
 		return nil, err
 	}
+	b.record(ctx, AuditRead, id, &resp.Data, nil)
+	b.cache.put(id, &resp.Data)
+	b.misses.remove(id)
 	return &resp.Data, nil
 }
 
+// CreateItem creates a new item and returns it as stored by the server. If
+// OrganizationID is set, the item is created directly in the organization,
+// in the collections in CollectionIDs, without passing through the personal
+// vault; it returns ErrNoCollections if there are none.
+func (b *BitwardenServer) CreateItem(ctx context.Context, item *Item) (*Item, error) {
+	req, err := createRequest(item)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.validate(&req); err != nil {
+		return nil, err
+	}
+	if err := b.checkFeatures(ctx, &req); err != nil {
+		return nil, err
+	}
+	if err := b.authorizeItem(ctx, "", &req); err != nil {
+		return nil, err
+	}
+	ctx = withAccess(ctx, SecretRef{})
+	endpoint := "/object/item"
+	if req.OrganizationID != nil {
+		endpoint += "?organizationid=" + url.QueryEscape(*req.OrganizationID)
+	}
+	resp := struct {
+		Data Item `json:"data"`
+	}{}
+	ctx, span := b.startSpan(ctx, "CreateItem")
+	if err := endSpan(span, b.request(ctx, http.MethodPost, endpoint, req, &resp)); err != nil {
+		b.record(ctx, AuditCreate, "", &req, err)
+		return nil, err
+	}
+	b.record(ctx, AuditCreate, "", &resp.Data, nil)
+	b.cache.put(resp.Data.ID, &resp.Data)
+	return &resp.Data, nil
+}
+
+// createRequest returns the item to send to create item.
+func createRequest(item *Item) (Item, error) {
+	req := *item
+	req.ID = ""
+	if req.Type == TypeSecureNote && req.SecureNote == nil {
+		req.SecureNote = &SecureNote{} // the cli refuses secure notes without one
+	}
+	if req.OrganizationID != nil {
+		if len(req.CollectionIDs) == 0 && req.CollectionID != nil {
+			req.CollectionIDs = []string{*req.CollectionID}
+		}
+		if len(req.CollectionIDs) == 0 {
+			return req, ErrNoCollections
+		}
+	}
+	return req, nil
+}
+
+// EditItem replaces the item with the same ID and returns it as stored by
+// the server. With WithConflictDetection it returns ErrConflict if the item
+// changed since it was read.
+func (b *BitwardenServer) EditItem(ctx context.Context, item *Item) (*Item, error) {
+	if err := b.validate(item); err != nil {
+		return nil, err
+	}
+	if err := b.checkFeatures(ctx, item); err != nil {
+		return nil, err
+	}
+	if err := b.authorize(ctx, SecretRef{ItemID: item.ID}); err != nil {
+		return nil, err
+	}
+	ctx = withAccess(ctx, SecretRef{ItemID: item.ID})
+	if err := b.authorizeItem(ctx, item.ID, item); err != nil {
+		return nil, err
+	}
+	if b.detectConflicts && item.RevisionDate != nil {
+		if err := b.checkRevision(ctx, item); err != nil {
+			return nil, err
+		}
+	}
+	resp := struct {
+		Data Item `json:"data"`
+	}{}
+	ctx, span := b.startSpan(ctx, "EditItem", attribute.String("bitwarden.item_id", item.ID))
+	if err := endSpan(span, b.request(ctx, http.MethodPut, "/object/item/"+item.ID, item, &resp)); err != nil {
+		b.record(ctx, AuditUpdate, item.ID, item, err)
+		return nil, err
+	}
+	b.record(ctx, AuditUpdate, item.ID, &resp.Data, nil)
+	b.cache.put(resp.Data.ID, &resp.Data)
+	b.misses.remove(resp.Data.ID)
+	return &resp.Data, nil
+}
+
+// DeleteItem moves the item to the trash.
+func (b *BitwardenServer) DeleteItem(ctx context.Context, id string) error {
+	ctx, span := b.startSpan(ctx, "DeleteItem", attribute.String("bitwarden.item_id", id))
+	err := endSpan(span, b.request(ctx, http.MethodDelete, "/object/item/"+id, nil, nil))
+	b.record(ctx, AuditDelete, id, nil, err)
+	if err != nil {
+		return err
+	}
+	b.cache.remove(id)
+	return nil
+}
+
+// GetItemIfChanged returns the item and whether its revision date is after
+// since. Items without a revision date are always reported as changed. The
+// item is always fetched from the server, since a cached copy would report
+// changes made by other clients as unchanged until it expires.
+func (b *BitwardenServer) GetItemIfChanged(ctx context.Context, id string, since time.Time) (*Item, bool, error) {
+	ctx, span := b.startSpan(ctx, "GetItemIfChanged", attribute.String("bitwarden.item_id", id))
+	if err := b.authorize(ctx, SecretRef{ItemID: id}); err != nil {
+		b.record(ctx, AuditRead, id, nil, err)
+		return nil, false, endSpan(span, err)
+	}
+	i, err := b.fetchItem(withAccess(ctx, SecretRef{ItemID: id}), id)
+	if err := endSpan(span, err); err != nil {
+		return nil, false, err
+	}
+	if i.RevisionDate != nil && !i.RevisionDate.After(since) {
+		return i, false, nil
+	}
+	return i, true, nil
+}
+
 func (b *BitwardenServer) GetLogin(ctx context.Context, id string) (*Login, error) {
 	i, err := b.GetItem(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	return i.login()
+}
+
+func (b *BitwardenServer) GetSecureNote(ctx context.Context, id string) (string, error) {
+	i, err := b.GetItem(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return i.secureNote()
+}
+
+// GetField returns the value of the custom field with the given name.
+func (b *BitwardenServer) GetField(ctx context.Context, id string, name string) (string, error) {
+	i, err := b.GetItem(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return i.field(name)
+}
+
+func (i *Item) login() (*Login, error) {
 	if i.Type != TypeLogin {
 		return nil, ErrNotALogin
 	}
@@ -239,11 +778,7 @@ func (b *BitwardenServer) GetLogin(ctx context.Context, id string) (*Login, erro
 	return i.Login, nil
 }
 
-func (b *BitwardenServer) GetSecureNote(ctx context.Context, id string) (string, error) {
-	i, err := b.GetItem(ctx, id)
-	if err != nil {
-		return "", err
-	}
+func (i *Item) secureNote() (string, error) {
 	if i.Type != TypeSecureNote {
 		return "", ErrNotASecureNote
 	}
@@ -252,3 +787,12 @@ func (b *BitwardenServer) GetSecureNote(ctx context.Context, id string) (string,
 	}
 	return *i.Notes, nil
 }
+
+func (i *Item) field(name string) (string, error) {
+	for _, f := range i.Fields {
+		if f.Name == name {
+			return f.Value, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrFieldNotFound, name)
+}
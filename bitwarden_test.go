@@ -3,6 +3,7 @@ package bitwarden
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
 	"net/http"
@@ -13,26 +14,80 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
-func newTestBitwarden() (*BitwardenServer, *Mockclient) {
+func newTestBitwarden(opts ...Option) (*BitwardenServer, *Mockclient) {
 	httpClient := &Mockclient{}
-	return new(nil, httpClient, "http://localhost"), httpClient
+	b := new(nil, httpClient, "http://localhost", opts...)
+	b.unlocked.Store(true) // skip checkStatus, see TestCheckStatus
+	return b, httpClient
 }
 
 func checkRequest(method string, url string, body string) func(req *http.Request) bool {
 	return func(req *http.Request) bool {
-		data, _ := io.ReadAll(req.Body)
+		data := readBody(req)
 		return req.URL.String() == url &&
 			req.Method == method &&
 			string(data) == body
 	}
 }
 
+// readBody reads the body of req and puts it back, so that every matcher
+// sees it.
+func readBody(req *http.Request) []byte {
+	data, _ := io.ReadAll(req.Body)
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	return data
+}
+
 func TestNewFromURI(t *testing.T) {
 	url := "http://test:3429"
 	bw := NewFromURL(url)
 	assert.Equal(t, bw.url, url)
 }
 
+func TestLifetime(t *testing.T) {
+	t.Run("Should fail requests in flight when the context is cancelled", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+		ctx, cancel := context.WithCancel(context.Background())
+		bw.lifetime = ctx
+
+		client.
+			On("Do", mock.Anything).
+			Return(func(req *http.Request) (*http.Response, error) {
+				<-req.Context().Done()
+				return nil, req.Context().Err()
+			}, nil).
+			Once()
+
+		time.AfterFunc(10*time.Millisecond, cancel)
+		err := bw.Sync(context.Background())
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("Should keep the body readable until it is closed", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		bw.lifetime = ctx
+
+		var reqCtx context.Context
+		client.
+			On("Do", mock.Anything).
+			Return(func(req *http.Request) (*http.Response, error) {
+				reqCtx = req.Context()
+				return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString("data"))}, nil
+			}, nil).
+			Once()
+
+		r, err := bw.DownloadAttachment(context.Background(), "item", "attachment")
+		assert.NoError(t, err)
+		assert.NoError(t, reqCtx.Err())
+		assert.NoError(t, r.Close())
+		assert.Error(t, reqCtx.Err())
+	})
+}
+
 func TestUnlock(t *testing.T) {
 	t.Run("Should unlock if password is correct", func(t *testing.T) {
 		bw, client := newTestBitwarden()
@@ -346,3 +401,274 @@ func TestGetSecureNote(t *testing.T) {
 		assert.Equal(t, "This is very secret!", note)
 	})
 }
+
+func TestGetItemIfChanged(t *testing.T) {
+	itemID := "382a9d7b-f6b5-4eaa-92a1-1f3c7d89e48f"
+	revision := time.Date(2023, 5, 6, 7, 8, 9, 100000, time.UTC)
+
+	t.Run("Should report a newer revision as changed", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))).
+			Return(itemResponse(itemID), nil).
+			Once()
+
+		item, changed, err := bw.GetItemIfChanged(context.Background(), itemID, revision.Add(-time.Second))
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, itemID, item.ID)
+	})
+
+	t.Run("Should report the same revision as unchanged", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))).
+			Return(itemResponse(itemID), nil).
+			Once()
+
+		_, changed, err := bw.GetItemIfChanged(context.Background(), itemID, revision)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.False(t, changed)
+	})
+
+	t.Run("Should bypass the cache", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithCache(time.Minute))
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))).
+			Return(func(*http.Request) (*http.Response, error) { return itemResponse(itemID), nil }).
+			Twice()
+
+		_, _, err := bw.GetItemIfChanged(context.Background(), itemID, revision)
+		assert.NoError(t, err)
+		_, changed, err := bw.GetItemIfChanged(context.Background(), itemID, revision)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.False(t, changed)
+	})
+
+	t.Run("Should return item errors", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 404}, nil).
+			Once()
+
+		item, changed, err := bw.GetItemIfChanged(context.Background(), itemID, revision)
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.False(t, changed)
+		assert.Nil(t, item)
+	})
+}
+
+func TestGetField(t *testing.T) {
+	itemID := "1d4cf845-8012-4b2d-a924-f9d8c9b7c44a"
+	respData := `{"data":{"id":"` + itemID + `","type":1,"name":"API","fields":[{"name":"token","value":"s3cr3t","type":1}]}}`
+
+	t.Run("Should return the value of a custom field", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(respData))}, nil).
+			Once()
+
+		value, err := bw.GetField(context.Background(), itemID, "token")
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "s3cr3t", value)
+	})
+
+	t.Run("Should return an error for unknown fields", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(respData))}, nil).
+			Once()
+
+		_, err := bw.GetField(context.Background(), itemID, "other")
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrFieldNotFound)
+	})
+
+	t.Run("Should check item errors", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 404}, nil).
+			Once()
+
+		_, err := bw.GetField(context.Background(), itemID, "token")
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func checkRequestJSON(method string, url string, check func(body map[string]any) bool) func(req *http.Request) bool {
+	return func(req *http.Request) bool {
+		var body map[string]any
+		if err := json.Unmarshal(readBody(req), &body); err != nil {
+			return false
+		}
+		return req.URL.String() == url &&
+			req.Method == method &&
+			check(body)
+	}
+}
+
+func TestCreateItem(t *testing.T) {
+	t.Run("Should create the item without an ID", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequestJSON(http.MethodPost, "http://localhost/object/item", func(body map[string]any) bool {
+				_, hasID := body["id"]
+				return !hasID && body["name"] == "ENV" && body["secureNote"] != nil
+			}))).
+			Return(itemResponse("new-id"), nil).
+			Once()
+
+		name := "ENV"
+		item, err := bw.CreateItem(context.Background(), &Item{ID: "ignored", Type: TypeSecureNote, Name: &name})
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "new-id", item.ID)
+	})
+
+	t.Run("Should create organization items in their collections", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequestJSON(http.MethodPost, "http://localhost/object/item?organizationid=org", func(body map[string]any) bool {
+				return body["organizationId"] == "org" && assert.ObjectsAreEqual([]any{"c1"}, body["collectionIds"])
+			}))).
+			Return(itemResponse("new-id"), nil).
+			Once()
+
+		org, collection, name := "org", "c1", "ENV"
+		_, err := bw.CreateItem(context.Background(), &Item{Type: TypeSecureNote, Name: &name, OrganizationID: &org, CollectionID: &collection})
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should refuse organization items without collections", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		org := "org"
+		_, err := bw.CreateItem(context.Background(), &Item{Type: TypeSecureNote, OrganizationID: &org})
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrNoCollections)
+	})
+
+	t.Run("Should return request errors", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 400}, nil).
+			Once()
+
+		name := "db"
+		_, err := bw.CreateItem(context.Background(), &Item{Type: TypeLogin, Name: &name})
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrBadRequest)
+	})
+}
+
+func TestEditItem(t *testing.T) {
+	itemID := "382a9d7b-f6b5-4eaa-92a1-1f3c7d89e48f"
+
+	t.Run("Should replace the item and refresh the cache", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithCache(time.Minute))
+
+		client.
+			On("Do", mock.MatchedBy(checkRequestJSON(http.MethodPut, "http://localhost/object/item/"+itemID, func(body map[string]any) bool {
+				return body["notes"] == "This is a secure note!"
+			}))).
+			Return(itemResponse(itemID), nil).
+			Once()
+
+		name, notes := "ENV", "This is a secure note!"
+		_, err := bw.EditItem(context.Background(), &Item{ID: itemID, Type: TypeSecureNote, Name: &name, Notes: &notes})
+		assert.NoError(t, err)
+		note, err := bw.GetSecureNote(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, notes, note)
+	})
+
+	t.Run("Should return request errors", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 404}, nil).
+			Once()
+
+		name := "ENV"
+		_, err := bw.EditItem(context.Background(), &Item{ID: itemID, Name: &name})
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestDeleteItem(t *testing.T) {
+	itemID := "382a9d7b-f6b5-4eaa-92a1-1f3c7d89e48f"
+
+	t.Run("Should delete the item and drop it from the cache", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithCache(time.Minute))
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))).
+			Return(itemResponse(itemID), nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodDelete, "http://localhost/object/item/"+itemID, ``))).
+			Return(&http.Response{StatusCode: 200}, nil).
+			Once()
+
+		_, err := bw.GetItem(context.Background(), itemID)
+		assert.NoError(t, err)
+		err = bw.DeleteItem(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		_, ok := bw.cache.get(itemID)
+		assert.False(t, ok)
+	})
+
+	t.Run("Should return request errors", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 404}, nil).
+			Once()
+
+		err := bw.DeleteItem(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
@@ -0,0 +1,83 @@
+package bitwardentest
+
+import (
+	"sync"
+	"time"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+)
+
+// Clock is a bitwarden.Clock that only moves when Advance is called, so
+// tests can expire cached items or trigger the next sync of Watch without
+// sleeping:
+//
+//	clock := bitwardentest.NewClock(time.Now())
+//	bw := srv.Client(bitwarden.WithClock(clock), bitwarden.WithCache(time.Minute))
+//	clock.Advance(time.Minute + time.Second)
+type Clock struct {
+	mu      sync.Mutex
+	now     time.Time
+	tickers []*ticker
+}
+
+var _ bitwarden.Clock = (*Clock)(nil)
+
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTicker returns a ticker that ticks when Advance moves the clock past
+// its next tick. Like a time.Ticker, it drops ticks the receiver is not
+// ready for.
+func (c *Clock) NewTicker(d time.Duration) bitwarden.Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &ticker{clock: c, c: make(chan time.Time, 1), period: d, next: c.now.Add(d)}
+	c.tickers = append(c.tickers, t)
+	return t
+}
+
+// Advance moves the clock forward by d and delivers the ticks that are due.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	for _, t := range c.tickers {
+		for !t.next.After(c.now) {
+			select {
+			case t.c <- t.next:
+			default:
+			}
+			t.next = t.next.Add(t.period)
+		}
+	}
+}
+
+type ticker struct {
+	clock  *Clock
+	c      chan time.Time
+	period time.Duration
+	next   time.Time
+}
+
+func (t *ticker) C() <-chan time.Time { return t.c }
+
+func (t *ticker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	for i, other := range t.clock.tickers {
+		if other == t {
+			t.clock.tickers = append(t.clock.tickers[:i], t.clock.tickers[i+1:]...)
+			return
+		}
+	}
+}
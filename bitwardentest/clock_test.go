@@ -0,0 +1,50 @@
+package bitwardentest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Should expire cached items when advanced", func(t *testing.T) {
+		srv := NewServer(WithItems(bitwarden.Item{ID: "db", Type: bitwarden.TypeSecureNote, Name: ptr("db"), Notes: ptr("v1")}))
+		defer srv.Close()
+		clock := NewClock(start)
+		bw := srv.Client(bitwarden.WithClock(clock), bitwarden.WithCache(time.Minute))
+
+		_, err := bw.GetItem(context.Background(), "db")
+		assert.NoError(t, err)
+		srv.AddItem(bitwarden.Item{ID: "db", Type: bitwarden.TypeSecureNote, Name: ptr("db"), Notes: ptr("v2")})
+
+		item, err := bw.GetItem(context.Background(), "db")
+		assert.NoError(t, err)
+		assert.Equal(t, "v1", *item.Notes)
+
+		clock.Advance(time.Minute + time.Second)
+		item, err = bw.GetItem(context.Background(), "db")
+		assert.NoError(t, err)
+		assert.Equal(t, "v2", *item.Notes)
+	})
+
+	t.Run("Should tick when advanced past the interval", func(t *testing.T) {
+		clock := NewClock(start)
+		ticker := clock.NewTicker(time.Minute)
+
+		clock.Advance(30 * time.Second)
+		assert.Empty(t, ticker.C())
+		clock.Advance(3 * time.Minute) // ticks the receiver is not ready for are dropped
+		assert.Equal(t, start.Add(time.Minute), <-ticker.C())
+		assert.Empty(t, ticker.C())
+
+		ticker.Stop()
+		clock.Advance(time.Hour)
+		assert.Empty(t, ticker.C())
+		assert.Equal(t, start.Add(time.Hour+3*time.Minute+30*time.Second), clock.Now())
+	})
+}
@@ -0,0 +1,585 @@
+// Package bitwardentest provides an in-memory fake of the bw serve API for
+// tests of code using this module:
+//
+//	srv := bitwardentest.NewServer(bitwardentest.WithItems(item))
+//	defer srv.Close()
+//	bw := srv.Client()
+//
+// The fake starts unlocked unless Locked is given, and keeps all changes in
+// memory, so tests can seed the vault, run the code under test and inspect
+// the result.
+package bitwardentest
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+)
+
+type Folder = bitwarden.Folder
+
+type attachment struct {
+	itemID string
+	data   []byte
+}
+
+// Server is a fake bw serve.
+type Server struct {
+	URL string
+
+	server *httptest.Server
+
+	mu          sync.Mutex
+	password    string
+	locked      bool
+	items       map[string]bitwarden.Item
+	folders     map[string]Folder
+	orgs        []bitwarden.Organization
+	members     map[string][]bitwarden.OrgMember
+	attachments map[string]attachment
+	latency     time.Duration
+	failures    []failure
+}
+
+type failure struct {
+	match  func(r *http.Request) bool
+	status int
+	times  int // 0 means forever
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithPassword sets the master password accepted by /unlock. Defaults to
+// "password".
+func WithPassword(password string) Option {
+	return func(s *Server) { s.password = password }
+}
+
+// WithItems seeds the vault, see AddItem.
+func WithItems(items ...bitwarden.Item) Option {
+	return func(s *Server) {
+		for _, i := range items {
+			s.addItem(i)
+		}
+	}
+}
+
+// WithFolders seeds the folders. Folders without an ID get a random one.
+func WithFolders(folders ...Folder) Option {
+	return func(s *Server) {
+		for _, f := range folders {
+			if f.ID == "" {
+				f.ID = newID()
+			}
+			s.folders[f.ID] = f
+		}
+	}
+}
+
+// WithOrganizations seeds the organizations the user is a member of.
+func WithOrganizations(orgs ...bitwarden.Organization) Option {
+	return func(s *Server) { s.orgs = append(s.orgs, orgs...) }
+}
+
+// WithOrgMembers seeds the members of the organization with the given ID.
+func WithOrgMembers(orgID string, members ...bitwarden.OrgMember) Option {
+	return func(s *Server) { s.members[orgID] = append(s.members[orgID], members...) }
+}
+
+// Locked starts the server with a locked vault.
+func Locked() Option {
+	return func(s *Server) { s.locked = true }
+}
+
+// NewServer starts a fake server. Call Close when done.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		password:    "password",
+		items:       map[string]bitwarden.Item{},
+		folders:     map[string]Folder{},
+		members:     map[string][]bitwarden.OrgMember{},
+		attachments: map[string]attachment{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	return s
+}
+
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns a client for the server.
+func (s *Server) Client(opts ...bitwarden.Option) *bitwarden.BitwardenServer {
+	return bitwarden.NewFromURL(s.URL, opts...)
+}
+
+// AddItem stores an item and returns it as stored. Items without an ID get
+// a random one, and the creation and revision dates are set if missing.
+func (s *Server) AddItem(item bitwarden.Item) bitwarden.Item {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addItem(item)
+}
+
+func (s *Server) addItem(item bitwarden.Item) bitwarden.Item {
+	if item.ID == "" {
+		item.ID = newID()
+	}
+	now := time.Now().UTC()
+	if item.CreationDate.IsZero() {
+		item.CreationDate = now
+	}
+	if item.RevisionDate == nil {
+		item.RevisionDate = &now
+	}
+	s.items[item.ID] = item
+	return item
+}
+
+// Item returns the stored item with the given ID.
+func (s *Server) Item(id string) (bitwarden.Item, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, ok := s.items[id]
+	return i, ok
+}
+
+// Items returns all stored items, including deleted ones, sorted by ID.
+func (s *Server) Items() []bitwarden.Item {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items := make([]bitwarden.Item, 0, len(s.items))
+	for _, i := range s.items {
+		items = append(items, i)
+	}
+	sort.Slice(items, func(a, b int) bool { return items[a].ID < items[b].ID })
+	return items
+}
+
+// AddAttachment attaches a file to an item and returns the attachment ID.
+func (s *Server) AddAttachment(itemID, fileName string, data []byte) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addAttachment(itemID, fileName, data)
+}
+
+func (s *Server) addAttachment(itemID, fileName string, data []byte) string {
+	id := newID()
+	item := s.items[itemID]
+	size := bitwarden.EncryptedAttachmentSize(int64(len(data)))
+	item.Attachments = append(item.Attachments, bitwarden.Attachment{
+		ID:       id,
+		FileName: fileName,
+		Size:     strconv.FormatInt(size, 10),
+		SizeName: fmt.Sprintf("%d Bytes", size),
+		URL:      s.URL + "/attachments/" + id,
+	})
+	s.items[itemID] = item
+	s.attachments[id] = attachment{itemID: itemID, data: append([]byte{}, data...)}
+	return id
+}
+
+// Lock locks the vault as if /lock was called.
+func (s *Server) Lock() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.locked = true
+}
+
+// IsLocked reports whether the vault is locked.
+func (s *Server) IsLocked() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.locked
+}
+
+// SetLatency delays every response by d.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// FailNext answers the next n requests with status. Requests for /status,
+// which the client sends by itself before its first request, are not
+// counted; use FailRequests to fail those.
+func (s *Server) FailNext(n int, status int) {
+	s.FailRequests(func(r *http.Request) bool { return r.URL.Path != "/status" }, status, n)
+}
+
+// FailRequests answers requests for which match returns true with status,
+// the next n times or forever if n is 0.
+func (s *Server) FailRequests(match func(r *http.Request) bool, status int, n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, failure{match: match, status: status, times: n})
+}
+
+// injectedFailure returns the status of the first failure matching r.
+func (s *Server) injectedFailure(r *http.Request) (int, bool) {
+	for i, f := range s.failures {
+		if !f.match(r) {
+			continue
+		}
+		if f.times > 0 {
+			if f.times--; f.times == 0 {
+				s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			} else {
+				s.failures[i] = f
+			}
+		}
+		return f.status, true
+	}
+	return 0, false
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	latency := s.latency
+	status, failed := s.injectedFailure(r)
+	s.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if failed {
+		writeError(w, status, http.StatusText(status))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := r.URL.Path
+	switch {
+	case r.Method == http.MethodPost && path == "/unlock":
+		s.unlock(w, r)
+	case r.Method == http.MethodPost && path == "/lock":
+		s.locked = true
+		writeData(w, message("Your vault is locked."))
+	case r.Method == http.MethodGet && path == "/status":
+		status := "unlocked"
+		if s.locked {
+			status = "locked"
+		}
+		writeData(w, map[string]any{"object": "template", "template": map[string]any{"status": status}})
+	case s.locked:
+		writeError(w, http.StatusBadRequest, "Vault is locked.")
+	case r.Method == http.MethodGet && path == "/generate":
+		writeData(w, map[string]any{"object": "string", "data": "generated-" + newID()})
+	case r.Method == http.MethodPost && path == "/sync":
+		writeData(w, message("Syncing complete."))
+	case r.Method == http.MethodGet && path == "/list/object/items":
+		s.listItems(w, r.URL.Query())
+	case r.Method == http.MethodGet && path == "/list/object/folders":
+		s.listFolders(w)
+	case r.Method == http.MethodPost && path == "/object/item":
+		s.createItem(w, r)
+	case r.Method == http.MethodPut && strings.HasPrefix(path, "/object/item-collections/"):
+		s.itemCollections(w, r, strings.TrimPrefix(path, "/object/item-collections/"))
+	case strings.HasPrefix(path, "/object/item/"):
+		s.item(w, r, strings.TrimPrefix(path, "/object/item/"))
+	case r.Method == http.MethodGet && path == "/list/object/organizations":
+		orgs := append([]bitwarden.Organization{}, s.orgs...)
+		writeData(w, map[string]any{"object": "list", "data": orgs})
+	case r.Method == http.MethodGet && path == "/list/object/org-members":
+		s.listOrgMembers(w, r.URL.Query().Get("organizationid"))
+	case r.Method == http.MethodPost && path == "/object/folder":
+		s.createFolder(w, r)
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "/object/folder/"):
+		s.folder(w, strings.TrimPrefix(path, "/object/folder/"))
+	case r.Method == http.MethodPost && path == "/attachment":
+		s.uploadAttachment(w, r, r.URL.Query().Get("itemid"))
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "/object/attachment/"):
+		s.attachment(w, strings.TrimPrefix(path, "/object/attachment/"), r.URL.Query().Get("itemid"))
+	case r.Method == http.MethodDelete && strings.HasPrefix(path, "/object/attachment/"):
+		s.deleteAttachment(w, strings.TrimPrefix(path, "/object/attachment/"), r.URL.Query().Get("itemid"))
+	default:
+		writeError(w, http.StatusNotFound, "Not found.")
+	}
+}
+
+func (s *Server) unlock(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Password string `json:"password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Password != s.password {
+		writeError(w, http.StatusBadRequest, "Invalid master password.")
+		return
+	}
+	s.locked = false
+	m := message("Your vault is now unlocked!")
+	m["raw"] = newID()
+	writeData(w, m)
+}
+
+func (s *Server) listItems(w http.ResponseWriter, query url.Values) {
+	trash := query.Get("trash") == "true"
+	items := []bitwarden.Item{}
+	for _, i := range s.items {
+		if (i.DeletedDate != nil) != trash || !matches(&i, query) {
+			continue
+		}
+		items = append(items, i)
+	}
+	sort.Slice(items, func(a, b int) bool { return items[a].ID < items[b].ID })
+	writeData(w, map[string]any{"object": "list", "data": items})
+}
+
+func (s *Server) listFolders(w http.ResponseWriter) {
+	folders := []Folder{}
+	for _, f := range s.folders {
+		folders = append(folders, f)
+	}
+	sort.Slice(folders, func(a, b int) bool { return folders[a].Name < folders[b].Name })
+	writeData(w, map[string]any{"object": "list", "data": folders})
+}
+
+func (s *Server) listOrgMembers(w http.ResponseWriter, orgID string) {
+	if orgID == "" {
+		writeError(w, http.StatusBadRequest, "--organizationid <organizationid> required.")
+		return
+	}
+	members := append([]bitwarden.OrgMember{}, s.members[orgID]...)
+	writeData(w, map[string]any{"object": "list", "data": members})
+}
+
+func (s *Server) createItem(w http.ResponseWriter, r *http.Request) {
+	var item bitwarden.Item
+	if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if orgID := r.URL.Query().Get("organizationid"); orgID != "" || item.OrganizationID != nil {
+		if item.OrganizationID == nil || *item.OrganizationID != orgID {
+			writeError(w, http.StatusBadRequest, "--organizationid <organizationid> does not match request object.")
+			return
+		}
+		if len(item.CollectionIDs) == 0 {
+			writeError(w, http.StatusBadRequest, "Organization items need at least one collection.")
+			return
+		}
+	}
+	item.ID = ""
+	item.CreationDate = time.Time{}
+	item.RevisionDate = nil
+	writeData(w, s.addItem(item))
+}
+
+func (s *Server) item(w http.ResponseWriter, r *http.Request, id string) {
+	item, ok := s.items[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not found.")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeData(w, item)
+	case http.MethodPut:
+		var edit bitwarden.Item
+		if err := json.NewDecoder(r.Body).Decode(&edit); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		now := time.Now().UTC()
+		edit.ID, edit.CreationDate, edit.RevisionDate = id, item.CreationDate, &now
+		edit.Attachments = item.Attachments
+		s.items[id] = edit
+		writeData(w, edit)
+	case http.MethodDelete:
+		if r.URL.Query().Get("permanent") == "true" {
+			delete(s.items, id)
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]any{"success": true})
+			return
+		}
+		now := time.Now().UTC()
+		item.DeletedDate = &now
+		s.items[id] = item
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]any{"success": true})
+	default:
+		writeError(w, http.StatusNotFound, "Not found.")
+	}
+}
+
+func (s *Server) itemCollections(w http.ResponseWriter, r *http.Request, id string) {
+	item, ok := s.items[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not found.")
+		return
+	}
+	if item.OrganizationID == nil {
+		writeError(w, http.StatusBadRequest, "Item does not belong to an organization.")
+		return
+	}
+	if err := json.NewDecoder(r.Body).Decode(&item.CollectionIDs); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	now := time.Now().UTC()
+	item.RevisionDate = &now
+	s.items[id] = item
+	writeData(w, item)
+}
+
+func (s *Server) createFolder(w http.ResponseWriter, r *http.Request) {
+	var f Folder
+	if err := json.NewDecoder(r.Body).Decode(&f); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	f.ID = newID()
+	s.folders[f.ID] = f
+	writeData(w, f)
+}
+
+func (s *Server) folder(w http.ResponseWriter, id string) {
+	f, ok := s.folders[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not found.")
+		return
+	}
+	writeData(w, f)
+}
+
+func (s *Server) attachment(w http.ResponseWriter, id string, itemID string) {
+	a, ok := s.attachments[id]
+	if !ok || a.itemID != itemID {
+		writeError(w, http.StatusNotFound, "Not found.")
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Write(a.data)
+}
+
+func (s *Server) uploadAttachment(w http.ResponseWriter, r *http.Request, itemID string) {
+	if _, ok := s.items[itemID]; !ok {
+		writeError(w, http.StatusNotFound, "Not found.")
+		return
+	}
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "No file provided.")
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	s.addAttachment(itemID, header.Filename, data)
+	writeData(w, s.items[itemID])
+}
+
+func (s *Server) deleteAttachment(w http.ResponseWriter, id string, itemID string) {
+	a, ok := s.attachments[id]
+	if !ok || a.itemID != itemID {
+		writeError(w, http.StatusNotFound, "Not found.")
+		return
+	}
+	delete(s.attachments, id)
+	item := s.items[itemID]
+	kept := []bitwarden.Attachment{}
+	for _, att := range item.Attachments {
+		if att.ID != id {
+			kept = append(kept, att)
+		}
+	}
+	item.Attachments = kept
+	s.items[itemID] = item
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{"success": true})
+}
+
+// matches applies the filters of a list request the way bw does.
+func matches(i *bitwarden.Item, query url.Values) bool {
+	if v := query.Get("folderid"); v != "" && (i.FolderID == nil || *i.FolderID != v) {
+		return false
+	}
+	if v := query.Get("collectionid"); v != "" && !slices.Contains(i.CollectionIDs, v) && (i.CollectionID == nil || *i.CollectionID != v) {
+		return false
+	}
+	if v := query.Get("organizationid"); v != "" && (i.OrganizationID == nil || *i.OrganizationID != v) {
+		return false
+	}
+	if v := query.Get("search"); v != "" && !matchesSearch(i, v) {
+		return false
+	}
+	if v := query.Get("url"); v != "" && !matchesURL(i, v) {
+		return false
+	}
+	return true
+}
+
+func matchesSearch(i *bitwarden.Item, term string) bool {
+	term = strings.ToLower(term)
+	for _, field := range []string{"name", "notes", "username", "uri"} {
+		if v, err := i.Value(field); err == nil && strings.Contains(strings.ToLower(v), term) {
+			return true
+		}
+	}
+	return i.ID == term
+}
+
+func matchesURL(i *bitwarden.Item, rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil || i.Login == nil {
+		return false
+	}
+	for _, uri := range i.Login.URIs {
+		if uri.URI == nil {
+			continue
+		}
+		if l, err := url.Parse(*uri.URI); err == nil && l.Hostname() != "" && strings.EqualFold(l.Hostname(), u.Hostname()) {
+			return true
+		}
+	}
+	return false
+}
+
+func message(title string) map[string]any {
+	return map[string]any{"object": "message", "title": title, "message": nil}
+}
+
+func writeData(w http.ResponseWriter, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"success": true, "data": data})
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{"success": false, "message": msg})
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,364 @@
+package bitwardentest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func ptr(s string) *string { return &s }
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Should serve seeded items", func(t *testing.T) {
+		srv := NewServer(WithItems(bitwarden.Item{ID: "db", Type: bitwarden.TypeLogin, Name: ptr("db"), Login: &bitwarden.Login{Password: ptr("hunter2")}}))
+		defer srv.Close()
+
+		login, err := srv.Client().GetLogin(ctx, "db")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "hunter2", *login.Password)
+	})
+
+	t.Run("Should require unlocking a locked vault", func(t *testing.T) {
+		srv := NewServer(Locked(), WithPassword("secret"), WithItems(bitwarden.Item{ID: "db"}))
+		defer srv.Close()
+		bw := srv.Client()
+
+		_, err := bw.GetItem(ctx, "db")
+		assert.ErrorIs(t, err, bitwarden.ErrVaultLocked)
+		assert.ErrorIs(t, bw.Unlock(ctx, "wrong"), bitwarden.ErrWrongPassword)
+		assert.NoError(t, bw.Unlock(ctx, "secret"))
+		_, err = bw.GetItem(ctx, "db")
+		assert.NoError(t, err)
+		assert.NoError(t, bw.Lock(ctx))
+		assert.True(t, srv.IsLocked())
+	})
+
+	t.Run("Should report the health of the vault", func(t *testing.T) {
+		srv := NewServer(Locked(), WithPassword("secret"))
+		defer srv.Close()
+		bw := srv.Client()
+
+		assert.ErrorIs(t, bw.Healthy(ctx), bitwarden.ErrVaultLocked)
+		assert.NoError(t, bw.Unlock(ctx, "secret"))
+		assert.NoError(t, bw.Healthy(ctx))
+	})
+
+	t.Run("Should store created, edited and deleted items", func(t *testing.T) {
+		srv := NewServer()
+		defer srv.Close()
+		bw := srv.Client()
+
+		created, err := bw.CreateItem(ctx, &bitwarden.Item{Type: bitwarden.TypeSecureNote, Name: ptr("ENV"), Notes: ptr("A=1")})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, created.ID)
+
+		created.Notes = ptr("A=2")
+		_, err = bw.EditItem(ctx, created)
+		assert.NoError(t, err)
+		stored, _ := srv.Item(created.ID)
+		assert.Equal(t, "A=2", *stored.Notes)
+
+		assert.NoError(t, bw.DeleteItem(ctx, created.ID))
+		items, err := bw.ListItems(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, items)
+		items, err = bw.ListItems(ctx, bitwarden.InTrash())
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+	})
+
+	t.Run("Should filter listed items", func(t *testing.T) {
+		srv := NewServer(
+			WithFolders(Folder{ID: "6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a", Name: "infra"}),
+			WithItems(
+				bitwarden.Item{ID: "a", Name: ptr("GitHub"), FolderID: ptr("6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a"), Type: bitwarden.TypeLogin, Login: &bitwarden.Login{URIs: []bitwarden.URI{{URI: ptr("https://github.com/login")}}}},
+				bitwarden.Item{ID: "b", Name: ptr("GitLab"), Type: bitwarden.TypeLogin, Login: &bitwarden.Login{URIs: []bitwarden.URI{{URI: ptr("https://gitlab.com")}}}},
+				bitwarden.Item{ID: "c", Name: ptr("Notes"), Type: bitwarden.TypeSecureNote},
+			),
+		)
+		defer srv.Close()
+		bw := srv.Client()
+
+		ids := func(opts ...bitwarden.ListOption) []string {
+			items, err := bw.ListItems(ctx, opts...)
+			assert.NoError(t, err)
+			var ids []string
+			for _, i := range items {
+				ids = append(ids, i.ID)
+			}
+			return ids
+		}
+
+		assert.Equal(t, []string{"a", "b", "c"}, ids())
+		assert.Equal(t, []string{"a"}, ids(bitwarden.InFolder("6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a")))
+		assert.Equal(t, []string{"a", "b"}, ids(bitwarden.Search("git")))
+		assert.Equal(t, []string{"b"}, ids(bitwarden.MatchingURL("https://gitlab.com/team/repo")))
+	})
+
+	t.Run("Should serve attachments", func(t *testing.T) {
+		srv := NewServer(WithItems(bitwarden.Item{ID: "cert"}))
+		defer srv.Close()
+		attachmentID := srv.AddAttachment("cert", "tls.crt", []byte("-----BEGIN CERTIFICATE-----"))
+
+		r, err := srv.Client().DownloadAttachment(ctx, "cert", attachmentID)
+		assert.NoError(t, err)
+		defer r.Close()
+		data, err := io.ReadAll(r)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "-----BEGIN CERTIFICATE-----", string(data))
+	})
+
+	t.Run("Should sync attachments with a directory", func(t *testing.T) {
+		srv := NewServer(WithItems(bitwarden.Item{ID: "cert", Type: bitwarden.TypeSecureNote, Name: ptr("certificates")}))
+		defer srv.Close()
+		srv.AddAttachment("cert", "tls.crt", []byte("old"))
+		srv.AddAttachment("cert", "ca.crt", []byte("ca"))
+		srv.AddAttachment("cert", "removed.key", []byte("key"))
+		dir := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "tls.crt"), []byte("renewed"), 0o600))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "ca.crt"), []byte("ca"), 0o600))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "tls.key"), []byte("secret"), 0o600))
+		attachments := func() map[string]string {
+			ids := map[string]string{}
+			item, _ := srv.Item("cert")
+			for _, a := range item.Attachments {
+				ids[a.FileName] = a.ID
+			}
+			return ids
+		}
+		contents := func() map[string]string {
+			data := map[string]string{}
+			for name, id := range attachments() {
+				r, err := srv.Client().DownloadAttachment(ctx, "cert", id)
+				assert.NoError(t, err)
+				b, _ := io.ReadAll(r)
+				r.Close()
+				data[name] = string(b)
+			}
+			return data
+		}
+
+		assert.NoError(t, srv.Client().SyncAttachments(ctx, "cert", dir))
+		assert.Equal(t, map[string]string{"ca.crt": "ca", "tls.crt": "renewed", "tls.key": "secret"}, contents())
+
+		synced := attachments()
+		assert.NoError(t, srv.Client().SyncAttachments(ctx, "cert", dir))
+		assert.Equal(t, synced, attachments(), "unchanged files are not uploaded again")
+
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "tls.crt"), []byte("rotated"), 0o600))
+		assert.NoError(t, os.Remove(filepath.Join(dir, "tls.key")))
+		assert.NoError(t, srv.Client().SyncAttachments(ctx, "cert", dir))
+		assert.Equal(t, map[string]string{"ca.crt": "ca", "tls.crt": "rotated"}, contents())
+		assert.Equal(t, synced["ca.crt"], attachments()["ca.crt"])
+		var fields []string
+		item, _ := srv.Item("cert")
+		for _, f := range item.Fields {
+			fields = append(fields, f.Name)
+		}
+		assert.ElementsMatch(t, []string{bitwarden.AttachmentChecksumField + "ca.crt", bitwarden.AttachmentChecksumField + "tls.crt"}, fields)
+	})
+
+	t.Run("Should inject failures", func(t *testing.T) {
+		srv := NewServer(WithItems(bitwarden.Item{ID: "db"}))
+		defer srv.Close()
+		bw := srv.Client()
+
+		srv.FailNext(1, http.StatusInternalServerError)
+		_, err := bw.GetItem(ctx, "db")
+		assert.ErrorIs(t, err, bitwarden.ErrUnexpectedStatusCode)
+		_, err = bw.GetItem(ctx, "db")
+		assert.NoError(t, err)
+
+		srv.FailRequests(func(r *http.Request) bool { return r.URL.Path == "/sync" }, http.StatusBadRequest, 0)
+		for i := 0; i < 2; i++ {
+			assert.ErrorIs(t, bw.Sync(ctx), bitwarden.ErrBadRequest)
+		}
+	})
+
+	t.Run("Should run bulk operations", func(t *testing.T) {
+		org := "org"
+		srv := NewServer(WithItems(
+			bitwarden.Item{ID: "a", Name: ptr("a"), OrganizationID: &org},
+			bitwarden.Item{ID: "b", Name: ptr("b")},
+		))
+		defer srv.Close()
+		bw := srv.Client()
+
+		assert.NoError(t, bw.MoveItemsToFolder(ctx, []string{"a", "b"}, "folder"))
+		a, _ := srv.Item("a")
+		assert.Equal(t, "folder", *a.FolderID)
+
+		err := bw.AssignItemsToCollections(ctx, []string{"a", "b"}, []string{"c"})
+		var bulkErr bitwarden.BulkError
+		if assert.ErrorAs(t, err, &bulkErr) {
+			assert.Contains(t, bulkErr, "b")
+			assert.NotContains(t, bulkErr, "a")
+		}
+		a, _ = srv.Item("a")
+		assert.Equal(t, []string{"c"}, a.CollectionIDs)
+
+		assert.NoError(t, bw.DeleteItems(ctx, []string{"a", "b"}))
+		items, err := bw.ListItems(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, items)
+	})
+
+	t.Run("Should list seeded organizations", func(t *testing.T) {
+		srv := NewServer(WithOrganizations(bitwarden.Organization{ID: "o1", Name: "Acme"}))
+		defer srv.Close()
+
+		orgs, err := srv.Client().ListOrganizations(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []bitwarden.Organization{{ID: "o1", Name: "Acme"}}, orgs)
+	})
+
+	t.Run("Should list seeded organization members", func(t *testing.T) {
+		srv := NewServer(WithOrgMembers("o1", bitwarden.OrgMember{ID: "m1", Email: "jane@example.com"}))
+		defer srv.Close()
+		bw := srv.Client()
+
+		members, err := bw.ListOrgMembers(ctx, "o1")
+		assert.NoError(t, err)
+		assert.Equal(t, []bitwarden.OrgMember{{ID: "m1", Email: "jane@example.com"}}, members)
+
+		_, err = bw.ListOrgMembers(ctx, "")
+		assert.ErrorIs(t, err, bitwarden.ErrBadRequest)
+	})
+
+	t.Run("Should create organization items in their collections", func(t *testing.T) {
+		srv := NewServer()
+		defer srv.Close()
+		bw := srv.Client()
+
+		item := bitwarden.Item{Name: ptr("shared"), OrganizationID: ptr("org"), CollectionIDs: []string{"c1"}}
+		created, err := bw.CreateItem(ctx, &item)
+		assert.NoError(t, err)
+		stored, _ := srv.Item(created.ID)
+		assert.Equal(t, []string{"c1"}, stored.CollectionIDs)
+	})
+
+	t.Run("Should empty the trash", func(t *testing.T) {
+		srv := NewServer(WithItems(bitwarden.Item{ID: "a", Name: ptr("a")}))
+		defer srv.Close()
+		bw := srv.Client()
+
+		assert.NoError(t, bw.DeleteItem(ctx, "a"))
+		trash, err := bw.ListTrash(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, trash, 1)
+
+		assert.NoError(t, bw.EmptyTrash(ctx, 0))
+		_, ok := srv.Item("a")
+		assert.False(t, ok)
+	})
+
+	t.Run("Should apply a desired state", func(t *testing.T) {
+		infraID := "5b0c4a1e-2f3d-4c6b-9a8e-7d1f0e2c3b4a"
+		srv := NewServer(WithFolders(Folder{ID: infraID, Name: "infra"}), WithItems(
+			bitwarden.Item{ID: "db", Type: bitwarden.TypeLogin, Name: ptr("db"), FolderID: ptr(infraID), Login: &bitwarden.Login{Password: ptr("old")}},
+			bitwarden.Item{ID: "stale", Type: bitwarden.TypeLogin, Name: ptr("stale"), FolderID: ptr(infraID), Login: &bitwarden.Login{}},
+			bitwarden.Item{ID: "other", Type: bitwarden.TypeLogin, Name: ptr("other"), Login: &bitwarden.Login{}},
+		))
+		defer srv.Close()
+		bw := srv.Client()
+		desired := []bitwarden.ItemSpec{
+			{Item: bitwarden.Item{Type: bitwarden.TypeLogin, Name: ptr("db"), Login: &bitwarden.Login{Password: ptr("new")}}, Folder: "infra"},
+			{Item: bitwarden.Item{Type: bitwarden.TypeLogin, Name: ptr("api"), Login: &bitwarden.Login{Username: ptr("svc")}}, Folder: "infra"},
+		}
+
+		scope := bitwarden.WithApplyScope(bitwarden.InFolder(infraID))
+		result, err := bw.Apply(ctx, desired, bitwarden.WithPrune(), scope)
+		assert.NoError(t, err)
+		assert.Len(t, result.Changes, 3)
+
+		db, _ := srv.Item("db")
+		assert.Equal(t, "new", *db.Login.Password)
+		assert.Equal(t, "old", db.PasswordHistory[0].Password)
+		folders, _ := bw.ListFolders(ctx)
+		if assert.Len(t, folders, 1) {
+			assert.Equal(t, folders[0].ID, *db.FolderID)
+		}
+		stale, _ := srv.Item("stale")
+		assert.NotNil(t, stale.DeletedDate)
+		other, _ := srv.Item("other")
+		assert.Nil(t, other.DeletedDate, "items out of scope are never pruned")
+
+		result, err = bw.Apply(ctx, desired, bitwarden.WithPrune(), scope)
+		assert.NoError(t, err)
+		assert.Empty(t, result.Changes)
+		assert.Equal(t, 2, result.Unchanged)
+	})
+
+	t.Run("Should keep applied items in the scope", func(t *testing.T) {
+		folderID := "5b0c4a1e-2f3d-4c6b-9a8e-7d1f0e2c3b4a"
+		orgID, collectionID := "0b7e5a4c-3d2f-4e1a-9c8b-7a6f5e4d3c2b", "b4d2c1e0-8f7a-4b6c-9d5e-3f2a1b0c9d8e"
+		srv := NewServer(WithFolders(Folder{ID: folderID, Name: "infra"}), WithItems(
+			bitwarden.Item{ID: "db", Type: bitwarden.TypeLogin, Name: ptr("db"), FolderID: ptr(folderID), Login: &bitwarden.Login{}},
+		))
+		defer srv.Close()
+		bw := srv.Client()
+
+		for _, scope := range [][]bitwarden.ListOption{
+			{bitwarden.InFolder(folderID)},
+			{bitwarden.InOrganization(orgID), bitwarden.InCollection(collectionID)},
+		} {
+			desired := []bitwarden.ItemSpec{
+				{Item: bitwarden.Item{Type: bitwarden.TypeLogin, Name: ptr("db"), Login: &bitwarden.Login{Username: ptr("svc")}}},
+				{Item: bitwarden.Item{Type: bitwarden.TypeSecureNote, Name: ptr("notes"), OrganizationID: ptr("other")}},
+			}
+
+			result, err := bw.Apply(ctx, desired, bitwarden.WithApplyScope(scope...))
+			assert.NoError(t, err)
+			assert.NotEmpty(t, result.Changes)
+
+			result, err = bw.Apply(ctx, desired, bitwarden.WithApplyScope(scope...))
+			assert.NoError(t, err)
+			assert.Empty(t, result.Changes, "a second apply changes nothing")
+			assert.Equal(t, 2, result.Unchanged)
+		}
+		db, _ := srv.Item("db")
+		assert.Equal(t, folderID, *db.FolderID)
+	})
+
+	t.Run("Should rotate and revert passwords", func(t *testing.T) {
+		srv := NewServer(WithItems(bitwarden.Item{ID: "db", Type: bitwarden.TypeLogin, Name: ptr("db"), Login: &bitwarden.Login{Password: ptr("hunter2")}}))
+		defer srv.Close()
+		bw := srv.Client()
+
+		old, new, err := bw.RotateLoginPassword(ctx, "db", bitwarden.GenerateOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, "hunter2", old)
+		stored, _ := srv.Item("db")
+		assert.Equal(t, new, *stored.Login.Password)
+
+		restored, err := bw.RevertLoginPassword(ctx, "db")
+		assert.NoError(t, err)
+		assert.Equal(t, "hunter2", restored)
+		stored, _ = srv.Item("db")
+		assert.Equal(t, "hunter2", *stored.Login.Password)
+	})
+
+	t.Run("Should delay responses", func(t *testing.T) {
+		srv := NewServer(WithItems(bitwarden.Item{ID: "db"}))
+		defer srv.Close()
+		srv.SetLatency(time.Second)
+
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		_, err := srv.Client().GetItem(ctx, "db")
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,109 @@
+package bitwarden
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+)
+
+// maxPooledBuffer is the capacity above which buffers are left to the
+// garbage collector, so one large vault listing does not stay in memory.
+const maxPooledBuffer = 1 << 20
+
+var bufferPool = sync.Pool{New: func() any { return &bytes.Buffer{} }}
+
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+// putBuffer zeroes buf and returns it to the pool. Buffers hold unlock
+// bodies and decrypted items, which must not linger in reused memory.
+func putBuffer(buf *bytes.Buffer) {
+	clear(buf.Bytes()[:buf.Cap()])
+	if buf.Cap() > maxPooledBuffer {
+		return
+	}
+	buf.Reset()
+	bufferPool.Put(buf)
+}
+
+// readResponse reads the body of r into a pooled buffer, which the caller
+// must return with putBuffer.
+func readResponse(r *http.Response) (*bytes.Buffer, error) {
+	buf := getBuffer()
+	if r.ContentLength > 0 && r.ContentLength <= maxPooledBuffer {
+		buf.Grow(int(r.ContentLength) + bytes.MinRead) // ReadFrom needs room to see EOF
+	}
+	if _, err := buf.ReadFrom(r.Body); err != nil {
+		putBuffer(buf)
+		return nil, err
+	}
+	return buf, nil
+}
+
+// requestBody is a JSON request body in a pooled buffer. Transports may read
+// and close the body after Do returned, so the buffer only goes back to the
+// pool once send is done with it and every reader of it is closed, including
+// those of GetBody. Readers that are never closed leave the buffer to the
+// garbage collector.
+type requestBody struct {
+	buf   *bytes.Buffer
+	refs  atomic.Int32
+	first bodyReader
+}
+
+// encodeBody encodes v like json.Marshal into a pooled buffer. The caller
+// must call release when it no longer uses the body.
+func encodeBody(v any) (*requestBody, error) {
+	buf := getBuffer()
+	if err := json.NewEncoder(buf).Encode(v); err != nil {
+		putBuffer(buf)
+		return nil, err
+	}
+	buf.Truncate(buf.Len() - 1) // Encode ends with a newline, Marshal does not
+	body := &requestBody{buf: buf}
+	body.refs.Store(2) // the caller and the first reader
+	body.first.Reset(buf.Bytes())
+	body.first.body = body
+	return body, nil
+}
+
+// reader returns a new reader of the body, for GetBody.
+func (b *requestBody) reader() io.ReadCloser {
+	b.refs.Add(1)
+	r := &bodyReader{body: b}
+	r.Reset(b.buf.Bytes())
+	return r
+}
+
+func (b *requestBody) release() {
+	if b.refs.Add(-1) == 0 {
+		putBuffer(b.buf)
+	}
+}
+
+type bodyReader struct {
+	bytes.Reader
+	body   *requestBody
+	closed atomic.Bool
+}
+
+func (r *bodyReader) Close() error {
+	if r.closed.CompareAndSwap(false, true) {
+		r.body.release()
+	}
+	return nil
+}
+
+// copyBuffer copies r to w in chunks through buf, or with io.Copy if buf is
+// nil. io.CopyBuffer alone would not use buf if r implements io.WriterTo or
+// w implements io.ReaderFrom, which pipes, files and connections do.
+func copyBuffer(w io.Writer, r io.Reader, buf []byte) (int64, error) {
+	if buf == nil {
+		return io.Copy(w, r)
+	}
+	return io.CopyBuffer(struct{ io.Writer }{w}, struct{ io.Reader }{r}, buf)
+}
@@ -0,0 +1,86 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeBody(t *testing.T) {
+	t.Run("Should encode like json.Marshal", func(t *testing.T) {
+		v := map[string]any{"name": "<b>&", "n": 1}
+		want, _ := json.Marshal(v)
+
+		body, err := encodeBody(v)
+
+		assert.NoError(t, err)
+		got, _ := io.ReadAll(&body.first)
+		assert.Equal(t, string(want), string(got))
+	})
+
+	t.Run("Should keep the buffer until every reader is closed", func(t *testing.T) {
+		body, err := encodeBody("secret")
+		assert.NoError(t, err)
+		again := body.reader()
+
+		body.release()
+		body.first.Close()
+		body.first.Close() // closing twice releases once
+		assert.Equal(t, int32(1), body.refs.Load())
+		data, _ := io.ReadAll(again)
+		assert.Equal(t, `"secret"`, string(data))
+
+		again.Close()
+		assert.Equal(t, int32(0), body.refs.Load())
+	})
+}
+
+func TestPutBuffer(t *testing.T) {
+	t.Run("Should zero the buffer before pooling it", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		buf.WriteString(`{"password":"hunter2"}`)
+		data := buf.Bytes()[:buf.Cap()]
+
+		putBuffer(buf)
+
+		assert.Equal(t, make([]byte, len(data)), data)
+	})
+}
+
+func TestPooledBodies(t *testing.T) {
+	t.Run("Should send intact bodies concurrently", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var item Item
+			if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"success": true, "data": item})
+		}))
+		defer srv.Close()
+		bw := NewFromURL(srv.URL)
+		bw.unlocked.Store(true)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				name := fmt.Sprintf("item %d", i)
+				item, err := bw.EditItem(context.Background(), &Item{ID: fmt.Sprint(i), Type: TypeSecureNote, Name: &name})
+				if assert.NoError(t, err) {
+					assert.Equal(t, name, *item.Name)
+				}
+			}(i)
+		}
+		wg.Wait()
+	})
+}
@@ -0,0 +1,129 @@
+package bitwarden
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const bulkConcurrency = 8
+
+// BulkError is returned by the bulk operations when some items failed. It
+// maps the ID of each failed item to its error; items that are not in the
+// map succeeded.
+type BulkError map[string]error
+
+func (e BulkError) Error() string {
+	ids := make([]string, 0, len(e))
+	for id := range e {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	msgs := make([]string, len(ids))
+	for i, id := range ids {
+		msgs[i] = fmt.Sprintf("%s: %s", id, e[id])
+	}
+	return fmt.Sprintf("%d items failed: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors of the failed items, so errors.Is matches if
+// any item failed with the target.
+func (e BulkError) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// bulk runs fn for every ID with bounded concurrency and returns a BulkError
+// with the failures, or nil. Once ctx is done no more items are started and
+// the remaining ones fail with the error of ctx.
+func bulk(ctx context.Context, ids []string, fn func(ctx context.Context, id string) error) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs = BulkError{}
+	)
+	sem := make(chan struct{}, bulkConcurrency)
+loop:
+	for i, id := range ids {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			mu.Lock()
+			for _, id := range ids[i:] {
+				errs[id] = ctx.Err()
+			}
+			mu.Unlock()
+			break loop
+		}
+		wg.Add(1)
+		go func(id string) {
+			defer func() { <-sem; wg.Done() }()
+			if err := fn(ctx, id); err != nil {
+				mu.Lock()
+				errs[id] = err
+				mu.Unlock()
+			}
+		}(id)
+	}
+	wg.Wait()
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// DeleteItems moves the items to the trash. If some items could not be
+// deleted, it returns a BulkError.
+func (b *BitwardenServer) DeleteItems(ctx context.Context, ids []string) error {
+	return bulk(ctx, ids, b.DeleteItem)
+}
+
+// MoveItemsToFolder moves the items to the folder with the given ID, or out
+// of any folder if folderID is empty. If some items could not be moved, it
+// returns a BulkError.
+func (b *BitwardenServer) MoveItemsToFolder(ctx context.Context, ids []string, folderID string) error {
+	return bulk(ctx, ids, func(ctx context.Context, id string) error {
+		item, err := b.fetchItem(ctx, id)
+		if err != nil {
+			return err
+		}
+		item.FolderID = nil
+		if folderID != "" {
+			item.FolderID = &folderID
+		}
+		_, err = b.EditItem(ctx, item)
+		return err
+	})
+}
+
+// AssignItemsToCollections sets the collections of the items, which must
+// belong to an organization. If some items could not be assigned, it
+// returns a BulkError.
+func (b *BitwardenServer) AssignItemsToCollections(ctx context.Context, ids []string, collectionIDs []string) error {
+	if collectionIDs == nil {
+		collectionIDs = []string{}
+	}
+	return bulk(ctx, ids, func(ctx context.Context, id string) error {
+		return b.setCollections(ctx, id, collectionIDs)
+	})
+}
+
+// setCollections replaces the collections of the item.
+func (b *BitwardenServer) setCollections(ctx context.Context, id string, collectionIDs []string) error {
+	resp := struct {
+		Data Item `json:"data"`
+	}{}
+	if err := b.request(ctx, http.MethodPut, "/object/item-collections/"+id, collectionIDs, &resp); err != nil {
+		b.record(ctx, AuditUpdate, id, nil, err)
+		return err
+	}
+	b.record(ctx, AuditUpdate, id, &resp.Data, nil)
+	b.cache.put(id, &resp.Data)
+	return nil
+}
@@ -0,0 +1,129 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBulk(t *testing.T) {
+	ok := func() *http.Response {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"success":true}`))}
+	}
+
+	t.Run("Should delete all items and report failures per ID", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		for _, id := range []string{"1", "2"} {
+			client.
+				On("Do", mock.MatchedBy(checkRequest(http.MethodDelete, "http://localhost/object/item/"+id, ``))).
+				Return(ok(), nil).
+				Once()
+		}
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodDelete, "http://localhost/object/item/3", ``))).
+			Return(&http.Response{StatusCode: 404}, nil).
+			Once()
+
+		err := bw.DeleteItems(context.Background(), []string{"1", "2", "3"})
+
+		client.AssertExpectations(t)
+		var bulkErr BulkError
+		if assert.True(t, errors.As(err, &bulkErr)) {
+			assert.Len(t, bulkErr, 1)
+			assert.ErrorIs(t, bulkErr["3"], ErrNotFound)
+		}
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.True(t, strings.HasPrefix(err.Error(), "1 items failed: 3: "))
+	})
+
+	t.Run("Should stop starting items when the context is done", func(t *testing.T) {
+		ids := make([]string, bulkConcurrency+2)
+		for i := range ids {
+			ids[i] = fmt.Sprint(i)
+		}
+		started, release := make(chan struct{}), make(chan struct{})
+		ctx, cancel := context.WithCancel(context.Background())
+
+		done := make(chan error)
+		go func() {
+			done <- bulk(ctx, ids, func(context.Context, string) error {
+				started <- struct{}{}
+				<-release
+				return nil
+			})
+		}()
+		for i := 0; i < bulkConcurrency; i++ {
+			<-started
+		}
+		cancel()
+		close(release)
+		err := <-done
+
+		var bulkErr BulkError
+		if assert.ErrorAs(t, err, &bulkErr) {
+			assert.Len(t, bulkErr, 2)
+			assert.ErrorIs(t, err, context.Canceled)
+		}
+	})
+
+	t.Run("Should return nil when all items succeed", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.Anything).
+			Return(func(*http.Request) (*http.Response, error) { return ok(), nil }).
+			Times(20)
+
+		ids := make([]string, 20)
+		for i := range ids {
+			ids[i] = string(rune('a' + i))
+		}
+		err := bw.DeleteItems(context.Background(), ids)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should move fetched items to the folder", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/1", ``))).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"data":{"id":"1","type":2,"name":"ENV","folderId":"old"}}`))}, nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequestJSON(http.MethodPut, "http://localhost/object/item/1", func(body map[string]any) bool {
+				return body["folderId"] == "new"
+			}))).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"data":{"id":"1","type":2,"folderId":"new"}}`))}, nil).
+			Once()
+
+		err := bw.MoveItemsToFolder(context.Background(), []string{"1"}, "new")
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should assign collections", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodPut, "http://localhost/object/item-collections/1", `["c1","c2"]`))).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"data":{"id":"1","collectionIds":["c1","c2"]}}`))}, nil).
+			Once()
+
+		err := bw.AssignItemsToCollections(context.Background(), []string{"1"}, []string{"c1", "c2"})
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,145 @@
+// Package bwoauth2 provides an oauth2.TokenSource that reads its client
+// credentials from a Bitwarden item, so services never embed them in config.
+//
+// The item is a login whose username is the client ID and whose password is
+// the client secret. Optional custom fields:
+//
+//	token_url      the token endpoint, if not set in Config
+//	scopes         space separated scopes, if not set in Config
+//	refresh_token  use the refresh token grant instead of client credentials
+//
+// Token endpoints that rotate refresh tokens invalidate the stored one on
+// use. The rotated token is kept in memory and, if the client implements
+// FieldSetter, written back to the refresh_token field as a hidden field.
+package bwoauth2
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+var ErrNoTokenURL = errors.New("no token url configured")
+
+type Client interface {
+	GetItem(ctx context.Context, id string) (*bitwarden.Item, error)
+}
+
+// FieldSetter is implemented by clients that can store a rotated refresh
+// token, such as *bitwarden.BitwardenServer.
+type FieldSetter interface {
+	SetField(ctx context.Context, itemID, name, value string, typ bitwarden.FieldType) error
+}
+
+type Config struct {
+	// TokenURL overrides the token_url field of the item.
+	TokenURL string
+	// Scopes overrides the scopes field of the item.
+	Scopes []string
+	// AuthStyle is how the client credentials are sent to the token endpoint.
+	AuthStyle oauth2.AuthStyle
+}
+
+// TokenSource returns a token source that caches tokens until they expire.
+// The item is read again for every new token, so rotated credentials are
+// picked up without a restart. ctx is used for the token requests and may
+// carry a custom *http.Client under oauth2.HTTPClient.
+func TokenSource(ctx context.Context, c Client, itemID string, cfg Config) oauth2.TokenSource {
+	return oauth2.ReuseTokenSource(nil, &itemTokenSource{ctx: ctx, client: c, itemID: itemID, cfg: cfg})
+}
+
+type itemTokenSource struct {
+	ctx    context.Context
+	client Client
+	itemID string
+	cfg    Config
+
+	mu sync.Mutex
+	// stored is the refresh token last seen in the item and rotated the one
+	// the token endpoint issued in exchange for it. rotated is used while the
+	// item still holds stored, that is until the write back succeeds or
+	// someone else changes the item.
+	stored, rotated string
+}
+
+func (s *itemTokenSource) Token() (*oauth2.Token, error) {
+	item, err := s.client.GetItem(s.ctx, s.itemID)
+	if err != nil {
+		return nil, err
+	}
+	clientID, err := item.Value("username")
+	if err != nil {
+		return nil, err
+	}
+	clientSecret, err := item.Value("password")
+	if err != nil {
+		return nil, err
+	}
+
+	tokenURL := s.cfg.TokenURL
+	if tokenURL == "" {
+		tokenURL, _ = item.Value("token_url")
+	}
+	if tokenURL == "" {
+		return nil, ErrNoTokenURL
+	}
+	scopes := s.cfg.Scopes
+	if scopes == nil {
+		if v, err := item.Value("scopes"); err == nil {
+			scopes = strings.Fields(v)
+		}
+	}
+
+	if refreshToken, err := item.Value("refresh_token"); err == nil {
+		cfg := oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			Endpoint:     oauth2.Endpoint{TokenURL: tokenURL, AuthStyle: s.cfg.AuthStyle},
+			Scopes:       scopes,
+		}
+		return s.refresh(cfg, refreshToken)
+	}
+
+	cfg := clientcredentials.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		TokenURL:     tokenURL,
+		Scopes:       scopes,
+		AuthStyle:    s.cfg.AuthStyle,
+	}
+	return cfg.Token(s.ctx)
+}
+
+// refresh uses the refresh token grant, preferring a rotated token over the
+// stored one it replaced, and keeps any new refresh token.
+func (s *itemTokenSource) refresh(cfg oauth2.Config, stored string) (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	refreshToken := stored
+	if s.rotated != "" && s.stored == stored {
+		refreshToken = s.rotated
+	}
+	token, err := cfg.TokenSource(s.ctx, &oauth2.Token{RefreshToken: refreshToken}).Token()
+	if err != nil {
+		return nil, err
+	}
+	if token.RefreshToken == "" || token.RefreshToken == stored {
+		return token, nil
+	}
+
+	s.stored, s.rotated = stored, token.RefreshToken
+	if setter, ok := s.client.(FieldSetter); ok {
+		// A failed write is not fatal: the rotated token stays in memory
+		// and the write is retried with the next token.
+		if err := setter.SetField(s.ctx, s.itemID, "refresh_token", token.RefreshToken, bitwarden.FieldHidden); err == nil {
+			s.stored, s.rotated = token.RefreshToken, ""
+		}
+	}
+	return token, nil
+}
@@ -0,0 +1,153 @@
+package bwoauth2
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+var _ Client = (*bitwarden.BitwardenServer)(nil)
+
+type fakeClient struct {
+	items map[string]*bitwarden.Item
+	calls int
+}
+
+func (f *fakeClient) GetItem(_ context.Context, id string) (*bitwarden.Item, error) {
+	f.calls++
+	if i, ok := f.items[id]; ok {
+		return i, nil
+	}
+	return nil, bitwarden.ErrNotFound
+}
+
+type fakeSetter struct {
+	*fakeClient
+}
+
+func (f fakeSetter) SetField(_ context.Context, id, name, value string, typ bitwarden.FieldType) error {
+	f.items[id].SetField(name, value, typ)
+	return nil
+}
+
+var _ FieldSetter = (*bitwarden.BitwardenServer)(nil)
+
+func ptr(s string) *string { return &s }
+
+func newTokenServer(t *testing.T, forms *[]map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		id, secret, _ := r.BasicAuth()
+		*forms = append(*forms, map[string]string{
+			"client_id":     id,
+			"client_secret": secret,
+			"grant_type":    r.PostForm.Get("grant_type"),
+			"scope":         r.PostForm.Get("scope"),
+			"refresh_token": r.PostForm.Get("refresh_token"),
+		})
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"access","token_type":"Bearer","expires_in":3600}`))
+	}))
+}
+
+func TestTokenSource(t *testing.T) {
+	t.Run("Should use the client credentials grant and cache the token", func(t *testing.T) {
+		var forms []map[string]string
+		server := newTokenServer(t, &forms)
+		defer server.Close()
+
+		c := &fakeClient{items: map[string]*bitwarden.Item{"app": {
+			Login:  &bitwarden.Login{Username: ptr("id"), Password: ptr("secret")},
+			Fields: []bitwarden.Field{{Name: "token_url", Value: server.URL}, {Name: "scopes", Value: "read write"}},
+		}}}
+
+		ts := TokenSource(context.Background(), c, "app", Config{AuthStyle: oauth2.AuthStyleInHeader})
+		for i := 0; i < 2; i++ {
+			token, err := ts.Token()
+			assert.NoError(t, err)
+			assert.Equal(t, "access", token.AccessToken)
+		}
+
+		assert.Equal(t, 1, c.calls)
+		assert.Equal(t, []map[string]string{{"client_id": "id", "client_secret": "secret", "grant_type": "client_credentials", "scope": "read write", "refresh_token": ""}}, forms)
+	})
+
+	t.Run("Should use a stored refresh token", func(t *testing.T) {
+		var forms []map[string]string
+		server := newTokenServer(t, &forms)
+		defer server.Close()
+
+		c := &fakeClient{items: map[string]*bitwarden.Item{"app": {
+			Login:  &bitwarden.Login{Username: ptr("id"), Password: ptr("secret")},
+			Fields: []bitwarden.Field{{Name: "refresh_token", Value: "refresh"}},
+		}}}
+
+		ts := TokenSource(context.Background(), c, "app", Config{TokenURL: server.URL, AuthStyle: oauth2.AuthStyleInHeader})
+		_, err := ts.Token()
+
+		assert.NoError(t, err)
+		assert.Len(t, forms, 1)
+		assert.Equal(t, "refresh_token", forms[0]["grant_type"])
+		assert.Equal(t, "refresh", forms[0]["refresh_token"])
+	})
+
+	t.Run("Should keep rotated refresh tokens", func(t *testing.T) {
+		for name, setter := range map[string]bool{"in the item": true, "in memory": false} {
+			t.Run(name, func(t *testing.T) {
+				var used []string
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					assert.NoError(t, r.ParseForm())
+					used = append(used, r.PostForm.Get("refresh_token"))
+					w.Header().Set("Content-Type", "application/json")
+					fmt.Fprintf(w, `{"access_token":"access","token_type":"Bearer","expires_in":1,"refresh_token":"refresh-%d"}`, len(used))
+				}))
+				defer server.Close()
+
+				fake := &fakeClient{items: map[string]*bitwarden.Item{"app": {
+					Login:  &bitwarden.Login{Username: ptr("id"), Password: ptr("secret")},
+					Fields: []bitwarden.Field{{Name: "refresh_token", Value: "refresh", Type: bitwarden.FieldHidden}},
+				}}}
+				var c Client = fake
+				if setter {
+					c = fakeSetter{fake}
+				}
+
+				ts := TokenSource(context.Background(), c, "app", Config{TokenURL: server.URL})
+				for i := 0; i < 3; i++ {
+					_, err := ts.Token()
+					assert.NoError(t, err)
+				}
+
+				assert.Equal(t, []string{"refresh", "refresh-1", "refresh-2"}, used)
+				stored, _ := fake.items["app"].Value("refresh_token")
+				if setter {
+					assert.Equal(t, "refresh-3", stored)
+				} else {
+					assert.Equal(t, "refresh", stored)
+				}
+			})
+		}
+	})
+
+	t.Run("Should require a token url", func(t *testing.T) {
+		c := &fakeClient{items: map[string]*bitwarden.Item{"app": {
+			Login: &bitwarden.Login{Username: ptr("id"), Password: ptr("secret")},
+		}}}
+
+		_, err := TokenSource(context.Background(), c, "app", Config{}).Token()
+
+		assert.ErrorIs(t, err, ErrNoTokenURL)
+	})
+
+	t.Run("Should return vault errors", func(t *testing.T) {
+		_, err := TokenSource(context.Background(), &fakeClient{}, "app", Config{}).Token()
+
+		assert.ErrorIs(t, err, bitwarden.ErrNotFound)
+	})
+}
@@ -0,0 +1,136 @@
+// Package bwprometheus exposes the metrics of a BitwardenServer to
+// Prometheus:
+//
+//	c := bwprometheus.New()
+//	prometheus.MustRegister(c)
+//	bw := bitwarden.New(bitwarden.WithMetrics(c))
+//
+// The cache hit ratio is
+//
+//	sum(rate(bitwarden_cache_lookups_total{result="hit"}[5m])) / sum(rate(bitwarden_cache_lookups_total[5m]))
+//
+// bitwarden_caller_requests_total has a series per caller set with
+// bitwarden.ContextWithCaller, so callers must be job or service names, never
+// request IDs or other unbounded values.
+package bwprometheus
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector implements bitwarden.Metrics and prometheus.Collector.
+type Collector struct {
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	cache    *prometheus.CounterVec
+	unlocks  *prometheus.CounterVec
+	callers  *prometheus.CounterVec
+}
+
+var (
+	_ bitwarden.Metrics       = (*Collector)(nil)
+	_ bitwarden.CallerMetrics = (*Collector)(nil)
+)
+
+func New() *Collector {
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "bitwarden_requests_total",
+			Help: "Requests sent to bw serve.",
+		}, []string{"method", "route"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "bitwarden_request_errors_total",
+			Help: "Failed requests to bw serve by error type.",
+		}, []string{"method", "route", "type"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "bitwarden_request_duration_seconds",
+			Help:    "Latency of requests to bw serve.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		cache: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "bitwarden_cache_lookups_total",
+			Help: "Lookups in the item cache by result, hit or miss.",
+		}, []string{"result"}),
+		unlocks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "bitwarden_unlocks_total",
+			Help: "Unlock attempts by result.",
+		}, []string{"result"}),
+		callers: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "bitwarden_caller_requests_total",
+			Help: "Requests sent to bw serve by caller, for requests made with bitwarden.ContextWithCaller.",
+		}, []string{"caller", "method", "route"}),
+	}
+}
+
+func (c *Collector) collectors() []prometheus.Collector {
+	return []prometheus.Collector{c.requests, c.errors, c.duration, c.cache, c.unlocks, c.callers}
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, collector := range c.collectors() {
+		collector.Describe(ch)
+	}
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	for _, collector := range c.collectors() {
+		collector.Collect(ch)
+	}
+}
+
+func (c *Collector) ObserveRequest(method, route string, duration time.Duration, err error) {
+	c.requests.WithLabelValues(method, route).Inc()
+	c.duration.WithLabelValues(method, route).Observe(duration.Seconds())
+	if err != nil {
+		c.errors.WithLabelValues(method, route, errorType(err)).Inc()
+	}
+}
+
+func (c *Collector) ObserveCallerRequest(caller, method, route string, _ error) {
+	c.callers.WithLabelValues(caller, method, route).Inc()
+}
+
+func (c *Collector) ObserveCache(hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	c.cache.WithLabelValues(result).Inc()
+}
+
+func (c *Collector) ObserveUnlock(err error) {
+	result := "success"
+	switch {
+	case errors.Is(err, bitwarden.ErrWrongPassword):
+		result = "wrong_password"
+	case err != nil:
+		result = "error"
+	}
+	c.unlocks.WithLabelValues(result).Inc()
+}
+
+// errorType returns a label for err with a small, fixed set of values.
+func errorType(err error) string {
+	switch {
+	case errors.Is(err, bitwarden.ErrVaultLocked):
+		return "vault_locked"
+	case errors.Is(err, bitwarden.ErrNotLoggedIn):
+		return "not_logged_in"
+	case errors.Is(err, bitwarden.ErrNotFound):
+		return "not_found"
+	case errors.Is(err, bitwarden.ErrBadRequest):
+		return "bad_request"
+	case errors.Is(err, bitwarden.ErrUnexpectedStatusCode):
+		return "unexpected_status"
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return "canceled"
+	default:
+		return "transport"
+	}
+}
@@ -0,0 +1,68 @@
+package bwprometheus
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollector(t *testing.T) {
+	t.Run("Should count requests and errors by type", func(t *testing.T) {
+		c := New()
+
+		c.ObserveRequest(http.MethodGet, "/object/item/{id}", 10*time.Millisecond, nil)
+		c.ObserveRequest(http.MethodGet, "/object/item/{id}", 20*time.Millisecond, bitwarden.ErrNotFound)
+		c.ObserveRequest(http.MethodPost, "/sync", time.Second, errors.Join(bitwarden.ErrBadRequest, bitwarden.ErrVaultLocked))
+		c.ObserveRequest(http.MethodPost, "/sync", time.Second, context.DeadlineExceeded)
+
+		assert.Equal(t, 2.0, testutil.ToFloat64(c.requests.WithLabelValues(http.MethodGet, "/object/item/{id}")))
+		assert.Equal(t, 1.0, testutil.ToFloat64(c.errors.WithLabelValues(http.MethodGet, "/object/item/{id}", "not_found")))
+		assert.Equal(t, 1.0, testutil.ToFloat64(c.errors.WithLabelValues(http.MethodPost, "/sync", "vault_locked")))
+		assert.Equal(t, 1.0, testutil.ToFloat64(c.errors.WithLabelValues(http.MethodPost, "/sync", "canceled")))
+		assert.Equal(t, 2, testutil.CollectAndCount(c.duration))
+	})
+
+	t.Run("Should count requests by caller", func(t *testing.T) {
+		c := New()
+
+		c.ObserveCallerRequest("billing", http.MethodGet, "/object/item/{id}", nil)
+		c.ObserveCallerRequest("billing", http.MethodGet, "/object/item/{id}", bitwarden.ErrNotFound)
+		c.ObserveCallerRequest("reports", http.MethodPost, "/sync", nil)
+
+		assert.Equal(t, 2.0, testutil.ToFloat64(c.callers.WithLabelValues("billing", http.MethodGet, "/object/item/{id}")))
+		assert.Equal(t, 1.0, testutil.ToFloat64(c.callers.WithLabelValues("reports", http.MethodPost, "/sync")))
+	})
+
+	t.Run("Should count cache lookups and unlocks", func(t *testing.T) {
+		c := New()
+
+		c.ObserveCache(true)
+		c.ObserveCache(true)
+		c.ObserveCache(false)
+		c.ObserveUnlock(nil)
+		c.ObserveUnlock(bitwarden.ErrWrongPassword)
+
+		assert.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(`
+# HELP bitwarden_cache_lookups_total Lookups in the item cache by result, hit or miss.
+# TYPE bitwarden_cache_lookups_total counter
+bitwarden_cache_lookups_total{result="hit"} 2
+bitwarden_cache_lookups_total{result="miss"} 1
+# HELP bitwarden_unlocks_total Unlock attempts by result.
+# TYPE bitwarden_unlocks_total counter
+bitwarden_unlocks_total{result="success"} 1
+bitwarden_unlocks_total{result="wrong_password"} 1
+`), "bitwarden_cache_lookups_total", "bitwarden_unlocks_total"))
+	})
+
+	t.Run("Should register with Prometheus", func(t *testing.T) {
+		assert.NoError(t, prometheus.NewRegistry().Register(New()))
+	})
+}
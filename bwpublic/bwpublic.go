@@ -0,0 +1,95 @@
+// Package bwpublic is a client for the Bitwarden Organization Public API,
+// used to automate the administration of an organization. It authenticates
+// with the organization API key, found in the organization settings of the
+// web vault:
+//
+//	c := bwpublic.New("organization.<id>", "<secret>")
+//	members, err := c.ListMembers(ctx)
+//
+// Failed requests return a *bitwarden.APIError, like the vault client, so
+// bitwarden.ErrNotFound and friends can be checked with errors.Is for both.
+package bwpublic
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/floriaanpost/go-bitwarden-client/internal/cloudapi"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	defaultAPIURL      = "https://api.bitwarden.com"
+	defaultIdentityURL = "https://identity.bitwarden.com"
+	scope              = "api.organization"
+)
+
+type client interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type Client struct {
+	apiURL      string
+	identityURL string
+	httpClient  *http.Client
+	client      client
+}
+
+// Option configures optional behaviour of a Client.
+type Option func(*Client)
+
+// WithServer points the client to a self-hosted server, which serves the API
+// under /api and the identity service under /identity.
+func WithServer(url string) Option {
+	url = strings.TrimSuffix(url, "/")
+	return WithURLs(url+"/api", url+"/identity")
+}
+
+// WithURLs sets the API and identity URLs separately, for example for the
+// EU cloud (https://api.bitwarden.eu and https://identity.bitwarden.eu).
+func WithURLs(apiURL, identityURL string) Option {
+	return func(c *Client) {
+		c.apiURL = strings.TrimSuffix(apiURL, "/")
+		c.identityURL = strings.TrimSuffix(identityURL, "/")
+	}
+}
+
+// WithHTTPClient sets the client used for API and token requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// New returns a client that authenticates with the organization client ID
+// and secret. Tokens are requested when needed and reused until they expire.
+func New(clientID, clientSecret string, opts ...Option) *Client {
+	c := &Client{apiURL: defaultAPIURL, identityURL: defaultIdentityURL}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	cfg := clientcredentials.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		TokenURL:     c.identityURL + "/connect/token",
+		Scopes:       []string{scope},
+		AuthStyle:    oauth2.AuthStyleInParams,
+	}
+	ctx := context.Background()
+	if c.httpClient != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, c.httpClient)
+	}
+	c.client = cfg.Client(ctx)
+	return c
+}
+
+// list is the envelope of every list response.
+type list[T any] struct {
+	Data              []T     `json:"data"`
+	ContinuationToken *string `json:"continuationToken"`
+}
+
+func (c *Client) request(ctx context.Context, method string, endpoint string, req any, resp any) error {
+	return cloudapi.Request(ctx, c.client, c.apiURL, method, endpoint, req, resp)
+}
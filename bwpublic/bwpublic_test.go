@@ -0,0 +1,103 @@
+package bwpublic
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestClient returns a client for a server that issues tokens and answers
+// API requests with handler, which is called with the path below /api.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/identity/connect/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("grant_type") != "client_credentials" || r.FormValue("client_id") != "organization.test" ||
+			r.FormValue("client_secret") != "secret" || r.FormValue("scope") != scope {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"access_token":"t0k3n","token_type":"Bearer","expires_in":3600}`)
+	})
+	mux.Handle("/api/", http.StripPrefix("/api", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer t0k3n" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		handler(w, r)
+	})))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return New("organization.test", "secret", WithServer(server.URL))
+}
+
+// respond returns a handler that checks the request and writes body.
+func respond(t *testing.T, method, uri string, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, method, r.Method)
+		assert.Equal(t, uri, r.URL.RequestURI())
+		io.WriteString(w, body)
+	}
+}
+
+// decodeRequest returns a handler that decodes the request body into v and
+// writes body.
+func decodeRequest(t *testing.T, method, uri string, v any, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, method, r.Method)
+		assert.Equal(t, uri, r.URL.RequestURI())
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(v))
+		io.WriteString(w, body)
+	}
+}
+
+func TestRequest(t *testing.T) {
+	t.Run("Should map status codes to the vault client errors", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+
+		_, err := c.GetMember(context.Background(), "missing")
+
+		assert.ErrorIs(t, err, bitwarden.ErrNotFound)
+	})
+
+	t.Run("Should include the server message", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `{"object":"error","message":"The model state is invalid.","errors":{"Email":["The Email field is required."]}}`)
+		})
+
+		_, err := c.ListMembers(context.Background())
+
+		var apiErr *bitwarden.APIError
+		assert.ErrorAs(t, err, &apiErr)
+		assert.ErrorIs(t, err, bitwarden.ErrBadRequest)
+		assert.EqualError(t, err, "GET /public/members: bad request: The model state is invalid.; Email: The Email field is required.")
+	})
+
+	t.Run("Should return unexpected status codes", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+
+		_, err := c.ListMembers(context.Background())
+
+		assert.ErrorIs(t, err, bitwarden.ErrUnexpectedStatusCode)
+	})
+
+	t.Run("Should fail if the token cannot be obtained", func(t *testing.T) {
+		c := newTestClient(t, nil)
+		c = New("organization.test", "wrong", WithURLs(c.apiURL, c.identityURL))
+
+		_, err := c.ListMembers(context.Background())
+
+		assert.Error(t, err)
+	})
+}
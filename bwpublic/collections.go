@@ -0,0 +1,32 @@
+package bwpublic
+
+import (
+	"context"
+	"net/http"
+)
+
+type Collection struct {
+	ID         string  `json:"id"`
+	ExternalID *string `json:"externalId"`
+	// Groups is only set by GetCollection.
+	Groups []CollectionAccess `json:"groups"`
+}
+
+// ListCollections returns all collections of the organization.
+func (c *Client) ListCollections(ctx context.Context) ([]Collection, error) {
+	var resp list[Collection]
+	if err := c.request(ctx, http.MethodGet, "/public/collections", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// GetCollection returns a single collection, including the groups with
+// access to it.
+func (c *Client) GetCollection(ctx context.Context, id string) (*Collection, error) {
+	var col Collection
+	if err := c.request(ctx, http.MethodGet, "/public/collections/"+id, nil, &col); err != nil {
+		return nil, err
+	}
+	return &col, nil
+}
@@ -0,0 +1,34 @@
+package bwpublic
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListCollections(t *testing.T) {
+	t.Run("Should list the collections", func(t *testing.T) {
+		c := newTestClient(t, respond(t, http.MethodGet, "/public/collections",
+			`{"object":"list","data":[{"object":"collection","id":"c1","externalId":"infra"}],"continuationToken":null}`))
+
+		collections, err := c.ListCollections(context.Background())
+
+		assert.NoError(t, err)
+		assert.Len(t, collections, 1)
+		assert.Equal(t, "infra", *collections[0].ExternalID)
+	})
+}
+
+func TestGetCollection(t *testing.T) {
+	t.Run("Should return the collection with its groups", func(t *testing.T) {
+		c := newTestClient(t, respond(t, http.MethodGet, "/public/collections/c1",
+			`{"object":"collection","id":"c1","groups":[{"id":"g1","hidePasswords":true}]}`))
+
+		collection, err := c.GetCollection(context.Background(), "c1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []CollectionAccess{{ID: "g1", HidePasswords: true}}, collection.Groups)
+	})
+}
@@ -0,0 +1,90 @@
+package bwpublic
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// EventType identifies what happened, see the Bitwarden event log
+// documentation for the meaning of each code.
+type EventType int
+
+type Event struct {
+	Type         EventType `json:"type"`
+	ItemID       *string   `json:"itemId"`
+	CollectionID *string   `json:"collectionId"`
+	GroupID      *string   `json:"groupId"`
+	PolicyID     *string   `json:"policyId"`
+	MemberID     *string   `json:"memberId"`
+	ActingUserID *string   `json:"actingUserId"`
+	Date         time.Time `json:"date"`
+	Device       *int      `json:"device"`
+	IPAddress    *string   `json:"ipAddress"`
+}
+
+// EventIterator iterates over the event log, fetching pages as needed:
+//
+//	it := c.Events(ctx, start, end)
+//	for it.Next() {
+//		e := it.Event()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type EventIterator struct {
+	ctx   context.Context
+	c     *Client
+	query url.Values
+
+	page  []Event
+	event Event
+	done  bool
+	err   error
+}
+
+// Events returns an iterator over the events between start and end.
+func (c *Client) Events(ctx context.Context, start, end time.Time) *EventIterator {
+	query := url.Values{}
+	query.Set("start", start.UTC().Format(time.RFC3339))
+	query.Set("end", end.UTC().Format(time.RFC3339))
+	return &EventIterator{ctx: ctx, c: c, query: query}
+}
+
+// Next advances to the next event. It returns false when there are no more
+// events or a request failed, see Err.
+func (it *EventIterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		it.fetch()
+	}
+	it.event, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Event returns the current event.
+func (it *EventIterator) Event() Event {
+	return it.event
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *EventIterator) Err() error {
+	return it.err
+}
+
+func (it *EventIterator) fetch() {
+	var resp list[Event]
+	if it.err = it.c.request(it.ctx, http.MethodGet, "/public/events?"+it.query.Encode(), nil, &resp); it.err != nil {
+		return
+	}
+	it.page = resp.Data
+	if resp.ContinuationToken == nil || *resp.ContinuationToken == "" {
+		it.done = true
+		return
+	}
+	it.query.Set("continuationToken", *resp.ContinuationToken)
+}
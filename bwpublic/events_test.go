@@ -0,0 +1,57 @@
+package bwpublic
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvents(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Should follow continuation tokens", func(t *testing.T) {
+		var uris []string
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			uris = append(uris, r.URL.RequestURI())
+			switch r.URL.Query().Get("continuationToken") {
+			case "":
+				io.WriteString(w, `{"object":"list","data":[{"type":1000,"date":"2024-01-01T10:00:00Z"},{"type":1100,"itemId":"i1","date":"2024-01-01T11:00:00Z"}],"continuationToken":"next"}`)
+			case "next":
+				io.WriteString(w, `{"object":"list","data":[],"continuationToken":"last"}`)
+			case "last":
+				io.WriteString(w, `{"object":"list","data":[{"type":1101,"itemId":"i1","date":"2024-01-01T12:00:00Z"}],"continuationToken":null}`)
+			}
+		})
+
+		var types []EventType
+		it := c.Events(context.Background(), start, end)
+		for it.Next() {
+			types = append(types, it.Event().Type)
+		}
+
+		assert.NoError(t, it.Err())
+		assert.Equal(t, []EventType{1000, 1100, 1101}, types)
+		assert.Equal(t, []string{
+			"/public/events?end=2024-01-02T00%3A00%3A00Z&start=2024-01-01T00%3A00%3A00Z",
+			"/public/events?continuationToken=next&end=2024-01-02T00%3A00%3A00Z&start=2024-01-01T00%3A00%3A00Z",
+			"/public/events?continuationToken=last&end=2024-01-02T00%3A00%3A00Z&start=2024-01-01T00%3A00%3A00Z",
+		}, uris)
+	})
+
+	t.Run("Should stop on request errors", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		})
+
+		it := c.Events(context.Background(), start, end)
+
+		assert.False(t, it.Next())
+		assert.ErrorIs(t, it.Err(), bitwarden.ErrBadRequest)
+	})
+}
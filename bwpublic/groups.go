@@ -0,0 +1,96 @@
+package bwpublic
+
+import (
+	"context"
+	"net/http"
+)
+
+type Group struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	ExternalID  *string            `json:"externalId"`
+	Collections []CollectionAccess `json:"collections"`
+}
+
+// ListGroups returns all groups of the organization.
+func (c *Client) ListGroups(ctx context.Context) ([]Group, error) {
+	var resp list[Group]
+	if err := c.request(ctx, http.MethodGet, "/public/groups", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// GetGroup returns a single group, including its collection access.
+func (c *Client) GetGroup(ctx context.Context, id string) (*Group, error) {
+	var g Group
+	if err := c.request(ctx, http.MethodGet, "/public/groups/"+id, nil, &g); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+// GroupUpdate holds the settings of a group. On update the collections
+// replace the current ones.
+type GroupUpdate struct {
+	Name        string             `json:"name"`
+	ExternalID  *string            `json:"externalId,omitempty"`
+	Collections []CollectionAccess `json:"collections"`
+}
+
+// CreateGroup creates a new group.
+func (c *Client) CreateGroup(ctx context.Context, group GroupUpdate) (*Group, error) {
+	var g Group
+	if err := c.request(ctx, http.MethodPost, "/public/groups", group, &g); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+// UpdateGroup changes the name and collections of a group.
+func (c *Client) UpdateGroup(ctx context.Context, id string, update GroupUpdate) (*Group, error) {
+	var g Group
+	if err := c.request(ctx, http.MethodPut, "/public/groups/"+id, update, &g); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+// DeleteGroup deletes a group. Its members stay in the organization.
+func (c *Client) DeleteGroup(ctx context.Context, id string) error {
+	return c.request(ctx, http.MethodDelete, "/public/groups/"+id, nil, nil)
+}
+
+// GroupMembers returns the IDs of the members of a group.
+func (c *Client) GroupMembers(ctx context.Context, id string) ([]string, error) {
+	var ids []string
+	if err := c.request(ctx, http.MethodGet, "/public/groups/"+id+"/member-ids", nil, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// SetGroupMembers replaces the members of a group.
+func (c *Client) SetGroupMembers(ctx context.Context, id string, memberIDs []string) error {
+	req := struct {
+		MemberIDs []string `json:"memberIds"`
+	}{MemberIDs: memberIDs}
+	return c.request(ctx, http.MethodPut, "/public/groups/"+id+"/member-ids", req, nil)
+}
+
+// MemberGroups returns the IDs of the groups a member is in.
+func (c *Client) MemberGroups(ctx context.Context, id string) ([]string, error) {
+	var ids []string
+	if err := c.request(ctx, http.MethodGet, "/public/members/"+id+"/group-ids", nil, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// SetMemberGroups replaces the groups a member is in.
+func (c *Client) SetMemberGroups(ctx context.Context, id string, groupIDs []string) error {
+	req := struct {
+		GroupIDs []string `json:"groupIds"`
+	}{GroupIDs: groupIDs}
+	return c.request(ctx, http.MethodPut, "/public/members/"+id+"/group-ids", req, nil)
+}
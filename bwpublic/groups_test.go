@@ -0,0 +1,115 @@
+package bwpublic
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListGroups(t *testing.T) {
+	t.Run("Should list the groups", func(t *testing.T) {
+		c := newTestClient(t, respond(t, http.MethodGet, "/public/groups",
+			`{"object":"list","data":[{"object":"group","id":"g1","name":"Developers","collections":[]}],"continuationToken":null}`))
+
+		groups, err := c.ListGroups(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Group{{ID: "g1", Name: "Developers", Collections: []CollectionAccess{}}}, groups)
+	})
+}
+
+func TestGetGroup(t *testing.T) {
+	t.Run("Should return the group with its collections", func(t *testing.T) {
+		c := newTestClient(t, respond(t, http.MethodGet, "/public/groups/g1",
+			`{"object":"group","id":"g1","name":"Developers","collections":[{"id":"c1","manage":true}]}`))
+
+		group, err := c.GetGroup(context.Background(), "g1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []CollectionAccess{{ID: "c1", Manage: true}}, group.Collections)
+	})
+}
+
+func TestCreateGroup(t *testing.T) {
+	t.Run("Should create the group", func(t *testing.T) {
+		var body GroupUpdate
+		c := newTestClient(t, decodeRequest(t, http.MethodPost, "/public/groups", &body,
+			`{"object":"group","id":"g2","name":"Ops","collections":[{"id":"c1"}]}`))
+
+		create := GroupUpdate{Name: "Ops", Collections: []CollectionAccess{{ID: "c1"}}}
+		group, err := c.CreateGroup(context.Background(), create)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "g2", group.ID)
+		assert.Equal(t, create, body)
+	})
+}
+
+func TestUpdateGroup(t *testing.T) {
+	t.Run("Should replace the group settings", func(t *testing.T) {
+		var body GroupUpdate
+		c := newTestClient(t, decodeRequest(t, http.MethodPut, "/public/groups/g2", &body,
+			`{"object":"group","id":"g2","name":"Platform","collections":[]}`))
+
+		update := GroupUpdate{Name: "Platform", Collections: []CollectionAccess{}}
+		group, err := c.UpdateGroup(context.Background(), "g2", update)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Platform", group.Name)
+		assert.Equal(t, update, body)
+	})
+}
+
+func TestDeleteGroup(t *testing.T) {
+	t.Run("Should delete the group", func(t *testing.T) {
+		c := newTestClient(t, respond(t, http.MethodDelete, "/public/groups/g2", ``))
+
+		err := c.DeleteGroup(context.Background(), "g2")
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestGroupMembers(t *testing.T) {
+	t.Run("Should return the member IDs", func(t *testing.T) {
+		c := newTestClient(t, respond(t, http.MethodGet, "/public/groups/g1/member-ids", `["m1","m2"]`))
+
+		ids, err := c.GroupMembers(context.Background(), "g1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"m1", "m2"}, ids)
+	})
+
+	t.Run("Should replace the members", func(t *testing.T) {
+		var body map[string][]string
+		c := newTestClient(t, decodeRequest(t, http.MethodPut, "/public/groups/g1/member-ids", &body, ``))
+
+		err := c.SetGroupMembers(context.Background(), "g1", []string{"m3"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string][]string{"memberIds": {"m3"}}, body)
+	})
+}
+
+func TestMemberGroups(t *testing.T) {
+	t.Run("Should return the group IDs", func(t *testing.T) {
+		c := newTestClient(t, respond(t, http.MethodGet, "/public/members/m1/group-ids", `["g1"]`))
+
+		ids, err := c.MemberGroups(context.Background(), "m1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"g1"}, ids)
+	})
+
+	t.Run("Should replace the groups", func(t *testing.T) {
+		var body map[string][]string
+		c := newTestClient(t, decodeRequest(t, http.MethodPut, "/public/members/m1/group-ids", &body, ``))
+
+		err := c.SetMemberGroups(context.Background(), "m1", []string{"g1", "g2"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string][]string{"groupIds": {"g1", "g2"}}, body)
+	})
+}
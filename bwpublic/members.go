@@ -0,0 +1,110 @@
+package bwpublic
+
+import (
+	"context"
+	"net/http"
+)
+
+type MemberType int
+
+const (
+	MemberOwner   MemberType = 0
+	MemberAdmin   MemberType = 1
+	MemberUser    MemberType = 2
+	MemberManager MemberType = 3
+	MemberCustom  MemberType = 4
+)
+
+type MemberStatus int
+
+const (
+	MemberRevoked   MemberStatus = -1
+	MemberInvited   MemberStatus = 0
+	MemberAccepted  MemberStatus = 1
+	MemberConfirmed MemberStatus = 2
+)
+
+// CollectionAccess grants access to a collection.
+type CollectionAccess struct {
+	ID            string `json:"id"`
+	ReadOnly      bool   `json:"readOnly"`
+	HidePasswords bool   `json:"hidePasswords"`
+	Manage        bool   `json:"manage"`
+}
+
+type Member struct {
+	ID                    string             `json:"id"`
+	UserID                *string            `json:"userId"`
+	Name                  *string            `json:"name"`
+	Email                 string             `json:"email"`
+	TwoFactorEnabled      bool               `json:"twoFactorEnabled"`
+	Status                MemberStatus       `json:"status"`
+	Type                  MemberType         `json:"type"`
+	ExternalID            *string            `json:"externalId"`
+	ResetPasswordEnrolled bool               `json:"resetPasswordEnrolled"`
+	Collections           []CollectionAccess `json:"collections"`
+}
+
+// ListMembers returns all members of the organization.
+func (c *Client) ListMembers(ctx context.Context) ([]Member, error) {
+	var resp list[Member]
+	if err := c.request(ctx, http.MethodGet, "/public/members", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// GetMember returns a single member, including its collection access.
+func (c *Client) GetMember(ctx context.Context, id string) (*Member, error) {
+	var m Member
+	if err := c.request(ctx, http.MethodGet, "/public/members/"+id, nil, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// MemberUpdate holds the settings of a member. On update the collections and
+// groups replace the current ones.
+type MemberUpdate struct {
+	Type                  MemberType         `json:"type"`
+	ExternalID            *string            `json:"externalId,omitempty"`
+	ResetPasswordEnrolled bool               `json:"resetPasswordEnrolled"`
+	Collections           []CollectionAccess `json:"collections"`
+	Groups                []string           `json:"groups"`
+}
+
+// MemberInvite is a member to invite by email.
+type MemberInvite struct {
+	Email string `json:"email"`
+	MemberUpdate
+}
+
+// InviteMember invites a new member, who receives an invitation email.
+func (c *Client) InviteMember(ctx context.Context, invite MemberInvite) (*Member, error) {
+	var m Member
+	if err := c.request(ctx, http.MethodPost, "/public/members", invite, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// ReinviteMember sends the invitation email again to a member that has not
+// accepted it yet.
+func (c *Client) ReinviteMember(ctx context.Context, id string) error {
+	return c.request(ctx, http.MethodPost, "/public/members/"+id+"/reinvite", nil, nil)
+}
+
+// UpdateMember changes the type, collections and groups of a member.
+func (c *Client) UpdateMember(ctx context.Context, id string, update MemberUpdate) (*Member, error) {
+	var m Member
+	if err := c.request(ctx, http.MethodPut, "/public/members/"+id, update, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// RemoveMember removes a member from the organization. The user account
+// itself is not deleted.
+func (c *Client) RemoveMember(ctx context.Context, id string) error {
+	return c.request(ctx, http.MethodDelete, "/public/members/"+id, nil, nil)
+}
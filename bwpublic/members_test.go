@@ -0,0 +1,96 @@
+package bwpublic
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListMembers(t *testing.T) {
+	t.Run("Should list the members", func(t *testing.T) {
+		c := newTestClient(t, respond(t, http.MethodGet, "/public/members",
+			`{"object":"list","data":[{"object":"member","id":"m1","email":"alice@example.com","status":2,"type":1,"twoFactorEnabled":true}],"continuationToken":null}`))
+
+		members, err := c.ListMembers(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Member{{ID: "m1", Email: "alice@example.com", Status: MemberConfirmed, Type: MemberAdmin, TwoFactorEnabled: true}}, members)
+	})
+}
+
+func TestGetMember(t *testing.T) {
+	t.Run("Should return the member with its collections", func(t *testing.T) {
+		c := newTestClient(t, respond(t, http.MethodGet, "/public/members/m1",
+			`{"object":"member","id":"m1","email":"alice@example.com","status":0,"type":2,"collections":[{"id":"c1","readOnly":true}]}`))
+
+		member, err := c.GetMember(context.Background(), "m1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, MemberInvited, member.Status)
+		assert.Equal(t, []CollectionAccess{{ID: "c1", ReadOnly: true}}, member.Collections)
+	})
+}
+
+func TestInviteMember(t *testing.T) {
+	t.Run("Should send the invite", func(t *testing.T) {
+		var body map[string]any
+		c := newTestClient(t, decodeRequest(t, http.MethodPost, "/public/members", &body,
+			`{"object":"member","id":"m2","email":"bob@example.com","status":0,"type":2}`))
+
+		member, err := c.InviteMember(context.Background(), MemberInvite{
+			Email: "bob@example.com",
+			MemberUpdate: MemberUpdate{
+				Type:        MemberUser,
+				Collections: []CollectionAccess{{ID: "c1", ReadOnly: true}},
+				Groups:      []string{"g1"},
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "m2", member.ID)
+		assert.Equal(t, map[string]any{
+			"email":                 "bob@example.com",
+			"type":                  float64(2),
+			"resetPasswordEnrolled": false,
+			"collections":           []any{map[string]any{"id": "c1", "readOnly": true, "hidePasswords": false, "manage": false}},
+			"groups":                []any{"g1"},
+		}, body)
+	})
+}
+
+func TestReinviteMember(t *testing.T) {
+	t.Run("Should request a new invitation", func(t *testing.T) {
+		c := newTestClient(t, respond(t, http.MethodPost, "/public/members/m2/reinvite", ``))
+
+		err := c.ReinviteMember(context.Background(), "m2")
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestUpdateMember(t *testing.T) {
+	t.Run("Should replace the member settings", func(t *testing.T) {
+		var body MemberUpdate
+		c := newTestClient(t, decodeRequest(t, http.MethodPut, "/public/members/m2", &body,
+			`{"object":"member","id":"m2","email":"bob@example.com","status":2,"type":3}`))
+
+		update := MemberUpdate{Type: MemberManager, Groups: []string{"g1", "g2"}}
+		member, err := c.UpdateMember(context.Background(), "m2", update)
+
+		assert.NoError(t, err)
+		assert.Equal(t, MemberManager, member.Type)
+		assert.Equal(t, update, body)
+	})
+}
+
+func TestRemoveMember(t *testing.T) {
+	t.Run("Should remove the member", func(t *testing.T) {
+		c := newTestClient(t, respond(t, http.MethodDelete, "/public/members/m2", ``))
+
+		err := c.RemoveMember(context.Background(), "m2")
+
+		assert.NoError(t, err)
+	})
+}
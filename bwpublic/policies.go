@@ -0,0 +1,149 @@
+package bwpublic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+type PolicyType int
+
+const (
+	PolicyTwoFactorAuthentication    PolicyType = 0
+	PolicyMasterPassword             PolicyType = 1
+	PolicyPasswordGenerator          PolicyType = 2
+	PolicySingleOrg                  PolicyType = 3
+	PolicyRequireSSO                 PolicyType = 4
+	PolicyPersonalOwnership          PolicyType = 5
+	PolicyDisableSend                PolicyType = 6
+	PolicySendOptions                PolicyType = 7
+	PolicyResetPassword              PolicyType = 8
+	PolicyMaximumVaultTimeout        PolicyType = 9
+	PolicyDisablePersonalVaultExport PolicyType = 10
+	PolicyActivateAutofill           PolicyType = 11
+)
+
+func (t PolicyType) String() string {
+	switch t {
+	case PolicyTwoFactorAuthentication:
+		return "two-factor authentication"
+	case PolicyMasterPassword:
+		return "master password requirements"
+	case PolicyPasswordGenerator:
+		return "password generator"
+	case PolicySingleOrg:
+		return "single organization"
+	case PolicyRequireSSO:
+		return "require single sign-on"
+	case PolicyPersonalOwnership:
+		return "remove individual vault"
+	case PolicyDisableSend:
+		return "remove send"
+	case PolicySendOptions:
+		return "send options"
+	case PolicyResetPassword:
+		return "account recovery"
+	case PolicyMaximumVaultTimeout:
+		return "vault timeout"
+	case PolicyDisablePersonalVaultExport:
+		return "remove individual vault export"
+	case PolicyActivateAutofill:
+		return "activate autofill"
+	}
+	return "unknown"
+}
+
+type Policy struct {
+	ID      string          `json:"id"`
+	Type    PolicyType      `json:"type"`
+	Enabled bool            `json:"enabled"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// DecodeData decodes the settings of the policy into v, which should be the
+// data struct for its type, such as *MasterPasswordPolicy. Policies without
+// settings leave v unchanged.
+func (p *Policy) DecodeData(v any) error {
+	if len(p.Data) == 0 || string(p.Data) == "null" {
+		return nil
+	}
+	return json.Unmarshal(p.Data, v)
+}
+
+// MasterPasswordPolicy is the data of PolicyMasterPassword.
+type MasterPasswordPolicy struct {
+	MinComplexity  *int `json:"minComplexity"`
+	MinLength      *int `json:"minLength"`
+	RequireUpper   bool `json:"requireUpper"`
+	RequireLower   bool `json:"requireLower"`
+	RequireNumbers bool `json:"requireNumbers"`
+	RequireSpecial bool `json:"requireSpecial"`
+	EnforceOnLogin bool `json:"enforceOnLogin"`
+}
+
+// PasswordGeneratorPolicy is the data of PolicyPasswordGenerator.
+type PasswordGeneratorPolicy struct {
+	DefaultType    *string `json:"defaultType"`
+	MinLength      *int    `json:"minLength"`
+	UseUpper       bool    `json:"useUpper"`
+	UseLower       bool    `json:"useLower"`
+	UseNumbers     bool    `json:"useNumbers"`
+	UseSpecial     bool    `json:"useSpecial"`
+	MinNumbers     *int    `json:"minNumbers"`
+	MinSpecial     *int    `json:"minSpecial"`
+	MinNumberWords *int    `json:"minNumberWords"`
+	Capitalize     bool    `json:"capitalize"`
+	IncludeNumber  bool    `json:"includeNumber"`
+}
+
+// SendOptionsPolicy is the data of PolicySendOptions.
+type SendOptionsPolicy struct {
+	DisableHideEmail bool `json:"disableHideEmail"`
+}
+
+// ResetPasswordPolicy is the data of PolicyResetPassword.
+type ResetPasswordPolicy struct {
+	AutoEnrollEnabled bool `json:"autoEnrollEnabled"`
+}
+
+// MaximumVaultTimeoutPolicy is the data of PolicyMaximumVaultTimeout.
+type MaximumVaultTimeoutPolicy struct {
+	Minutes int `json:"minutes"`
+	// Action is "lock" or "logOut", or nil to let users choose.
+	Action *string `json:"action"`
+}
+
+// ListPolicies returns all policies of the organization.
+func (c *Client) ListPolicies(ctx context.Context) ([]Policy, error) {
+	var resp list[Policy]
+	if err := c.request(ctx, http.MethodGet, "/public/policies", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// GetPolicy returns the policy of the given type.
+func (c *Client) GetPolicy(ctx context.Context, t PolicyType) (*Policy, error) {
+	var p Policy
+	if err := c.request(ctx, http.MethodGet, "/public/policies/"+strconv.Itoa(int(t)), nil, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// UpdatePolicy enables or disables the policy of the given type and replaces
+// its settings with data, which should be the data struct for the type or nil
+// for policies without settings.
+func (c *Client) UpdatePolicy(ctx context.Context, t PolicyType, enabled bool, data any) (*Policy, error) {
+	req := struct {
+		Enabled bool `json:"enabled"`
+		Data    any  `json:"data"`
+	}{Enabled: enabled, Data: data}
+
+	var p Policy
+	if err := c.request(ctx, http.MethodPut, "/public/policies/"+strconv.Itoa(int(t)), req, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
@@ -0,0 +1,65 @@
+package bwpublic
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListPolicies(t *testing.T) {
+	t.Run("Should list the policies", func(t *testing.T) {
+		c := newTestClient(t, respond(t, http.MethodGet, "/public/policies",
+			`{"object":"list","data":[{"object":"policy","id":"p1","type":0,"enabled":true,"data":null}],"continuationToken":null}`))
+
+		policies, err := c.ListPolicies(context.Background())
+
+		assert.NoError(t, err)
+		assert.Len(t, policies, 1)
+		assert.True(t, policies[0].Enabled)
+	})
+}
+
+func TestGetPolicy(t *testing.T) {
+	t.Run("Should get the policy by type", func(t *testing.T) {
+		c := newTestClient(t, respond(t, http.MethodGet, "/public/policies/1",
+			`{"object":"policy","id":"p1","type":1,"enabled":false,"data":{"minLength":12}}`))
+
+		policy, err := c.GetPolicy(context.Background(), PolicyMasterPassword)
+		assert.NoError(t, err)
+		var data MasterPasswordPolicy
+		err = policy.DecodeData(&data)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 12, *data.MinLength)
+		assert.Equal(t, "master password requirements", policy.Type.String())
+	})
+
+	t.Run("Should leave the data alone for policies without settings", func(t *testing.T) {
+		c := newTestClient(t, respond(t, http.MethodGet, "/public/policies/3",
+			`{"object":"policy","id":"p3","type":3,"enabled":true,"data":null}`))
+
+		policy, err := c.GetPolicy(context.Background(), PolicySingleOrg)
+		assert.NoError(t, err)
+		data := struct{}{}
+		err = policy.DecodeData(&data)
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestUpdatePolicy(t *testing.T) {
+	t.Run("Should send the enabled flag and settings", func(t *testing.T) {
+		var body map[string]any
+		c := newTestClient(t, decodeRequest(t, http.MethodPut, "/public/policies/9", &body,
+			`{"object":"policy","id":"p9","type":9,"enabled":true,"data":{"minutes":15,"action":"lock"}}`))
+
+		action := "lock"
+		policy, err := c.UpdatePolicy(context.Background(), PolicyMaximumVaultTimeout, true, MaximumVaultTimeoutPolicy{Minutes: 15, Action: &action})
+
+		assert.NoError(t, err)
+		assert.True(t, policy.Enabled)
+		assert.Equal(t, map[string]any{"enabled": true, "data": map[string]any{"minutes": float64(15), "action": "lock"}}, body)
+	})
+}
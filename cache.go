@@ -0,0 +1,419 @@
+package bitwarden
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	defaultCacheMaxAge = 5 * time.Minute
+
+	cacheSaltSize = 16
+	cacheKeySize  = 32
+)
+
+var errCacheCorrupt = errors.New("cache file is corrupt or the secret is wrong")
+
+type cacheEntry struct {
+	Item      *Item     `json:"item"`
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+// itemCache keeps recently fetched items in memory and, when a path is set,
+// mirrors them to an encrypted file so they survive restarts. Entries are
+// deep copies, so callers can modify what they put in or get out.
+type itemCache struct {
+	mu      sync.Mutex
+	maxAge  time.Duration
+	entries map[string]cacheEntry
+	gen     uint64 // incremented on every change that must be saved
+	clock   Clock
+	logger  *slog.Logger
+
+	path string
+
+	// saveMu serializes file writes, which run outside mu because of the
+	// encryption. saved is the generation last written, so a slow writer
+	// cannot overwrite a newer file with an older snapshot.
+	saveMu sync.Mutex
+	saved  uint64
+	secret []byte
+	salt   []byte
+	key    []byte
+}
+
+// cacheSnapshot is the state of the cache to write to the file.
+type cacheSnapshot struct {
+	gen     uint64
+	entries map[string]cacheEntry
+}
+
+// WithCache enables an in-memory item cache. Items younger than maxAge are
+// returned without contacting the server, which also keeps them available
+// while the server is unreachable. Sync refreshes the cached items from the
+// vault, since that is when changes made by other clients arrive: changed
+// items are replaced and items that are gone are dropped.
+func WithCache(maxAge time.Duration) Option {
+	return func(b *BitwardenServer) {
+		b.ensureCache().maxAge = maxAge
+	}
+}
+
+// WithCacheFile persists the item cache to path, encrypted with a key
+// derived from secret. A cache file that cannot be read or decrypted is
+// ignored and overwritten, with a warning to the WithLogger logger. Enables
+// the cache with a default max age of five minutes if WithCache is not
+// given.
+func WithCacheFile(path string, secret []byte) Option {
+	return func(b *BitwardenServer) {
+		c := b.ensureCache()
+		c.path = path
+		c.secret = secret
+	}
+}
+
+func (b *BitwardenServer) ensureCache() *itemCache {
+	if b.cache == nil {
+		b.cache = &itemCache{maxAge: defaultCacheMaxAge, entries: map[string]cacheEntry{}}
+	}
+	return b.cache
+}
+
+// PurgeCache removes all cached items from memory and deletes the cache file.
+func (b *BitwardenServer) PurgeCache() error {
+	return b.cache.purge()
+}
+
+func (c *itemCache) get(id string) (*Item, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[id]
+	if !ok {
+		return nil, false
+	}
+	if c.clock.Now().Sub(e.FetchedAt) > c.maxAge {
+		delete(c.entries, id)
+		return nil, false
+	}
+	item, err := copyItem(e.Item)
+	if err != nil {
+		return nil, false
+	}
+	return item, true
+}
+
+func (c *itemCache) put(id string, item *Item) {
+	if c == nil {
+		return
+	}
+	cp, err := copyItem(item)
+	if err != nil {
+		c.warn("bitwarden cache skipped item", "item", id, "error", err)
+		return
+	}
+
+	c.mu.Lock()
+	c.entries[id] = cacheEntry{Item: cp, FetchedAt: c.clock.Now()}
+	snap := c.snapshot()
+	c.mu.Unlock()
+	c.save(snap)
+}
+
+func (c *itemCache) putAll(items []Item) {
+	if c == nil {
+		return
+	}
+	copies := make([]*Item, 0, len(items))
+	for i := range items {
+		cp, err := copyItem(&items[i])
+		if err != nil {
+			c.warn("bitwarden cache skipped item", "item", items[i].ID, "error", err)
+			continue
+		}
+		copies = append(copies, cp)
+	}
+
+	c.mu.Lock()
+	now := c.clock.Now()
+	for _, cp := range copies {
+		c.entries[cp.ID] = cacheEntry{Item: cp, FetchedAt: now}
+	}
+	snap := c.snapshot()
+	c.mu.Unlock()
+	c.save(snap)
+}
+
+// refresh replaces the cached items with their state in items, the whole
+// vault as listed after a sync, and drops the ones that are not listed.
+func (c *itemCache) refresh(items []Item) {
+	if c == nil {
+		return
+	}
+	listed := make(map[string]*Item, len(items))
+	for i := range items {
+		listed[items[i].ID] = &items[i]
+	}
+
+	c.mu.Lock()
+	now := c.clock.Now()
+	for id := range c.entries {
+		item, ok := listed[id]
+		if !ok {
+			delete(c.entries, id)
+			continue
+		}
+		cp, err := copyItem(item)
+		if err != nil {
+			delete(c.entries, id)
+			continue
+		}
+		c.entries[id] = cacheEntry{Item: cp, FetchedAt: now}
+	}
+	snap := c.snapshot()
+	c.mu.Unlock()
+	c.save(snap)
+}
+
+// empty reports whether nothing is cached.
+func (c *itemCache) empty() bool {
+	if c == nil {
+		return true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries) == 0
+}
+
+func (c *itemCache) remove(id string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	delete(c.entries, id)
+	snap := c.snapshot()
+	c.mu.Unlock()
+	c.save(snap)
+}
+
+func (c *itemCache) purge() error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	c.entries = map[string]cacheEntry{}
+	c.gen++
+	gen := c.gen
+	c.mu.Unlock()
+
+	if c.path == "" {
+		return nil
+	}
+	c.saveMu.Lock()
+	defer c.saveMu.Unlock()
+	c.saved = max(c.saved, gen) // drop snapshots taken before the purge
+	if err := os.Remove(c.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// copyItem returns a deep copy of item. It goes through JSON, so no nested
+// slice or pointer is shared with the original.
+func copyItem(item *Item) (*Item, error) {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	var cp Item
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, err
+	}
+	return &cp, nil
+}
+
+// warn logs a cache problem. The cache is best effort, so problems only cost
+// a refetch, but a cache that silently never works is hard to diagnose.
+func (c *itemCache) warn(msg string, args ...any) {
+	if c.logger != nil {
+		c.logger.Warn(msg, args...)
+	}
+}
+
+// load reads the cache file, dropping entries older than maxAge.
+func (c *itemCache) load() {
+	if c.path == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.saveMu.Lock()
+	defer c.saveMu.Unlock()
+
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return
+	}
+	if err != nil {
+		c.warn("bitwarden cache file not loaded", "path", c.path, "error", err)
+		return
+	}
+	entries, err := c.decrypt(data)
+	if err != nil {
+		c.warn("bitwarden cache file not loaded", "path", c.path, "error", err)
+		return
+	}
+	for id, e := range entries {
+		if e.Item != nil && c.clock.Now().Sub(e.FetchedAt) <= c.maxAge {
+			c.entries[id] = e
+		}
+	}
+}
+
+// snapshot captures the entries to save. The caller must hold c.mu. It
+// returns nil when the cache has no file.
+func (c *itemCache) snapshot() *cacheSnapshot {
+	if c.path == "" {
+		return nil
+	}
+	c.gen++
+	return &cacheSnapshot{gen: c.gen, entries: maps.Clone(c.entries)}
+}
+
+// save writes snap to the cache file, unless a newer snapshot has already
+// been written. The caller must not hold c.mu.
+func (c *itemCache) save(snap *cacheSnapshot) {
+	if snap == nil {
+		return
+	}
+	c.saveMu.Lock()
+	defer c.saveMu.Unlock()
+
+	if snap.gen <= c.saved {
+		return
+	}
+	if err := c.write(snap.entries); err != nil {
+		c.warn("bitwarden cache file not saved", "path", c.path, "error", err)
+		return
+	}
+	c.saved = snap.gen
+}
+
+// write writes the cache file atomically. The caller must hold c.saveMu.
+func (c *itemCache) write(entries map[string]cacheEntry) error {
+	data, err := c.encrypt(entries)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), ".bwcache-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path)
+}
+
+// deriveKey derives the encryption key for salt, reusing it while the salt
+// stays the same because scrypt is deliberately slow.
+func (c *itemCache) deriveKey(salt []byte) ([]byte, error) {
+	if c.key != nil && string(c.salt) == string(salt) {
+		return c.key, nil
+	}
+	key, err := scrypt.Key(c.secret, salt, 1<<15, 8, 1, cacheKeySize)
+	if err != nil {
+		return nil, err
+	}
+	c.salt, c.key = salt, key
+	return key, nil
+}
+
+func (c *itemCache) gcm(salt []byte) (cipher.AEAD, error) {
+	key, err := c.deriveKey(salt)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encrypt returns salt | nonce | ciphertext.
+func (c *itemCache) encrypt(entries map[string]cacheEntry) ([]byte, error) {
+	plain, err := json.Marshal(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := c.salt
+	if salt == nil {
+		salt = make([]byte, cacheSaltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, err
+		}
+	}
+	aead, err := c.gcm(salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	out := append([]byte{}, salt...)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, plain, nil), nil
+}
+
+func (c *itemCache) decrypt(data []byte) (map[string]cacheEntry, error) {
+	if len(data) < cacheSaltSize {
+		return nil, errCacheCorrupt
+	}
+	salt, data := data[:cacheSaltSize], data[cacheSaltSize:]
+	aead, err := c.gcm(salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, errCacheCorrupt
+	}
+	nonce, data := data[:aead.NonceSize()], data[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, data, nil)
+	if err != nil {
+		return nil, errCacheCorrupt
+	}
+
+	entries := map[string]cacheEntry{}
+	if err := json.Unmarshal(plain, &entries); err != nil {
+		return nil, errCacheCorrupt
+	}
+	return entries, nil
+}
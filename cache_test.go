@@ -0,0 +1,274 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func itemResponse(itemID string) *http.Response {
+	respData := []byte(`{"data":{"revisionDate":"2023-05-06T07:08:09.0001Z","creationDate":"2023-01-01T01:02:03.0004Z","deletedDate":null,"object":"item","id":"` + itemID + `","type":2,"reprompt":0,"name":"ENV","notes":"This is a secure note!","favorite":false,"secureNote":{"type":0}}}`)
+	return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBuffer(respData))}
+}
+
+func TestCache(t *testing.T) {
+	itemID := "382a9d7b-f6b5-4eaa-92a1-1f3c7d89e48f"
+
+	t.Run("Should serve repeated lookups from the cache", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithCache(time.Minute))
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))).
+			Return(itemResponse(itemID), nil).
+			Once()
+
+		_, err := bw.GetItem(context.Background(), itemID)
+		assert.NoError(t, err)
+		note, err := bw.GetSecureNote(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "This is a secure note!", note)
+	})
+
+	t.Run("Should refetch expired items", func(t *testing.T) {
+		clock := newFakeClock()
+		bw, client := newTestBitwarden(WithCache(time.Minute), WithClock(clock))
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))).
+			Return(func(*http.Request) (*http.Response, error) { return itemResponse(itemID), nil }).
+			Twice()
+
+		_, err := bw.GetItem(context.Background(), itemID)
+		assert.NoError(t, err)
+		clock.Advance(time.Minute + time.Second)
+		_, err = bw.GetItem(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should not cache errors", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithCache(time.Minute))
+
+		testErr := errors.New("test error")
+		client.
+			On("Do", mock.Anything).
+			Return(nil, testErr).
+			Twice()
+
+		_, err := bw.GetItem(context.Background(), itemID)
+		assert.ErrorIs(t, err, testErr)
+		_, err = bw.GetItem(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, testErr)
+	})
+
+	t.Run("Should purge cached items", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithCache(time.Minute))
+
+		client.
+			On("Do", mock.Anything).
+			Return(func(*http.Request) (*http.Response, error) { return itemResponse(itemID), nil }).
+			Twice()
+
+		_, err := bw.GetItem(context.Background(), itemID)
+		assert.NoError(t, err)
+		assert.NoError(t, bw.PurgeCache())
+		_, err = bw.GetItem(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should refresh cached items on a sync", func(t *testing.T) {
+		otherID := "5b0c4a1e-2f3d-4c6b-9a8e-7d1f0e2c3b4a"
+		bw, client := newTestBitwarden(WithCache(time.Minute))
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))).
+			Return(itemResponse(itemID), nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+otherID, ``))).
+			Return(func(*http.Request) (*http.Response, error) { return itemResponse(otherID), nil }).
+			Twice()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodPost, "http://localhost/sync", `{}`))).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"success":true}`))}, nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items", ``))).
+			Return(listResponse(`{"id":"`+itemID+`","type":2,"name":"ENV","notes":"Changed elsewhere","secureNote":{"type":0}}`), nil).
+			Once()
+
+		_, err := bw.GetItem(context.Background(), itemID)
+		assert.NoError(t, err)
+		_, err = bw.GetItem(context.Background(), otherID)
+		assert.NoError(t, err)
+		assert.NoError(t, bw.Sync(context.Background()))
+		note, err := bw.GetSecureNote(context.Background(), itemID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Changed elsewhere", note)
+		_, err = bw.GetItem(context.Background(), otherID) // no longer listed
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should not share nested values with callers", func(t *testing.T) {
+		c := &itemCache{maxAge: time.Minute, entries: map[string]cacheEntry{}, clock: newFakeClock()}
+
+		item := &Item{ID: itemID, Fields: []Field{{Name: "token", Value: "original"}}}
+		c.put(itemID, item)
+		item.Fields[0].Value = "changed by the caller"
+		got, ok := c.get(itemID)
+		assert.True(t, ok)
+		got.Fields[0].Value = "changed by the reader"
+		got, ok = c.get(itemID)
+
+		assert.True(t, ok)
+		assert.Equal(t, "original", got.Fields[0].Value)
+	})
+}
+
+func TestCacheFile(t *testing.T) {
+	itemID := "382a9d7b-f6b5-4eaa-92a1-1f3c7d89e48f"
+	secret := []byte("cache secret")
+
+	t.Run("Should warm start from an encrypted cache file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "cache")
+		bw, client := newTestBitwarden(WithCacheFile(path, secret))
+
+		client.
+			On("Do", mock.Anything).
+			Return(itemResponse(itemID), nil).
+			Once()
+
+		_, err := bw.GetItem(context.Background(), itemID)
+		assert.NoError(t, err)
+		client.AssertExpectations(t)
+
+		data, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(data), "This is a secure note!")
+		info, err := os.Stat(path)
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+		restarted, client := newTestBitwarden(WithCacheFile(path, secret))
+		note, err := restarted.GetSecureNote(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "This is a secure note!", note)
+	})
+
+	t.Run("Should keep the cache file on a sync", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "cache")
+		bw, client := newTestBitwarden(WithCacheFile(path, secret))
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))).
+			Return(itemResponse(itemID), nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodPost, "http://localhost/sync", `{}`))).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"success":true}`))}, nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items", ``))).
+			Return(listResponse(`{"id":"`+itemID+`","type":2,"name":"ENV","notes":"This is a secure note!","secureNote":{"type":0}}`), nil).
+			Once()
+
+		_, err := bw.GetItem(context.Background(), itemID)
+		assert.NoError(t, err)
+		assert.NoError(t, bw.Sync(context.Background()))
+		client.AssertExpectations(t)
+
+		restarted, client := newTestBitwarden(WithCacheFile(path, secret))
+		note, err := restarted.GetSecureNote(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "This is a secure note!", note)
+	})
+
+	t.Run("Should ignore a cache file encrypted with another secret", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "cache")
+		bw, client := newTestBitwarden(WithCacheFile(path, secret))
+
+		client.
+			On("Do", mock.Anything).
+			Return(itemResponse(itemID), nil).
+			Once()
+		_, err := bw.GetItem(context.Background(), itemID)
+		assert.NoError(t, err)
+
+		other, client := newTestBitwarden(WithCacheFile(path, []byte("other secret")))
+		client.
+			On("Do", mock.Anything).
+			Return(itemResponse(itemID), nil).
+			Once()
+		_, err = other.GetItem(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should log why a cache file was not loaded", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "cache")
+		assert.NoError(t, os.WriteFile(path, []byte("garbage"), 0o600))
+
+		var logs bytes.Buffer
+		newTestBitwarden(WithCacheFile(path, secret), WithLogger(slog.New(slog.NewJSONHandler(&logs, nil))))
+
+		assert.Contains(t, logs.String(), "bitwarden cache file not loaded")
+		assert.Contains(t, logs.String(), errCacheCorrupt.Error())
+	})
+
+	t.Run("Should drop entries older than the max age on load", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "cache")
+		clock := newFakeClock()
+		bw, client := newTestBitwarden(WithCacheFile(path, secret), WithClock(clock))
+
+		client.
+			On("Do", mock.Anything).
+			Return(itemResponse(itemID), nil).
+			Once()
+		_, err := bw.GetItem(context.Background(), itemID)
+		assert.NoError(t, err)
+
+		clock.Advance(time.Minute + time.Second)
+		restarted, _ := newTestBitwarden(WithCacheFile(path, secret), WithCache(time.Minute), WithClock(clock))
+		assert.Empty(t, restarted.cache.entries)
+	})
+
+	t.Run("Should delete the cache file on purge", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "cache")
+		bw, client := newTestBitwarden(WithCacheFile(path, secret))
+
+		client.
+			On("Do", mock.Anything).
+			Return(itemResponse(itemID), nil).
+			Once()
+		_, err := bw.GetItem(context.Background(), itemID)
+		assert.NoError(t, err)
+
+		assert.NoError(t, bw.PurgeCache())
+		_, err = os.Stat(path)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
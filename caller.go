@@ -0,0 +1,25 @@
+package bitwarden
+
+import "context"
+
+type callerKey struct{}
+
+// ContextWithCaller labels the accesses made with ctx with the name of the
+// job or service making them, so they can be attributed without global
+// state. The label is part of audit entries, log records and spans, is
+// counted by Metrics that implement CallerMetrics, and can be read by
+// Middleware with CallerFromContext on the request context.
+//
+// Metrics turn every caller into its own series, so use a small, fixed set
+// of names; per-request values such as request IDs belong in spans or log
+// attributes instead.
+func ContextWithCaller(ctx context.Context, caller string) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// CallerFromContext returns the label set with ContextWithCaller, or an
+// empty string.
+func CallerFromContext(ctx context.Context) string {
+	caller, _ := ctx.Value(callerKey{}).(string)
+	return caller
+}
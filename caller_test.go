@@ -0,0 +1,90 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// callerMetrics also records the callers of requests.
+type callerMetrics struct {
+	fakeMetrics
+	callers []string
+}
+
+func (m *callerMetrics) ObserveCallerRequest(caller, method, route string, _ error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.callers = append(m.callers, caller+" "+method+" "+route)
+}
+
+func TestContextWithCaller(t *testing.T) {
+	itemID := "382a9d7b-f6b5-4eaa-92a1-1f3c7d89e48f"
+
+	t.Run("Should label logs, spans, metrics and middleware with the caller", func(t *testing.T) {
+		var logs bytes.Buffer
+		recorder := tracetest.NewSpanRecorder()
+		metrics := &callerMetrics{}
+		var seen string
+		bw, client := newTestBitwarden(
+			WithLogger(slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+			WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))),
+			WithMetrics(metrics),
+			WithMiddleware(func(next RequestFunc) RequestFunc {
+				return func(req *http.Request) (*http.Response, error) {
+					seen = CallerFromContext(req.Context())
+					return next(req)
+				}
+			}),
+		)
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))).
+			Return(itemResponse(itemID), nil).
+			Once()
+
+		_, err := bw.GetItem(ContextWithCaller(context.Background(), "nightly-report"), itemID)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "nightly-report", seen)
+		var record map[string]any
+		assert.NoError(t, json.Unmarshal(logs.Bytes(), &record))
+		assert.Equal(t, "nightly-report", record["caller"])
+		if spans := recorder.Ended(); assert.Len(t, spans, 1) {
+			assert.Contains(t, spans[0].Attributes(), attribute.String("bitwarden.caller", "nightly-report"))
+		}
+		assert.Equal(t, []string{"nightly-report GET /object/item/{id}"}, metrics.callers)
+		assert.Len(t, metrics.requests, 1)
+	})
+
+	t.Run("Should leave requests without a caller unlabeled", func(t *testing.T) {
+		var logs bytes.Buffer
+		metrics := &callerMetrics{}
+		bw, client := newTestBitwarden(
+			WithLogger(slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+			WithMetrics(metrics),
+			WithCache(time.Minute),
+		)
+
+		client.On("Do", mock.Anything).Return(itemResponse(itemID), nil).Once()
+
+		_, err := bw.GetItem(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.NotContains(t, logs.String(), "caller")
+		assert.Empty(t, metrics.callers)
+		assert.Equal(t, "", CallerFromContext(context.Background()))
+	})
+}
@@ -0,0 +1,206 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// CLI reads and writes the vault by running bw commands, for environments
+// where bw serve cannot open a local port. It returns the same errors as
+// BitwardenServer: ErrNotFound for unknown items and ErrBadRequest, with the
+// message of bw, for other failed commands, which is what bw serve answers
+// in those cases.
+type CLI struct {
+	path     string
+	session  string
+	payloads payloads
+}
+
+// CLIOption configures optional behaviour of a CLI.
+type CLIOption func(*CLI)
+
+// WithCLIPath sets the bw executable to run. Defaults to bw on the PATH.
+func WithCLIPath(path string) CLIOption {
+	return func(c *CLI) { c.path = path }
+}
+
+var _ SecretSource = (*CLI)(nil)
+
+// NewCLI returns a CLI for an unlocked vault. session is the key printed by
+// bw unlock; it is passed in BW_SESSION, which bw treats like the --session
+// flag, so it does not show up in the process list.
+func NewCLI(session string, opts ...CLIOption) *CLI {
+	c := &CLI{path: "bw", session: session}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *CLI) run(ctx context.Context, resp any, args ...string) error {
+	out, err := c.output(ctx, args...)
+	if err != nil {
+		return err
+	}
+	if resp != nil {
+		return c.payloads.unmarshal(out, resp)
+	}
+	return nil
+}
+
+// output runs bw and returns what it printed to stdout.
+func (c *CLI) output(ctx context.Context, args ...string) ([]byte, error) {
+	return c.outputEnv(ctx, nil, args...)
+}
+
+// outputEnv is output with additional environment variables, for secrets
+// that should not show up in the process list.
+func (c *CLI) outputEnv(ctx context.Context, env []string, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.path, append(args, "--nointeraction")...)
+	cmd.Env = append(append(os.Environ(), "BW_SESSION="+c.session), env...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = strings.TrimSpace(stdout.String())
+		}
+		return nil, cliError(err, msg)
+	}
+	return stdout.Bytes(), nil
+}
+
+// stream runs bw and copies what it prints to stdout to w as it is printed,
+// through buf if it is not nil, instead of holding it in memory.
+func (c *CLI) stream(ctx context.Context, w io.Writer, buf []byte, args ...string) error {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.path, append(args, "--nointeraction")...)
+	cmd.Env = append(os.Environ(), "BW_SESSION="+c.session)
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	_, copyErr := copyBuffer(w, stdout, buf)
+	if copyErr != nil {
+		io.Copy(io.Discard, stdout) // let bw finish instead of blocking on a full pipe
+	}
+	if err := cmd.Wait(); err != nil {
+		return cliError(err, strings.TrimSpace(stderr.String()))
+	}
+	return copyErr
+}
+
+// cliError returns the error for a failed run of bw that printed msg.
+func cliError(err error, msg string) error {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return err
+	}
+	if msg == "Not found." {
+		return ErrNotFound
+	}
+	if typed, ok := messageErrors[msg]; ok {
+		return fmt.Errorf("%w: %w", ErrBadRequest, typed)
+	}
+	return fmt.Errorf("%w: %s", ErrBadRequest, msg)
+}
+
+func (c *CLI) GetItem(ctx context.Context, id string) (*Item, error) {
+	var item Item
+	if err := c.run(ctx, &item, "get", "item", id); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (c *CLI) ListItems(ctx context.Context, opts ...ListOption) ([]Item, error) {
+	o, err := newListOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(o.query))
+	for key := range o.query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	args := []string{"list", "items"}
+	for _, key := range keys {
+		if key == "trash" {
+			args = append(args, "--trash")
+			continue
+		}
+		args = append(args, "--"+key, o.query.Get(key))
+	}
+
+	var items []Item
+	if err := c.run(ctx, &items, args...); err != nil {
+		return nil, err
+	}
+	return o.filter(items), nil
+}
+
+// CreateItem creates a new item and returns it as stored by the server, see
+// BitwardenServer.CreateItem.
+func (c *CLI) CreateItem(ctx context.Context, item *Item) (*Item, error) {
+	req, err := createRequest(item)
+	if err != nil {
+		return nil, err
+	}
+	if err := DefaultValidationLimits.validate(&req); err != nil {
+		return nil, err
+	}
+	data, err := c.payloads.marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"create", "item", base64.StdEncoding.EncodeToString(data)}
+	if req.OrganizationID != nil {
+		args = append(args, "--organizationid", *req.OrganizationID)
+	}
+	var created Item
+	if err := c.run(ctx, &created, args...); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (c *CLI) GetLogin(ctx context.Context, id string) (*Login, error) {
+	i, err := c.GetItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return i.login()
+}
+
+func (c *CLI) GetSecureNote(ctx context.Context, id string) (string, error) {
+	i, err := c.GetItem(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return i.secureNote()
+}
+
+// GetField returns the value of the custom field with the given name.
+func (c *CLI) GetField(ctx context.Context, id string, name string) (string, error) {
+	i, err := c.GetItem(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return i.field(name)
+}
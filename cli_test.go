@@ -0,0 +1,227 @@
+package bitwarden
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeBW writes a bw executable that logs its session and arguments and
+// answers like the real one for a single known item.
+func fakeBW(t *testing.T) (cli *CLI, calls func() []string) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs a POSIX shell")
+	}
+	dir := t.TempDir()
+	log := filepath.Join(dir, "calls")
+	script := `#!/bin/sh
+echo "$BW_SESSION $*" >> ` + log + `
+case "$1 $2 $3" in
+"get item known")
+	echo '{"id":"known","type":1,"login":{"username":"admin","password":"hunter2"},"fields":[{"name":"token","value":"t0k3n","type":1}]}' ;;
+"get item "*)
+	echo "Not found." >&2; exit 1 ;;
+"list items"*)
+	echo '[{"id":"known","type":1}]' ;;
+"create item "*)
+	echo '{"id":"created","type":2,"notes":"hello"}' ;;
+"export --format json")
+	echo '{"encrypted":false,"folders":[],"items":[]}' ;;
+"export --format encrypted_json")
+	echo '{"encrypted":true,"passwordProtected":true}' ;;
+"import bitwardencsv "*)
+	cp "$3" ` + filepath.Join(dir, "imported") + `; echo "Imported 1 items." ;;
+"import "*)
+	echo "Format is not valid." >&2; exit 1 ;;
+"sync"*)
+	echo "Session expired." >&2; exit 1 ;;
+"send receive https://send.example/#/text/"*)
+	[ "$BW_SEND_PASSWORD" = "open sesame" ] || { echo "Invalid password." >&2; exit 1; }
+	echo '{"object":"send-access","id":"s1","name":"token","type":0,"text":{"text":"one-time","hidden":true}}' ;;
+"send receive https://send.example/#/file/"*)
+	case "$4" in
+	--obj) echo '{"object":"send-access","id":"s2","name":"cert","type":1,"file":{"id":"f1","fileName":"tls.crt","size":"27"}}' ;;
+	--output) echo "-----BEGIN CERTIFICATE-----" > "$5" ;;
+	esac ;;
+"--version"*)
+	echo "2024.9.0" ;;
+*)
+	echo "You are not logged in." >&2; exit 1 ;;
+esac
+`
+	path := filepath.Join(dir, "bw")
+	assert.NoError(t, os.WriteFile(path, []byte(script), 0o700))
+
+	return NewCLI("s3ss10n", WithCLIPath(path)), func() []string {
+		data, _ := os.ReadFile(log)
+		return strings.Split(strings.TrimSpace(string(data)), "\n")
+	}
+}
+
+func TestCLIGetItem(t *testing.T) {
+	t.Run("Should read the item with the session", func(t *testing.T) {
+		cli, calls := fakeBW(t)
+
+		login, err := cli.GetLogin(context.Background(), "known")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "hunter2", *login.Password)
+		assert.Equal(t, []string{"s3ss10n get item known --nointeraction"}, calls())
+	})
+
+	t.Run("Should read custom fields", func(t *testing.T) {
+		cli, _ := fakeBW(t)
+
+		token, err := cli.GetField(context.Background(), "known", "token")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "t0k3n", token)
+	})
+
+	t.Run("Should return the errors of the serve client", func(t *testing.T) {
+		cli, _ := fakeBW(t)
+
+		_, err := cli.GetItem(context.Background(), "unknown")
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		_, err = cli.GetSecureNote(context.Background(), "known")
+		assert.ErrorIs(t, err, ErrNotASecureNote)
+	})
+}
+
+func TestCLIListItems(t *testing.T) {
+	t.Run("Should pass the list options as flags", func(t *testing.T) {
+		cli, calls := fakeBW(t)
+
+		items, err := cli.ListItems(context.Background(), Search("git"), InFolder("6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a"), InTrash())
+
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+		assert.Equal(t, []string{"s3ss10n list items --folderid 6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a --search git --trash --nointeraction"}, calls())
+	})
+
+	t.Run("Should cut pages of the listed items", func(t *testing.T) {
+		cli, _ := fakeBW(t)
+
+		items, err := cli.ListItems(context.Background(), Page(2), PerPage(1))
+
+		assert.NoError(t, err)
+		assert.Empty(t, items)
+	})
+}
+
+func TestCLICreateItem(t *testing.T) {
+	t.Run("Should pass the encoded item", func(t *testing.T) {
+		cli, calls := fakeBW(t)
+
+		name, notes := "ENV", "hello"
+		item, err := cli.CreateItem(context.Background(), &Item{Type: TypeSecureNote, Name: &name, Notes: &notes})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "created", item.ID)
+		args := strings.Fields(calls()[0])
+		data, err := base64.StdEncoding.DecodeString(args[3])
+		assert.NoError(t, err)
+		var sent map[string]any
+		assert.NoError(t, json.Unmarshal(data, &sent))
+		assert.Equal(t, "hello", sent["notes"])
+		assert.Equal(t, map[string]any{"type": float64(0)}, sent["secureNote"])
+	})
+
+	t.Run("Should pass the organization of organization items", func(t *testing.T) {
+		cli, calls := fakeBW(t)
+
+		org, name := "org", "ENV"
+		_, err := cli.CreateItem(context.Background(), &Item{Type: TypeSecureNote, Name: &name, OrganizationID: &org, CollectionIDs: []string{"c1"}})
+
+		assert.NoError(t, err)
+		args := strings.Fields(calls()[0])
+		assert.Equal(t, []string{"--organizationid", "org", "--nointeraction"}, args[4:])
+	})
+}
+
+func TestCLIErrors(t *testing.T) {
+	t.Run("Should wrap failed commands as bad requests", func(t *testing.T) {
+		cli, _ := fakeBW(t)
+
+		err := cli.run(context.Background(), nil, "sync")
+
+		assert.ErrorIs(t, err, ErrBadRequest)
+		assert.EqualError(t, err, "bad request: Session expired.")
+	})
+
+	t.Run("Should return typed errors for well known messages", func(t *testing.T) {
+		cli, _ := fakeBW(t)
+
+		err := cli.run(context.Background(), nil, "status")
+
+		assert.ErrorIs(t, err, ErrBadRequest)
+		assert.ErrorIs(t, err, ErrNotLoggedIn)
+	})
+}
+
+func TestCLIVersion(t *testing.T) {
+	t.Run("Should parse the output of bw --version", func(t *testing.T) {
+		cli, _ := fakeBW(t)
+
+		v, err := cli.CLIVersion(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, Version{2024, 9, 0}, v)
+	})
+
+	t.Run("Should detect the version of bw serve once", func(t *testing.T) {
+		cli, calls := fakeBW(t)
+		version := &cliVersion{path: cli.path}
+
+		for i := 0; i < 2; i++ {
+			v, err := version.get(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, Version{2024, 9, 0}, v)
+		}
+		assert.Len(t, calls(), 1)
+	})
+}
+
+func TestCLIAccessSend(t *testing.T) {
+	t.Run("Should receive text with the password in the environment", func(t *testing.T) {
+		cli, calls := fakeBW(t)
+
+		send, err := cli.AccessSend(context.Background(), "https://send.example/#/text/key", "open sesame")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &SendContent{ID: "s1", Name: "token", Type: SendTypeText, Text: "one-time"}, send)
+		assert.Equal(t, []string{"s3ss10n send receive https://send.example/#/text/key --obj --passwordenv BW_SEND_PASSWORD --nointeraction"}, calls())
+	})
+
+	t.Run("Should return the error of a wrong password", func(t *testing.T) {
+		cli, _ := fakeBW(t)
+
+		_, err := cli.AccessSend(context.Background(), "https://send.example/#/text/key", "wrong")
+
+		assert.ErrorIs(t, err, ErrBadRequest)
+	})
+
+	t.Run("Should stream files from a temporary file", func(t *testing.T) {
+		cli, _ := fakeBW(t)
+
+		send, err := cli.AccessSend(context.Background(), "https://send.example/#/file/key", "")
+		assert.NoError(t, err)
+		data, err := io.ReadAll(send.File)
+		assert.NoError(t, err)
+		path := send.File.(tempFile).Name()
+		assert.NoError(t, send.File.Close())
+
+		assert.Equal(t, "tls.crt", send.FileName)
+		assert.Equal(t, "-----BEGIN CERTIFICATE-----\n", string(data))
+		assert.NoFileExists(t, path)
+	})
+}
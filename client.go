@@ -0,0 +1,92 @@
+package bitwarden
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os/exec"
+	"text/template"
+	"time"
+)
+
+// Client is implemented by BitwardenServer. Code using this package can
+// depend on it to substitute a mock, such as the one in the mocks package,
+// in tests.
+type Client interface {
+	SecretSource
+
+	Close()
+	Unlock(ctx context.Context, password string) error
+	Lock(ctx context.Context) error
+	Sync(ctx context.Context) error
+	Healthy(ctx context.Context) error
+	Status(ctx context.Context) (*VaultStatus, error)
+	CLIVersion(ctx context.Context) (Version, error)
+	DebugTrail() []RequestRecord
+
+	GetItem(ctx context.Context, id string) (*Item, error)
+	GetItemIfChanged(ctx context.Context, id string, since time.Time) (*Item, bool, error)
+	ListItems(ctx context.Context, opts ...ListOption) ([]Item, error)
+	CreateItem(ctx context.Context, item *Item) (*Item, error)
+	EditItem(ctx context.Context, item *Item) (*Item, error)
+	CloneItem(ctx context.Context, id string, mutate func(*Item)) (*Item, error)
+	UpsertItemByName(ctx context.Context, folderID, name string, build func(*Item)) (*Item, bool, error)
+	FindDuplicates(ctx context.Context) ([][]Item, error)
+	MergeItems(ctx context.Context, keep string, drop []string) (*Item, error)
+	SetFavorite(ctx context.Context, id string, fav bool) error
+	SetField(ctx context.Context, itemID, name, value string, typ FieldType) error
+	DeleteField(ctx context.Context, itemID, name string) error
+	DeleteItem(ctx context.Context, id string) error
+	DeleteItems(ctx context.Context, ids []string) error
+	ListTrash(ctx context.Context) ([]Item, error)
+	EmptyTrash(ctx context.Context, olderThan time.Duration) error
+	MoveItemsToFolder(ctx context.Context, ids []string, folderID string) error
+	MoveItemsToFolderPath(ctx context.Context, ids []string, path string) error
+	AssignItemsToCollections(ctx context.Context, ids []string, collectionIDs []string) error
+	AddItemToCollection(ctx context.Context, itemID, collectionID string) error
+	RemoveItemFromCollection(ctx context.Context, itemID, collectionID string) error
+	ListAttachments(ctx context.Context, itemID string) ([]Attachment, error)
+	DownloadAttachment(ctx context.Context, itemID string, attachmentID string, opts ...DownloadOption) (io.ReadCloser, error)
+	DownloadAttachmentTo(ctx context.Context, itemID, attachmentID string, w io.Writer, opts ...DownloadOption) (int64, error)
+	UploadAttachment(ctx context.Context, itemID, fileName string, r io.Reader) (*Item, error)
+	DeleteAttachment(ctx context.Context, itemID, attachmentID string) error
+	SyncAttachments(ctx context.Context, itemID, dir string) error
+	DownloadAttachmentToFile(ctx context.Context, itemID, attachmentID, path string, mode fs.FileMode, opts ...DownloadOption) error
+	ListFolders(ctx context.Context) ([]Folder, error)
+	ListOrganizations(ctx context.Context) ([]Organization, error)
+	ListOrgMembers(ctx context.Context, orgID string) ([]OrgMember, error)
+	CreateFolder(ctx context.Context, name string) (*Folder, error)
+	GetFolderTree(ctx context.Context) (*FolderTree, error)
+	EnsureFolderPath(ctx context.Context, path string) (*Folder, error)
+	Apply(ctx context.Context, desired []ItemSpec, opts ...ApplyOption) (*ApplyResult, error)
+	Scoped(scope Scope) *ScopedClient
+
+	Generate(ctx context.Context, opts GenerateOptions) (string, error)
+	GenerateUsername(ctx context.Context, opts UsernameOptions) (string, error)
+	RotateLoginPassword(ctx context.Context, itemID string, gen GenerateOptions) (old, new string, err error)
+	RevertLoginPassword(ctx context.Context, itemID string) (string, error)
+	AuditVault(ctx context.Context, opts ...AuditVaultOption) (*AuditReport, error)
+
+	Resolve(ctx context.Context, ref SecretRef) (string, error)
+	ResolveAll(ctx context.Context, refs map[string]SecretRef, opts ...ResolveOption) (map[string]string, error)
+	Decode(ctx context.Context, v any) error
+	ExpandString(ctx context.Context, s string) (string, error)
+	TemplateFuncs(ctx context.Context) template.FuncMap
+	GetDSNParams(ctx context.Context, itemID string) (*DSNParams, error)
+	DSN(ctx context.Context, itemID string, format DSNFormat) (string, error)
+	BuildDSN(ctx context.Context, itemID string, tmpl string) (string, error)
+	RunWithSecrets(ctx context.Context, cmd *exec.Cmd, mapping map[string]SecretRef) error
+	WriteDotenv(ctx context.Context, w io.Writer, source DotenvSource) error
+	ToKubernetesSecret(ctx context.Context, mapping map[string]SecretRef, name, namespace string) (*KubernetesSecret, error)
+	FS(ctx context.Context) fs.FS
+
+	PurgeCache() error
+	Warm(ctx context.Context, ids ...string) error
+	WarmByFolder(ctx context.Context, folderID string) error
+
+	Watch(ctx context.Context, interval time.Duration) (<-chan ChangeEvent, error)
+	OnItemChange(id string, fn func(*Item)) (unsubscribe func())
+	OnDSNChange(itemID string, format DSNFormat, fn func(dsn string)) (unsubscribe func())
+}
+
+var _ Client = (*BitwardenServer)(nil)
@@ -0,0 +1,65 @@
+package bitwarden
+
+import (
+	"context"
+	"time"
+)
+
+// Clock tells the time and makes tickers. Set one with WithClock to
+// simulate time in tests instead of sleeping; bitwardentest.Clock is a
+// clock that only moves when told to.
+type Clock interface {
+	Now() time.Time
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker delivers ticks like a time.Ticker.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// WithClock sets the clock of the cache expiry, the Watch interval, the
+// trash cutoff of EmptyTrash, password ages of AuditVault, the token age of
+// PerRPCCredentials, the time of audit entries and password history
+// entries, the wait for bw serve to start, and the remaining time of a
+// context deadline before an interactive unlock, so such deadlines must be
+// set relative to the clock. Defaults to SystemClock. Request durations in
+// logs, metrics and DebugTrail are always measured with the system clock.
+func WithClock(c Clock) Option {
+	return func(b *BitwardenServer) { b.clock = c }
+}
+
+// SystemClock returns the clock of the system.
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+// sleep waits for d on the clock, or returns the error of ctx once it is
+// done.
+func sleep(ctx context.Context, c Clock, d time.Duration) error {
+	t := c.NewTicker(d)
+	defer t.Stop()
+	select {
+	case <-t.C():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return systemTicker{time.NewTicker(d)}
+}
+
+type systemTicker struct {
+	t *time.Ticker
+}
+
+func (t systemTicker) C() <-chan time.Time { return t.t.C }
+
+func (t systemTicker) Stop() { t.t.Stop() }
@@ -0,0 +1,43 @@
+package bitwarden
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// CloneItem creates a copy of the item with the given ID, for example to
+// stamp out per-environment credentials from a template. The ID, dates,
+// attachments, password history and passkeys of the copy are cleared before
+// mutate, which may be nil, is called on it; passkeys are bound to the
+// original credential, so they are never copied.
+func (b *BitwardenServer) CloneItem(ctx context.Context, id string, mutate func(*Item)) (*Item, error) {
+	ctx, span := b.startSpan(ctx, "CloneItem", attribute.String("bitwarden.item_id", id))
+	item, err := b.GetItem(ctx, id)
+	if err != nil {
+		return nil, endSpan(span, err)
+	}
+
+	// Deep copy so mutate cannot change the original, which may be cached.
+	clone, err := copyItem(item)
+	if err != nil {
+		return nil, endSpan(span, err)
+	}
+	clone.ID = ""
+	clone.CreationDate = time.Time{}
+	clone.RevisionDate = nil
+	clone.DeletedDate = nil
+	clone.Attachments = nil
+	clone.PasswordHistory = nil
+	if clone.Login != nil {
+		clone.Login.PasswordRevisionDate = nil
+		clone.Login.Fido2Credentials = nil
+	}
+	if mutate != nil {
+		mutate(clone)
+	}
+
+	created, err := b.CreateItem(ctx, clone)
+	return created, endSpan(span, err)
+}
@@ -0,0 +1,64 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCloneItem(t *testing.T) {
+	itemID := "382a9d7b-f6b5-4eaa-92a1-1f3c7d89e48f"
+
+	t.Run("Should create a mutated copy without server assigned fields", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithCache(time.Minute))
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"data":{"id":"` + itemID + `","type":1,"name":"db-staging","creationDate":"2023-01-01T01:02:03Z","revisionDate":"2023-05-06T07:08:09Z","login":{"username":"app","password":"hunter2","passwordRevisionDate":"2023-05-06T07:08:09Z","fido2Credentials":[{"credentialId":"c1"}]},"passwordHistory":[{"lastUsedDate":"2023-05-06T07:08:09Z","password":"old"}],"attachments":[{"id":"att"}]}}`))}, nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequestJSON(http.MethodPost, "http://localhost/object/item", func(body map[string]any) bool {
+				login := body["login"].(map[string]any)
+				return body["id"] == nil && body["name"] == "db-production" && body["revisionDate"] == nil &&
+					body["passwordHistory"] == nil && body["attachments"] == nil &&
+					login["username"] == "app-production" && login["passwordRevisionDate"] == nil && login["fido2Credentials"] == nil
+			}))).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"data":{"id":"copy","type":1,"name":"db-production"}}`))}, nil).
+			Once()
+
+		clone, err := bw.CloneItem(context.Background(), itemID, func(item *Item) {
+			name := "db-production"
+			item.Name = &name
+			username := "app-production"
+			item.Login.Username = &username
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "copy", clone.ID)
+		original, err := bw.GetItem(context.Background(), itemID)
+		assert.NoError(t, err)
+		assert.Equal(t, "db-staging", *original.Name)
+		assert.Equal(t, "app", *original.Login.Username)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("Should return the error of the lookup", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))).
+			Return(&http.Response{StatusCode: 404, Body: io.NopCloser(bytes.NewBufferString(`{"success":false,"message":"Not found."}`))}, nil).
+			Once()
+
+		_, err := bw.CloneItem(context.Background(), itemID, nil)
+
+		assert.ErrorIs(t, err, ErrNotFound)
+		client.AssertExpectations(t)
+	})
+}
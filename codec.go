@@ -0,0 +1,73 @@
+package bitwarden
+
+import "encoding/json"
+
+// Codec encodes the bodies sent to bw and decodes the bodies it returns.
+// The default uses encoding/json; a faster implementation can be set with
+// WithCodec, as long as it honours the json struct tags. Like UnmarshalJSON,
+// Unmarshal must copy data if it keeps any of it, because response buffers
+// are reused.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// DecodeHook rewrites the body of a response before it is decoded, for
+// example to normalize date formats or to turn "true" into true, to cope
+// with payloads that differ between versions of bw or the server.
+type DecodeHook func(data []byte) ([]byte, error)
+
+// WithCodec sets the codec for request and response bodies.
+func WithCodec(c Codec) Option {
+	return func(b *BitwardenServer) { b.payloads.codec = c }
+}
+
+// WithDecodeHook adds a hook that is run on every response body before it
+// is decoded. Hooks run in the order they are added.
+func WithDecodeHook(h DecodeHook) Option {
+	return func(b *BitwardenServer) { b.payloads.hooks = append(b.payloads.hooks, h) }
+}
+
+// WithCLICodec sets the codec for the input and output of bw, see WithCodec.
+func WithCLICodec(c Codec) CLIOption {
+	return func(cli *CLI) { cli.payloads.codec = c }
+}
+
+// WithCLIDecodeHook adds a hook that is run on the output of bw before it is
+// decoded, see WithDecodeHook.
+func WithCLIDecodeHook(h DecodeHook) CLIOption {
+	return func(cli *CLI) { cli.payloads.hooks = append(cli.payloads.hooks, h) }
+}
+
+// payloads encodes and decodes bodies with the codec and hooks. The zero
+// value uses encoding/json without hooks.
+type payloads struct {
+	codec Codec
+	hooks []DecodeHook
+}
+
+func (p payloads) marshal(v any) ([]byte, error) {
+	if p.codec == nil {
+		return json.Marshal(v)
+	}
+	return p.codec.Marshal(v)
+}
+
+func (p payloads) unmarshal(data []byte, v any) error {
+	for _, hook := range p.hooks {
+		var err error
+		if data, err = hook(data); err != nil {
+			return err
+		}
+	}
+	if p.codec == nil {
+		return json.Unmarshal(data, v)
+	}
+	return p.codec.Unmarshal(data, v)
+}
+
+// streams reports whether bodies can be decoded as they are read, which
+// codecs and hooks cannot.
+func (p payloads) streams() bool {
+	return p.codec == nil && len(p.hooks) == 0
+}
@@ -0,0 +1,102 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// countingCodec is encoding/json that counts its calls.
+type countingCodec struct {
+	marshals, unmarshals int
+}
+
+func (c *countingCodec) Marshal(v any) ([]byte, error) {
+	c.marshals++
+	return json.Marshal(v)
+}
+
+func (c *countingCodec) Unmarshal(data []byte, v any) error {
+	c.unmarshals++
+	return json.Unmarshal(data, v)
+}
+
+func TestCodec(t *testing.T) {
+	itemID := "382a9d7b-f6b5-4eaa-92a1-1f3c7d89e48f"
+
+	t.Run("Should use the codec for requests and responses", func(t *testing.T) {
+		codec := &countingCodec{}
+		bw, client := newTestBitwarden(WithCodec(codec))
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodPut, "http://localhost/object/item/"+itemID, `{"id":"`+itemID+`","creationDate":"0001-01-01T00:00:00Z","revisionDate":null,"deletedDate":null,"organizationId":null,"collectionId":null,"collectionIds":null,"folderId":null,"type":2,"name":"ENV","notes":null,"favorite":false,"fields":null,"login":null,"secureNote":null,"card":null,"identity":null,"sshKey":null,"attachments":null,"passwordHistory":null,"reprompt":0}`))).
+			Return(itemResponse(itemID), nil).
+			Once()
+
+		name := "ENV"
+		_, err := bw.EditItem(context.Background(), &Item{ID: itemID, Type: TypeSecureNote, Name: &name})
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, codec.marshals)
+		assert.Equal(t, 1, codec.unmarshals)
+	})
+
+	t.Run("Should run the decode hooks in order before decoding", func(t *testing.T) {
+		bw, client := newTestBitwarden(
+			WithDecodeHook(func(data []byte) ([]byte, error) {
+				return bytes.ReplaceAll(data, []byte(`"favorite":"yes"`), []byte(`"favorite":"true"`)), nil
+			}),
+			WithDecodeHook(func(data []byte) ([]byte, error) {
+				return bytes.ReplaceAll(data, []byte(`"favorite":"true"`), []byte(`"favorite":true`)), nil
+			}),
+		)
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"data":{"id":"` + itemID + `","type":2,"favorite":"yes"}}`))}, nil).
+			Once()
+
+		item, err := bw.GetItem(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.True(t, item.Favorite)
+	})
+
+	t.Run("Should return errors of decode hooks", func(t *testing.T) {
+		hookErr := errors.New("hook failed")
+		bw, client := newTestBitwarden(WithDecodeHook(func([]byte) ([]byte, error) { return nil, hookErr }))
+
+		client.
+			On("Do", mock.Anything).
+			Return(itemResponse(itemID), nil).
+			Once()
+
+		_, err := bw.GetItem(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, hookErr)
+	})
+}
+
+func TestCLIDecodeHook(t *testing.T) {
+	t.Run("Should run the decode hooks on the output of bw", func(t *testing.T) {
+		cli, _ := fakeBW(t)
+		cli = NewCLI("s3ss10n", WithCLIPath(cli.path), WithCLIDecodeHook(func(data []byte) ([]byte, error) {
+			return bytes.ReplaceAll(data, []byte(`"admin"`), []byte(`"root"`)), nil
+		}))
+
+		login, err := cli.GetLogin(context.Background(), "known")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "root", *login.Username)
+	})
+}
@@ -0,0 +1,49 @@
+package bitwarden
+
+import (
+	"context"
+	"slices"
+)
+
+// AddItemToCollection adds the organization item to the collection, keeping
+// its other collections. It does nothing if the item is already in it, and
+// returns ErrConflict if the item changed while it was being updated.
+func (b *BitwardenServer) AddItemToCollection(ctx context.Context, itemID, collectionID string) error {
+	return b.editCollections(ctx, itemID, func(ids []string) []string {
+		if slices.Contains(ids, collectionID) {
+			return ids
+		}
+		return append(ids, collectionID)
+	})
+}
+
+// RemoveItemFromCollection removes the organization item from the
+// collection, keeping its other collections. It does nothing if the item is
+// not in it. bw refuses to remove an item from its last collection.
+func (b *BitwardenServer) RemoveItemFromCollection(ctx context.Context, itemID, collectionID string) error {
+	return b.editCollections(ctx, itemID, func(ids []string) []string {
+		return slices.DeleteFunc(ids, func(id string) bool { return id == collectionID })
+	})
+}
+
+// editCollections reads the collections of the item, changes them with edit
+// and writes them back. bw has no conditional updates, so right before the
+// write the item is read again, and if its revision changed it returns
+// ErrConflict without writing, like WithConflictDetection.
+func (b *BitwardenServer) editCollections(ctx context.Context, itemID string, edit func([]string) []string) error {
+	item, err := b.fetchItem(ctx, itemID)
+	if err != nil {
+		return err
+	}
+	ids := edit(slices.Clone(item.CollectionIDs))
+	if slices.Equal(ids, item.CollectionIDs) {
+		return nil
+	}
+	if err := b.checkRevision(ctx, item); err != nil {
+		return err
+	}
+	if ids == nil {
+		ids = []string{}
+	}
+	return b.setCollections(ctx, itemID, ids)
+}
@@ -0,0 +1,74 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestItemCollections(t *testing.T) {
+	itemRequest := mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/1", ``))
+	item := func(revision string, collections string) func(*http.Request) *http.Response {
+		return func(*http.Request) *http.Response {
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"data":{"id":"1","organizationId":"org","revisionDate":"` + revision + `","collectionIds":` + collections + `}}`))}
+		}
+	}
+
+	t.Run("Should add the collection to the existing ones", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.On("Do", itemRequest).Return(item("2024-01-01T00:00:00Z", `["c1"]`), nil).Twice()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodPut, "http://localhost/object/item-collections/1", `["c1","c2"]`))).
+			Return(item("2024-01-02T00:00:00Z", `["c1","c2"]`), nil).
+			Once()
+
+		err := bw.AddItemToCollection(context.Background(), "1", "c2")
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should not write unchanged collections", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.On("Do", itemRequest).Return(item("2024-01-01T00:00:00Z", `["c1","c2"]`), nil).Twice()
+
+		assert.NoError(t, bw.AddItemToCollection(context.Background(), "1", "c2"))
+		assert.NoError(t, bw.RemoveItemFromCollection(context.Background(), "1", "c3"))
+		client.AssertExpectations(t)
+	})
+
+	t.Run("Should remove the collection", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.On("Do", itemRequest).Return(item("2024-01-01T00:00:00Z", `["c1","c2"]`), nil).Twice()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodPut, "http://localhost/object/item-collections/1", `["c2"]`))).
+			Return(item("2024-01-02T00:00:00Z", `["c2"]`), nil).
+			Once()
+
+		err := bw.RemoveItemFromCollection(context.Background(), "1", "c1")
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should not overwrite a change made in the meantime", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.On("Do", itemRequest).Return(item("2024-01-01T00:00:00Z", `["c1"]`), nil).Once()
+		client.On("Do", itemRequest).Return(item("2024-01-02T00:00:00Z", `["c1","c3"]`), nil).Once()
+
+		err := bw.AddItemToCollection(context.Background(), "1", "c2")
+
+		client.AssertExpectations(t)
+		client.AssertNotCalled(t, "Do", mock.MatchedBy(checkRequest(http.MethodPut, "http://localhost/object/item-collections/1", `["c1","c2"]`)))
+		assert.ErrorIs(t, err, ErrConflict)
+	})
+}
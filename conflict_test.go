@@ -0,0 +1,56 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestConflictDetection(t *testing.T) {
+	itemRequest := mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/1", ``))
+	stored := func(revision string) *http.Response {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"data":{"id":"1","type":2,"name":"ENV","revisionDate":"` + revision + `"}}`))}
+	}
+	name := "ENV"
+	seen := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Should write items that did not change", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithConflictDetection())
+
+		client.On("Do", itemRequest).Return(stored("2024-01-01T00:00:00Z"), nil).Once()
+		client.On("Do", mock.MatchedBy(checkRequestJSON(http.MethodPut, "http://localhost/object/item/1", func(map[string]any) bool { return true }))).Return(stored("2024-01-02T00:00:00Z"), nil).Once()
+
+		_, err := bw.EditItem(context.Background(), &Item{ID: "1", Type: TypeSecureNote, Name: &name, RevisionDate: &seen})
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should refuse to overwrite changed items", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithConflictDetection())
+
+		client.On("Do", itemRequest).Return(stored("2024-01-02T00:00:00Z"), nil).Once()
+
+		_, err := bw.EditItem(context.Background(), &Item{ID: "1", Type: TypeSecureNote, Name: &name, RevisionDate: &seen})
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrConflict)
+	})
+
+	t.Run("Should not check without the option", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.On("Do", mock.MatchedBy(checkRequestJSON(http.MethodPut, "http://localhost/object/item/1", func(map[string]any) bool { return true }))).Return(stored("2024-01-02T00:00:00Z"), nil).Once()
+
+		_, err := bw.EditItem(context.Background(), &Item{ID: "1", Type: TypeSecureNote, Name: &name, RevisionDate: &seen})
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+}
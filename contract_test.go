@@ -0,0 +1,106 @@
+package bitwarden
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/floriaanpost/go-bitwarden-client/fixtures"
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "update the golden files of the contract tests")
+
+// assertGolden compares the JSON of v with testdata/contract/<name>.golden.
+func assertGolden(t *testing.T, name string, v any) {
+	got, err := json.MarshalIndent(v, "", "  ")
+	assert.NoError(t, err)
+	path := filepath.Join("testdata", "contract", name+".golden")
+	if *update {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, append(got, '\n'), 0o644))
+	}
+	want, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(want), string(got))
+}
+
+func TestContract(t *testing.T) {
+	ctx := context.Background()
+	items := map[string]string{
+		"login":      fixtures.LoginItemID,
+		"securenote": fixtures.SecureNoteItemID,
+		"card":       fixtures.CardItemID,
+		"identity":   fixtures.IdentityItemID,
+	}
+
+	for _, version := range fixtures.Versions() {
+		rec, err := fixtures.Load(version)
+		assert.NoError(t, err)
+		bw := NewFromURL(fixtures.URL, WithHTTPClient(rec.Client()))
+
+		t.Run("Should unlock, sync and lock with cli "+version, func(t *testing.T) {
+			assert.NoError(t, bw.Unlock(ctx, "password"))
+			assert.NoError(t, bw.Sync(ctx))
+			assert.NoError(t, bw.Lock(ctx))
+		})
+
+		t.Run("Should decode items of cli "+version, func(t *testing.T) {
+			for name, id := range items {
+				item, err := bw.GetItem(ctx, id)
+				assert.NoError(t, err)
+				assertGolden(t, filepath.Join(version, name), item)
+			}
+
+			item, err := bw.GetItem(ctx, fixtures.SSHKeyItemID)
+			if version < "2025" {
+				assert.ErrorIs(t, err, ErrNotFound)
+			} else {
+				assert.NoError(t, err)
+				assertGolden(t, filepath.Join(version, "sshkey"), item)
+			}
+
+			_, err = bw.GetItem(ctx, fixtures.MissingItemID)
+			assert.ErrorIs(t, err, ErrNotFound)
+		})
+
+		t.Run("Should read typed values of cli "+version, func(t *testing.T) {
+			login, err := bw.GetLogin(ctx, fixtures.LoginItemID)
+			assert.NoError(t, err)
+			assert.Equal(t, "hunter2", *login.Password)
+			assert.Equal(t, MatchExact, *login.URIs[1].Match)
+
+			note, err := bw.GetSecureNote(ctx, fixtures.SecureNoteItemID)
+			assert.NoError(t, err)
+			assert.Equal(t, "DB_HOST=db.internal\nDB_PASSWORD=\"p@ss word\"", note)
+
+			token, err := bw.GetField(ctx, fixtures.LoginItemID, "token")
+			assert.NoError(t, err)
+			assert.NotEmpty(t, token)
+		})
+
+		t.Run("Should list items of cli "+version, func(t *testing.T) {
+			all, err := bw.ListItems(ctx)
+			assert.NoError(t, err)
+			assert.GreaterOrEqual(t, len(all), len(items))
+
+			inFolder, err := bw.ListItems(ctx, InFolder(fixtures.FolderID))
+			assert.NoError(t, err)
+			assert.Len(t, inFolder, 1)
+			assert.Equal(t, fixtures.LoginItemID, inFolder[0].ID)
+		})
+
+		t.Run("Should download attachments of cli "+version, func(t *testing.T) {
+			r, err := bw.DownloadAttachment(ctx, fixtures.LoginItemID, fixtures.AttachmentID)
+			assert.NoError(t, err)
+			defer r.Close()
+			data, err := io.ReadAll(r)
+			assert.NoError(t, err)
+			assert.Equal(t, "1. 4f8a-2b9c\n2. 7d1e-0c3f\n", string(data))
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package bitwarden
+
+import (
+	"sync"
+	"time"
+)
+
+const defaultDebugTrailSize = 20
+
+// RequestRecord describes a request to bw serve, for debugging. Bodies and
+// query parameters are never recorded.
+type RequestRecord struct {
+	Start    time.Time
+	Method   string
+	Endpoint string
+	// Status is the status code of the response, or 0 if there was none.
+	Status int
+	// Duration is how long the request took, or has been running so far if
+	// it is still in flight.
+	Duration time.Duration
+	InFlight bool
+	Error    string
+}
+
+// WithDebugTrail sets how many of the last requests DebugTrail returns.
+// Defaults to 20; 0 disables the trail.
+func WithDebugTrail(n int) Option {
+	return func(b *BitwardenServer) { b.trail = newDebugTrail(n) }
+}
+
+// DebugTrail returns the last requests of the client, oldest first,
+// including those still in flight. It is meant for support, when the client
+// misbehaved and no logger was configured.
+func (b *BitwardenServer) DebugTrail() []RequestRecord {
+	return b.trail.records()
+}
+
+// debugTrail is a ring buffer of the last requests. A nil trail records
+// nothing.
+type debugTrail struct {
+	mu      sync.Mutex
+	entries []*RequestRecord
+	next    int
+}
+
+func newDebugTrail(size int) *debugTrail {
+	if size <= 0 {
+		return nil
+	}
+	return &debugTrail{entries: make([]*RequestRecord, size)}
+}
+
+func (t *debugTrail) start(method, endpoint string) *RequestRecord {
+	if t == nil {
+		return nil
+	}
+	rec := &RequestRecord{Start: time.Now(), Method: method, Endpoint: endpoint, InFlight: true}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.entries[t.next] = rec
+	t.next = (t.next + 1) % len(t.entries)
+	return rec
+}
+
+func (t *debugTrail) finish(rec *RequestRecord, status int, err error) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	rec.Status = status
+	rec.Duration = time.Since(rec.Start)
+	rec.InFlight = false
+	if err != nil {
+		rec.Error = err.Error()
+	}
+}
+
+func (t *debugTrail) records() []RequestRecord {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var records []RequestRecord
+	for i := range t.entries {
+		rec := t.entries[(t.next+i)%len(t.entries)]
+		if rec == nil {
+			continue
+		}
+		r := *rec
+		if r.InFlight {
+			r.Duration = time.Since(r.Start)
+		}
+		records = append(records, r)
+	}
+	return records
+}
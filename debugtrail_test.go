@@ -0,0 +1,86 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDebugTrail(t *testing.T) {
+	t.Run("Should record the last requests without query or body", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithDebugTrail(2))
+
+		for i := 0; i < 2; i++ {
+			client.
+				On("Do", mock.MatchedBy(checkRequest(http.MethodPost, "http://localhost/sync", `{}`))).
+				Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"success":true}`))}, nil).
+				Once()
+		}
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items?search=secret", ``))).
+			Return(&http.Response{StatusCode: 500, Body: io.NopCloser(bytes.NewBufferString(`{"success":false,"message":"boom"}`))}, nil).
+			Once()
+
+		assert.NoError(t, bw.Sync(context.Background()))
+		assert.NoError(t, bw.Sync(context.Background()))
+		_, err := bw.ListItems(context.Background(), Search("secret"))
+		assert.Error(t, err)
+
+		trail := bw.DebugTrail()
+		if assert.Len(t, trail, 2) {
+			assert.Equal(t, "/sync", trail[0].Endpoint)
+			assert.Equal(t, 200, trail[0].Status)
+			assert.Empty(t, trail[0].Error)
+			assert.Equal(t, http.MethodGet, trail[1].Method)
+			assert.Equal(t, "/list/object/items", trail[1].Endpoint)
+			assert.Equal(t, 500, trail[1].Status)
+			assert.NotEmpty(t, trail[1].Error)
+			assert.False(t, trail[1].InFlight)
+		}
+		client.AssertExpectations(t)
+	})
+
+	t.Run("Should show requests in flight", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+		started, unblock := make(chan struct{}), make(chan struct{})
+
+		client.
+			On("Do", mock.Anything).
+			Return(func(req *http.Request) (*http.Response, error) {
+				close(started)
+				<-unblock
+				return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"success":true}`))}, nil
+			}, nil).
+			Once()
+
+		done := make(chan error)
+		go func() { done <- bw.Sync(context.Background()) }()
+		<-started
+
+		trail := bw.DebugTrail()
+		if assert.Len(t, trail, 1) {
+			assert.True(t, trail[0].InFlight)
+			assert.Equal(t, 0, trail[0].Status)
+		}
+		close(unblock)
+		assert.NoError(t, <-done)
+		assert.False(t, bw.DebugTrail()[0].InFlight)
+	})
+
+	t.Run("Should record nothing when disabled", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithDebugTrail(0))
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"success":true}`))}, nil).
+			Once()
+
+		assert.NoError(t, bw.Sync(context.Background()))
+		assert.Empty(t, bw.DebugTrail())
+	})
+}
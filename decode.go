@@ -0,0 +1,288 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const tagName = "bitwarden"
+
+var (
+	ErrInvalidTag    = errors.New("invalid bitwarden struct tag")
+	ErrRequiredField = errors.New("required field is missing")
+)
+
+// Decode fills the fields of the struct v points to from the vault. Fields
+// are selected with a struct tag of the form
+//
+//	`bitwarden:"item=<id>,field=<selector>[,default=<value>][,required]"`
+//
+// where selector is a selector of SecretRef, such as password or
+// attachment:cert.pem. Instead of item and field, the tag can hold a
+// reference in the form of ParseSecretRef, as ref=bw://<id>/password. Nested structs and pointers to structs are decoded
+// recursively. Missing values fall back to the default, are an error when
+// required, and are left untouched otherwise. Supported field types are
+// strings, byte slices such as SecureString, booleans, numbers and
+// time.Duration.
+func (b *BitwardenServer) Decode(ctx context.Context, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: Decode needs a non-nil pointer to a struct, got %T", ErrInvalidTag, v)
+	}
+	d := decoder{bw: b, items: map[string]*Item{}}
+	return d.decodeStruct(ctx, rv.Elem(), "")
+}
+
+type decoder struct {
+	bw    *BitwardenServer
+	items map[string]*Item
+}
+
+type fieldTag struct {
+	item       string
+	field      string
+	def        string
+	hasDefault bool
+	required   bool
+}
+
+func parseFieldTag(tag string) (fieldTag, error) {
+	var t fieldTag
+	for _, part := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "item":
+			t.item = value
+		case "field":
+			t.field = value
+		case "ref":
+			ref, err := ParseSecretRef(value)
+			if err != nil {
+				return t, fmt.Errorf("%w: %w", ErrInvalidTag, err)
+			}
+			t.item, t.field = ref.ItemID, ref.Selector
+		case "default":
+			t.def, t.hasDefault = value, true
+		case "required":
+			t.required = true
+		default:
+			return t, fmt.Errorf("%w: unknown option %q", ErrInvalidTag, key)
+		}
+	}
+	if t.item == "" || t.field == "" {
+		return t, fmt.Errorf("%w: item and field are required", ErrInvalidTag)
+	}
+	return t, nil
+}
+
+func (d *decoder) decodeStruct(ctx context.Context, v reflect.Value, prefix string) error {
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		fv := v.Field(i)
+		path := prefix + sf.Name
+
+		tag, ok := sf.Tag.Lookup(tagName)
+		if !ok {
+			if err := d.decodeNested(ctx, fv, path); err != nil {
+				return err
+			}
+			continue
+		}
+
+		t, err := parseFieldTag(tag)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if err := d.decodeField(ctx, fv, t); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeNested(ctx context.Context, v reflect.Value, path string) error {
+	switch {
+	case v.Kind() == reflect.Struct && v.Type() != reflect.TypeOf(time.Time{}):
+		return d.decodeStruct(ctx, v, path+".")
+	case v.Kind() == reflect.Pointer && v.Type().Elem().Kind() == reflect.Struct:
+		if v.IsNil() {
+			if !hasTaggedFields(v.Type().Elem(), map[reflect.Type]bool{}) {
+				return nil
+			}
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return d.decodeStruct(ctx, v.Elem(), path+".")
+	}
+	return nil
+}
+
+// hasTaggedFields reports whether t or any struct nested in it has fields to
+// decode, so nil pointers to unrelated structs are not allocated.
+func hasTaggedFields(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		if _, ok := sf.Tag.Lookup(tagName); ok {
+			return true
+		}
+		ft := sf.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct && hasTaggedFields(ft, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *decoder) decodeField(ctx context.Context, v reflect.Value, t fieldTag) error {
+	value, err := d.lookup(ctx, SecretRef{ItemID: t.item, Selector: t.field})
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrNotFound) || errors.Is(err, ErrFieldNotFound):
+		if t.hasDefault {
+			value = t.def
+			break
+		}
+		if t.required {
+			return fmt.Errorf("%w: %w", ErrRequiredField, err)
+		}
+		return nil
+	default:
+		return err
+	}
+	return setValue(v, value)
+}
+
+// lookup resolves ref, fetching every item only once.
+func (d *decoder) lookup(ctx context.Context, ref SecretRef) (string, error) {
+	if err := d.bw.authorize(ctx, ref); err != nil {
+		return "", err
+	}
+	ctx = withAccess(ctx, ref)
+	item, ok := d.items[ref.ItemID]
+	if !ok {
+		var err error
+		item, err = d.bw.GetItem(ctx, ref.ItemID)
+		if err != nil {
+			return "", err
+		}
+		d.items[ref.ItemID] = item
+	}
+	if kind, name := splitSelector(ref.Selector); kind == "attachment" {
+		return d.bw.attachmentValue(ctx, item, name)
+	}
+	return item.Value(ref.Selector)
+}
+
+// Value returns a well known property of the item (name, notes, username,
+// password, totp or uri) or, failing that, the custom field with the given
+// name. A field: prefix, as in SecretRef, selects a custom field even if it
+// has the name of a property.
+func (item *Item) Value(field string) (string, error) {
+	if name, ok := strings.CutPrefix(field, "field:"); ok {
+		for _, f := range item.Fields {
+			if f.Name == name {
+				return f.Value, nil
+			}
+		}
+		return "", fmt.Errorf("%w: %s", ErrFieldNotFound, name)
+	}
+	var value *string
+	switch field {
+	case "name":
+		value = item.Name
+	case "notes":
+		value = item.Notes
+	case "username", "password", "totp", "uri":
+		if item.Login == nil {
+			break
+		}
+		switch field {
+		case "username":
+			value = item.Login.Username
+		case "password":
+			value = item.Login.Password
+		case "totp":
+			value = item.Login.TOTP
+		case "uri":
+			if len(item.Login.URIs) > 0 {
+				value = item.Login.URIs[0].URI
+			}
+		}
+	default:
+		for _, f := range item.Fields {
+			if f.Name == field {
+				return f.Value, nil
+			}
+		}
+	}
+	if value == nil {
+		return "", fmt.Errorf("%w: %s", ErrFieldNotFound, field)
+	}
+	return *value, nil
+}
+
+func setValue(v reflect.Value, s string) error {
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("%w: unsupported type %s", ErrInvalidTag, v.Type())
+		}
+		v.SetBytes([]byte(s))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("%w: unsupported type %s", ErrInvalidTag, v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDecode(t *testing.T) {
+	itemID := "1d4cf845-8012-4b2d-a924-f9d8c9b7c44a"
+	respData := `{"data":{"id":"` + itemID + `","type":1,"name":"Database","login":{"username":"admin","password":"hunter2","uris":[{"match":null,"uri":"postgres://db:5432"}]},"fields":[{"name":"port","value":"5432","type":0},{"name":"timeout","value":"3s","type":0},{"name":"tls","value":"true","type":2}]}}`
+	itemRequest := mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))
+	itemResponse := func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(respData))}, nil
+	}
+
+	t.Run("Should fill tagged and nested fields with one request per item", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", itemRequest).
+			Return(itemResponse).
+			Once()
+
+		type TLS struct {
+			Enabled bool `bitwarden:"item=1d4cf845-8012-4b2d-a924-f9d8c9b7c44a,field=tls"`
+		}
+		var cfg struct {
+			Username string        `bitwarden:"item=1d4cf845-8012-4b2d-a924-f9d8c9b7c44a,field=username"`
+			Password []byte        `bitwarden:"item=1d4cf845-8012-4b2d-a924-f9d8c9b7c44a,field=password"`
+			URI      string        `bitwarden:"item=1d4cf845-8012-4b2d-a924-f9d8c9b7c44a,field=uri"`
+			Port     int           `bitwarden:"item=1d4cf845-8012-4b2d-a924-f9d8c9b7c44a,field=port"`
+			Timeout  time.Duration `bitwarden:"item=1d4cf845-8012-4b2d-a924-f9d8c9b7c44a,field=timeout"`
+			Untagged string
+			TLS      *TLS
+			Other    *http.Client
+		}
+		cfg.Untagged = "keep"
+
+		err := bw.Decode(context.Background(), &cfg)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "admin", cfg.Username)
+		assert.Equal(t, []byte("hunter2"), cfg.Password)
+		assert.Equal(t, "postgres://db:5432", cfg.URI)
+		assert.Equal(t, 5432, cfg.Port)
+		assert.Equal(t, 3*time.Second, cfg.Timeout)
+		assert.Equal(t, "keep", cfg.Untagged)
+		assert.True(t, cfg.TLS.Enabled)
+		assert.Nil(t, cfg.Other)
+	})
+
+	t.Run("Should accept references in the ref option", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", itemRequest).
+			Return(itemResponse).
+			Once()
+
+		var cfg struct {
+			Password string `bitwarden:"ref=bw://1d4cf845-8012-4b2d-a924-f9d8c9b7c44a/password"`
+			Timeout  string `bitwarden:"ref=bw://1d4cf845-8012-4b2d-a924-f9d8c9b7c44a/field/timeout"`
+		}
+
+		err := bw.Decode(context.Background(), &cfg)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "hunter2", cfg.Password)
+		assert.Equal(t, "3s", cfg.Timeout)
+
+		var invalid struct {
+			Password string `bitwarden:"ref=bw://1d4cf845-8012-4b2d-a924-f9d8c9b7c44a/email"`
+		}
+		err = bw.Decode(context.Background(), &invalid)
+		assert.ErrorIs(t, err, ErrInvalidTag)
+		assert.ErrorIs(t, err, ErrInvalidSecretRef)
+	})
+
+	t.Run("Should use defaults and check required fields", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", itemRequest).
+			Return(itemResponse).
+			Twice()
+
+		var cfg struct {
+			Host     string `bitwarden:"item=1d4cf845-8012-4b2d-a924-f9d8c9b7c44a,field=host,default=localhost"`
+			Database string `bitwarden:"item=1d4cf845-8012-4b2d-a924-f9d8c9b7c44a,field=database"`
+		}
+		err := bw.Decode(context.Background(), &cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, "localhost", cfg.Host)
+		assert.Empty(t, cfg.Database)
+
+		var required struct {
+			Nested struct {
+				Database string `bitwarden:"item=1d4cf845-8012-4b2d-a924-f9d8c9b7c44a,field=database,required"`
+			}
+		}
+		err = bw.Decode(context.Background(), &required)
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrRequiredField)
+		assert.ErrorIs(t, err, ErrFieldNotFound)
+		assert.ErrorContains(t, err, "Nested.Database")
+	})
+
+	t.Run("Should treat missing items as missing values", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.Anything).
+			Return(func(*http.Request) (*http.Response, error) { return &http.Response{StatusCode: 404}, nil })
+
+		var cfg struct {
+			Password string `bitwarden:"item=missing,field=password,default=changeme"`
+			Token    string `bitwarden:"item=missing,field=token,required"`
+		}
+		err := bw.Decode(context.Background(), &cfg)
+
+		assert.ErrorIs(t, err, ErrRequiredField)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Equal(t, "changeme", cfg.Password)
+	})
+
+	t.Run("Should return other request errors", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 500}, nil).
+			Once()
+
+		var cfg struct {
+			Password string `bitwarden:"item=x,field=password,default=changeme"`
+		}
+		err := bw.Decode(context.Background(), &cfg)
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrUnexpectedStatusCode)
+	})
+
+	t.Run("Should reject invalid targets and tags", func(t *testing.T) {
+		bw, _ := newTestBitwarden()
+
+		var s struct{}
+		assert.ErrorIs(t, bw.Decode(context.Background(), s), ErrInvalidTag)
+
+		var missingItem struct {
+			Password string `bitwarden:"field=password"`
+		}
+		assert.ErrorIs(t, bw.Decode(context.Background(), &missingItem), ErrInvalidTag)
+
+		var unknownOption struct {
+			Password string `bitwarden:"item=x,field=password,optional"`
+		}
+		assert.ErrorIs(t, bw.Decode(context.Background(), &unknownOption), ErrInvalidTag)
+	})
+
+	t.Run("Should report unsupported types and bad values", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", itemRequest).
+			Return(itemResponse).
+			Twice()
+
+		var unsupported struct {
+			Tags []string `bitwarden:"item=1d4cf845-8012-4b2d-a924-f9d8c9b7c44a,field=username"`
+		}
+		assert.ErrorIs(t, bw.Decode(context.Background(), &unsupported), ErrInvalidTag)
+
+		var badValue struct {
+			Port int `bitwarden:"item=1d4cf845-8012-4b2d-a924-f9d8c9b7c44a,field=username"`
+		}
+		assert.Error(t, bw.Decode(context.Background(), &badValue))
+
+		client.AssertExpectations(t)
+	})
+}
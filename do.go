@@ -0,0 +1,26 @@
+package bitwarden
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Do calls an endpoint of bw serve that this package does not wrap yet,
+// such as one added by a newer bw. body, if not nil, is sent as JSON, and
+// the data field of the response is decoded into T. Errors, middleware,
+// logging and metrics are the same as for the wrapped endpoints:
+//
+//	status, err := bitwarden.Do[map[string]any](ctx, bw, http.MethodGet, "/status", nil)
+func Do[T any](ctx context.Context, bw *BitwardenServer, method, endpoint string, body any) (T, error) {
+	if !strings.HasPrefix(endpoint, "/") {
+		endpoint = "/" + endpoint
+	}
+	resp := struct {
+		Data T `json:"data"`
+	}{}
+	ctx, span := bw.startSpan(ctx, "Do", attribute.String("http.request.method", method))
+	err := endSpan(span, bw.request(ctx, method, endpoint, body, &resp))
+	return resp.Data, err
+}
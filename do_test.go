@@ -0,0 +1,46 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDo(t *testing.T) {
+	t.Run("Should decode the data of the response", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodPost, "http://localhost/object/send", `{"name":"note"}`))).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"success":true,"data":{"object":"send","id":"s1"}}`))}, nil).
+			Once()
+
+		type send struct {
+			ID string `json:"id"`
+		}
+		resp, err := Do[send](context.Background(), bw, http.MethodPost, "object/send", map[string]string{"name": "note"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, send{ID: "s1"}, resp)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("Should map errors like the wrapped endpoints", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 400, Body: io.NopCloser(bytes.NewBufferString(`{"success":false,"message":"Vault is locked."}`))}, nil).
+			Once()
+
+		_, err := Do[any](context.Background(), bw, http.MethodGet, "/object/send/s1", nil)
+
+		assert.ErrorIs(t, err, ErrVaultLocked)
+		client.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,182 @@
+// Package dockercredential implements a Docker credential helper on top of
+// the vault, so docker login state is kept in login items instead of in
+// plain text in ~/.docker/config.json. A helper binary named
+// docker-credential-<name> only needs to unlock a client and serve it:
+//
+//	func main() {
+//		bw := bitwarden.New()
+//		defer bw.Close()
+//		// unlock the vault ...
+//		credentials.Serve(dockercredential.New(context.Background(), bw))
+//	}
+//
+// and can then be configured as credsStore in ~/.docker/config.json.
+//
+// The helper only touches items it owns: the items in the WithFolder folder
+// or, without one, login items marked with the MarkerField custom field. The
+// helper adds the field to the items it creates; mark existing items by hand
+// to hand them to Docker.
+package dockercredential
+
+import (
+	"context"
+
+	"github.com/docker/docker-credential-helpers/credentials"
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+)
+
+// MarkerField is the boolean custom field that marks a login item as a
+// Docker registry credential.
+const MarkerField = "docker_credential"
+
+type Client interface {
+	ListItems(ctx context.Context, opts ...bitwarden.ListOption) ([]bitwarden.Item, error)
+	CreateItem(ctx context.Context, item *bitwarden.Item) (*bitwarden.Item, error)
+	EditItem(ctx context.Context, item *bitwarden.Item) (*bitwarden.Item, error)
+	DeleteItem(ctx context.Context, id string) error
+}
+
+// Option configures the helper.
+type Option func(*Helper)
+
+// WithFolder keeps the credentials in a single folder. New credentials are
+// created in it and all login items in it are considered, marked or not.
+func WithFolder(folderID string) Option {
+	return func(h *Helper) { h.folderID = &folderID }
+}
+
+// Helper is a credentials.Helper backed by the vault. Every registry is a
+// login item named after its server URL, holding the username and secret
+// and the server URL as its URI.
+type Helper struct {
+	ctx      context.Context
+	client   Client
+	folderID *string
+}
+
+var _ credentials.Helper = (*Helper)(nil)
+
+// New returns a helper that uses ctx for its vault requests.
+func New(ctx context.Context, c Client, opts ...Option) *Helper {
+	h := &Helper{ctx: ctx, client: c}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Add stores the credentials, replacing the username and secret of an
+// existing item for the same server URL.
+func (h *Helper) Add(creds *credentials.Credentials) error {
+	if creds.ServerURL == "" {
+		return credentials.NewErrCredentialsMissingServerURL()
+	}
+	if creds.Username == "" {
+		return credentials.NewErrCredentialsMissingUsername()
+	}
+
+	item, err := h.find(creds.ServerURL)
+	if err != nil {
+		return err
+	}
+	if item != nil {
+		item.Login.Username = &creds.Username
+		item.Login.Password = &creds.Secret
+		_, err = h.client.EditItem(h.ctx, item)
+		return err
+	}
+
+	_, err = h.client.CreateItem(h.ctx, &bitwarden.Item{
+		Type:     bitwarden.TypeLogin,
+		Name:     &creds.ServerURL,
+		FolderID: h.folderID,
+		Fields:   []bitwarden.Field{{Name: MarkerField, Value: "true", Type: bitwarden.FieldBoolean}},
+		Login: &bitwarden.Login{
+			URIs:     []bitwarden.URI{{URI: &creds.ServerURL}},
+			Username: &creds.Username,
+			Password: &creds.Secret,
+		},
+	})
+	return err
+}
+
+// Delete removes the item for the server URL.
+func (h *Helper) Delete(serverURL string) error {
+	item, err := h.find(serverURL)
+	if err != nil {
+		return err
+	}
+	if item == nil {
+		return credentials.NewErrCredentialsNotFound()
+	}
+	return h.client.DeleteItem(h.ctx, item.ID)
+}
+
+// Get returns the username and secret for the server URL.
+func (h *Helper) Get(serverURL string) (string, string, error) {
+	item, err := h.find(serverURL)
+	if err != nil {
+		return "", "", err
+	}
+	if item == nil {
+		return "", "", credentials.NewErrCredentialsNotFound()
+	}
+	return value(item.Login.Username), value(item.Login.Password), nil
+}
+
+// List returns the usernames of all stored credentials by server URL.
+func (h *Helper) List() (map[string]string, error) {
+	items, err := h.list()
+	if err != nil {
+		return nil, err
+	}
+	creds := map[string]string{}
+	for _, i := range items {
+		if h.owns(&i) {
+			creds[*i.Name] = value(i.Login.Username)
+		}
+	}
+	return creds, nil
+}
+
+func (h *Helper) list(opts ...bitwarden.ListOption) ([]bitwarden.Item, error) {
+	if h.folderID != nil {
+		opts = append(opts, bitwarden.InFolder(*h.folderID))
+	}
+	return h.client.ListItems(h.ctx, opts...)
+}
+
+// find returns the item for the server URL, or nil if there is none.
+func (h *Helper) find(serverURL string) (*bitwarden.Item, error) {
+	items, err := h.list(bitwarden.Search(serverURL))
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range items {
+		if h.owns(&i) && *i.Name == serverURL {
+			return &i, nil
+		}
+	}
+	return nil, nil
+}
+
+// owns reports whether the item is a registry credential of the helper, so
+// that a personal login named after a registry is never listed, overwritten
+// or deleted.
+func (h *Helper) owns(i *bitwarden.Item) bool {
+	if i.Type != bitwarden.TypeLogin || i.Login == nil || i.Name == nil || *i.Name == "" {
+		return false
+	}
+	if h.folderID != nil {
+		return i.FolderID != nil && *i.FolderID == *h.folderID
+	}
+	v, err := i.Value(MarkerField)
+	return err == nil && v == "true"
+}
+
+func value(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,152 @@
+package dockercredential
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/docker/docker-credential-helpers/credentials"
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Client = (*bitwarden.BitwardenServer)(nil)
+
+type fakeClient struct {
+	items   []bitwarden.Item
+	err     error
+	created []bitwarden.Item
+	edited  []bitwarden.Item
+	deleted []string
+}
+
+func (f *fakeClient) ListItems(context.Context, ...bitwarden.ListOption) ([]bitwarden.Item, error) {
+	return f.items, f.err
+}
+
+func (f *fakeClient) CreateItem(_ context.Context, item *bitwarden.Item) (*bitwarden.Item, error) {
+	f.created = append(f.created, *item)
+	return item, nil
+}
+
+func (f *fakeClient) EditItem(_ context.Context, item *bitwarden.Item) (*bitwarden.Item, error) {
+	f.edited = append(f.edited, *item)
+	return item, nil
+}
+
+func (f *fakeClient) DeleteItem(_ context.Context, id string) error {
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+func ptr(s string) *string { return &s }
+
+func registry(id, serverURL, username, secret string) bitwarden.Item {
+	return bitwarden.Item{ID: id, Type: bitwarden.TypeLogin, Name: ptr(serverURL), Login: &bitwarden.Login{
+		URIs:     []bitwarden.URI{{URI: ptr(serverURL)}},
+		Username: ptr(username),
+		Password: ptr(secret),
+	}, Fields: []bitwarden.Field{{Name: MarkerField, Value: "true", Type: bitwarden.FieldBoolean}}}
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{items: []bitwarden.Item{
+		registry("1", "https://index.docker.io/v1/", "alice", "hub-token"),
+		registry("2", "ghcr.io", "bob", "gh-token"),
+		{ID: "3", Type: bitwarden.TypeSecureNote, Name: ptr("ghcr.io")},
+		{ID: "4", Type: bitwarden.TypeLogin, Name: ptr("quay.io"), Login: &bitwarden.Login{Username: ptr("personal"), Password: ptr("hunter2")}},
+	}}
+}
+
+func TestGet(t *testing.T) {
+	t.Run("Should return the credentials of the registry", func(t *testing.T) {
+		username, secret, err := New(context.Background(), newFakeClient()).Get("ghcr.io")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "bob", username)
+		assert.Equal(t, "gh-token", secret)
+	})
+
+	t.Run("Should return not found for unknown registries", func(t *testing.T) {
+		_, _, err := New(context.Background(), newFakeClient()).Get("quay.io")
+
+		assert.True(t, credentials.IsErrCredentialsNotFound(err))
+	})
+
+	t.Run("Should return client errors", func(t *testing.T) {
+		clientErr := errors.New("locked")
+		_, _, err := New(context.Background(), &fakeClient{err: clientErr}).Get("ghcr.io")
+
+		assert.ErrorIs(t, err, clientErr)
+	})
+}
+
+func TestAdd(t *testing.T) {
+	t.Run("Should create an item for a new registry", func(t *testing.T) {
+		c := newFakeClient()
+		err := New(context.Background(), c, WithFolder("docker")).Add(&credentials.Credentials{ServerURL: "quay.io", Username: "carol", Secret: "quay-token"})
+
+		assert.NoError(t, err)
+		assert.Len(t, c.created, 1)
+		assert.Equal(t, "quay.io", *c.created[0].Name)
+		assert.Equal(t, "docker", *c.created[0].FolderID)
+		assert.Equal(t, "quay.io", *c.created[0].Login.URIs[0].URI)
+		assert.Equal(t, "carol", *c.created[0].Login.Username)
+		assert.Equal(t, "quay-token", *c.created[0].Login.Password)
+		assert.Equal(t, []bitwarden.Field{{Name: MarkerField, Value: "true", Type: bitwarden.FieldBoolean}}, c.created[0].Fields)
+	})
+
+	t.Run("Should update the item of a known registry", func(t *testing.T) {
+		c := newFakeClient()
+		err := New(context.Background(), c).Add(&credentials.Credentials{ServerURL: "ghcr.io", Username: "bob", Secret: "new-token"})
+
+		assert.NoError(t, err)
+		assert.Empty(t, c.created)
+		assert.Len(t, c.edited, 1)
+		assert.Equal(t, "2", c.edited[0].ID)
+		assert.Equal(t, "new-token", *c.edited[0].Login.Password)
+	})
+
+	t.Run("Should reject credentials without a username", func(t *testing.T) {
+		err := New(context.Background(), newFakeClient()).Add(&credentials.Credentials{ServerURL: "quay.io"})
+
+		assert.True(t, credentials.IsCredentialsMissingUsername(err))
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("Should delete the item of the registry", func(t *testing.T) {
+		c := newFakeClient()
+		err := New(context.Background(), c).Delete("https://index.docker.io/v1/")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1"}, c.deleted)
+	})
+
+	t.Run("Should return not found for unknown registries", func(t *testing.T) {
+		c := newFakeClient()
+		err := New(context.Background(), c).Delete("quay.io")
+
+		assert.True(t, credentials.IsErrCredentialsNotFound(err))
+		assert.Empty(t, c.deleted, "unmarked logins are not registry credentials")
+	})
+}
+
+func TestList(t *testing.T) {
+	t.Run("Should list the usernames by registry", func(t *testing.T) {
+		creds, err := New(context.Background(), newFakeClient()).List()
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"https://index.docker.io/v1/": "alice", "ghcr.io": "bob"}, creds)
+	})
+
+	t.Run("Should list every login in the folder", func(t *testing.T) {
+		c := &fakeClient{items: []bitwarden.Item{
+			{ID: "1", Type: bitwarden.TypeLogin, Name: ptr("quay.io"), FolderID: ptr("docker"), Login: &bitwarden.Login{Username: ptr("carol")}},
+		}}
+		creds, err := New(context.Background(), c, WithFolder("docker")).List()
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"quay.io": "carol"}, creds)
+	})
+}
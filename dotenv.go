@@ -0,0 +1,82 @@
+package bitwarden
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var dotenvBare = regexp.MustCompile(`^[-._/:@a-zA-Z0-9]*$`)
+
+// DotenvSource is what WriteDotenv writes, see DotenvNote and DotenvMapping.
+type DotenvSource interface {
+	dotenv(ctx context.Context, b *BitwardenServer, w io.Writer) error
+}
+
+type dotenvNote string
+
+// DotenvNote writes the secure note with the given ID as is, for notes that
+// hold a complete .env file.
+func DotenvNote(itemID string) DotenvSource {
+	return dotenvNote(itemID)
+}
+
+func (n dotenvNote) dotenv(ctx context.Context, b *BitwardenServer, w io.Writer) error {
+	note, err := b.GetSecureNote(ctx, string(n))
+	if err != nil {
+		return err
+	}
+	if note != "" && !strings.HasSuffix(note, "\n") {
+		note += "\n"
+	}
+	_, err = io.WriteString(w, note)
+	return err
+}
+
+type dotenvMapping map[string]SecretRef
+
+// DotenvMapping resolves mapping and writes a NAME=value line for every
+// mapped name, sorted by name. Values are quoted where needed.
+func DotenvMapping(mapping map[string]SecretRef) DotenvSource {
+	return dotenvMapping(mapping)
+}
+
+func (m dotenvMapping) dotenv(ctx context.Context, b *BitwardenServer, w io.Writer) error {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	d := decoder{bw: b, items: map[string]*Item{}}
+	var sb strings.Builder
+	for _, name := range names {
+		ref := m[name]
+		value, err := d.lookup(ctx, ref)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		sb.WriteString(name + "=" + quoteDotenv(value) + "\n")
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteDotenv writes source to w in .env format. Nothing is written if a
+// value cannot be resolved.
+func (b *BitwardenServer) WriteDotenv(ctx context.Context, w io.Writer, source DotenvSource) error {
+	return source.dotenv(ctx, b, w)
+}
+
+// quoteDotenv double quotes values that are not made of safe characters only,
+// escaping what dotenv parsers would otherwise interpret.
+func quoteDotenv(s string) string {
+	if dotenvBare.MatchString(s) {
+		return s
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "`", "\\`", "\n", `\n`, "\r", `\r`)
+	return `"` + r.Replace(s) + `"`
+}
@@ -0,0 +1,74 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWriteDotenv(t *testing.T) {
+	itemID := "1d4cf845-8012-4b2d-a924-f9d8c9b7c44a"
+	itemRequest := mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))
+
+	t.Run("Should write a secure note as is", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		respData := `{"data":{"id":"` + itemID + `","type":2,"notes":"A=1\nB=\"two words\""}}`
+		client.
+			On("Do", itemRequest).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(respData))}, nil).
+			Once()
+
+		var out bytes.Buffer
+		err := bw.WriteDotenv(context.Background(), &out, DotenvNote(itemID))
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "A=1\nB=\"two words\"\n", out.String())
+	})
+
+	t.Run("Should render a mapping with quoting", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		respData := `{"data":{"id":"` + itemID + `","type":1,"login":{"username":"admin","password":"p\"a$s\\s\nword"}}}`
+		client.
+			On("Do", itemRequest).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(respData))}, nil).
+			Once()
+
+		var out bytes.Buffer
+		err := bw.WriteDotenv(context.Background(), &out, DotenvMapping(map[string]SecretRef{
+			"DB_USER":     {ItemID: itemID, Selector: "username"},
+			"DB_PASSWORD": {ItemID: itemID, Selector: "password"},
+		}))
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "DB_PASSWORD=\"p\\\"a\\$s\\\\s\\nword\"\nDB_USER=admin\n", out.String())
+	})
+
+	t.Run("Should write nothing if a value is missing", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		respData := `{"data":{"id":"` + itemID + `","type":1,"login":{"username":"admin"}}}`
+		client.
+			On("Do", itemRequest).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(respData))}, nil).
+			Once()
+
+		var out bytes.Buffer
+		err := bw.WriteDotenv(context.Background(), &out, DotenvMapping(map[string]SecretRef{
+			"DB_USER":     {ItemID: itemID, Selector: "username"},
+			"DB_PASSWORD": {ItemID: itemID, Selector: "password"},
+		}))
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrFieldNotFound)
+		assert.Empty(t, out.String())
+	})
+}
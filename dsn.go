@@ -0,0 +1,168 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"text/template"
+)
+
+// ErrInvalidDSN is returned when a connection parameter cannot be written in
+// the DSN format.
+var ErrInvalidDSN = errors.New("value cannot be written in the dsn")
+
+// DSNParams are the connection parameters read from a login item. Username
+// and password come from the login. Host, Port and Database come from the
+// custom fields host, port and database, or else from the first URI of the
+// login, which may be a URL like postgres://db:5432/app or a plain host:port.
+type DSNParams struct {
+	Username string
+	Password string
+	Host     string
+	Port     string
+	Database string
+	// Fields holds all custom fields of the item by name.
+	Fields map[string]string
+}
+
+// DSNFormat renders connection parameters into a data source name.
+type DSNFormat func(p DSNParams) (string, error)
+
+// TemplateDSN renders a text/template with the DSNParams as data, for
+// example "host={{.Host}} user={{.Username}} password={{.Password}}".
+func TemplateDSN(tmpl string) DSNFormat {
+	return func(p DSNParams) (string, error) {
+		t, err := template.New("dsn").Option("missingkey=error").Parse(tmpl)
+		if err != nil {
+			return "", err
+		}
+		var sb strings.Builder
+		if err := t.Execute(&sb, p); err != nil {
+			return "", err
+		}
+		return sb.String(), nil
+	}
+}
+
+// PostgresDSN renders a postgres:// URL. The port defaults to 5432.
+func PostgresDSN(p DSNParams) (string, error) {
+	port := p.Port
+	if port == "" {
+		port = "5432"
+	}
+	u := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(p.Username, p.Password),
+		Host:   net.JoinHostPort(p.Host, port),
+		Path:   "/" + p.Database,
+	}
+	return u.String(), nil
+}
+
+// MySQLDSN renders a DSN for github.com/go-sql-driver/mysql. The port
+// defaults to 3306. The database name is escaped; the driver splits the user
+// and password at the first colon and takes the password as is, so only a
+// username containing a colon cannot be written and returns ErrInvalidDSN.
+func MySQLDSN(p DSNParams) (string, error) {
+	if strings.Contains(p.Username, ":") {
+		return "", fmt.Errorf("%w: mysql username contains a colon", ErrInvalidDSN)
+	}
+	port := p.Port
+	if port == "" {
+		port = "3306"
+	}
+	return fmt.Sprintf("%s:%s@tcp(%s)/%s", p.Username, p.Password, net.JoinHostPort(p.Host, port), url.PathEscape(p.Database)), nil
+}
+
+// GetDSNParams reads the connection parameters from a login item.
+func (b *BitwardenServer) GetDSNParams(ctx context.Context, itemID string) (*DSNParams, error) {
+	item, err := b.GetItem(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	return dsnParams(item)
+}
+
+// DSN builds a data source name from a login item.
+func (b *BitwardenServer) DSN(ctx context.Context, itemID string, format DSNFormat) (string, error) {
+	p, err := b.GetDSNParams(ctx, itemID)
+	if err != nil {
+		return "", err
+	}
+	return format(*p)
+}
+
+// BuildDSN builds a data source name from a login item using a template, see
+// TemplateDSN.
+func (b *BitwardenServer) BuildDSN(ctx context.Context, itemID string, tmpl string) (string, error) {
+	return b.DSN(ctx, itemID, TemplateDSN(tmpl))
+}
+
+// OnDSNChange calls fn with a fresh data source name whenever a running Watch
+// detects a change to the item, so connection pools can be refreshed after a
+// rotation. Changes that do not produce a valid DSN, such as a deletion, are
+// skipped.
+func (b *BitwardenServer) OnDSNChange(itemID string, format DSNFormat, fn func(dsn string)) (unsubscribe func()) {
+	return b.OnItemChange(itemID, func(item *Item) {
+		if item == nil {
+			return
+		}
+		p, err := dsnParams(item)
+		if err != nil {
+			return
+		}
+		if dsn, err := format(*p); err == nil {
+			fn(dsn)
+		}
+	})
+}
+
+func dsnParams(item *Item) (*DSNParams, error) {
+	if item.Type != TypeLogin {
+		return nil, ErrNotALogin
+	}
+	if item.Login == nil {
+		return nil, ErrEmptyLogin
+	}
+
+	p := DSNParams{Fields: map[string]string{}}
+	for _, f := range item.Fields {
+		p.Fields[f.Name] = f.Value
+	}
+	if item.Login.Username != nil {
+		p.Username = *item.Login.Username
+	}
+	if item.Login.Password != nil {
+		p.Password = *item.Login.Password
+	}
+	if len(item.Login.URIs) > 0 && item.Login.URIs[0].URI != nil {
+		p.Host, p.Port, p.Database = parseDSNURI(*item.Login.URIs[0].URI)
+	}
+	if v, ok := p.Fields["host"]; ok {
+		p.Host = v
+	}
+	if v, ok := p.Fields["port"]; ok {
+		p.Port = v
+	}
+	if v, ok := p.Fields["database"]; ok {
+		p.Database = v
+	}
+	return &p, nil
+}
+
+func parseDSNURI(uri string) (host, port, database string) {
+	if strings.Contains(uri, "://") {
+		u, err := url.Parse(uri)
+		if err != nil {
+			return "", "", ""
+		}
+		return u.Hostname(), u.Port(), strings.TrimPrefix(u.Path, "/")
+	}
+	if h, p, err := net.SplitHostPort(uri); err == nil {
+		return h, p, ""
+	}
+	return uri, "", ""
+}
@@ -0,0 +1,154 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var ErrMergeIntoSelf = errors.New("cannot merge an item into itself")
+
+// FindDuplicates returns the groups of items that have the same name,
+// username and URIs. Names and usernames are compared without case and
+// surrounding spaces, and URIs without case, trailing slashes and order.
+// Groups are sorted by the ID of their first item, and the items of a group
+// by ID.
+func (b *BitwardenServer) FindDuplicates(ctx context.Context) ([][]Item, error) {
+	items, err := b.ListItems(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return findDuplicates(items), nil
+}
+
+func findDuplicates(items []Item) [][]Item {
+	byKey := map[string][]Item{}
+	for _, item := range items {
+		key := duplicateKey(&item)
+		byKey[key] = append(byKey[key], item)
+	}
+
+	var groups [][]Item
+	for _, group := range byKey {
+		if len(group) < 2 {
+			continue
+		}
+		sort.Slice(group, func(i, j int) bool { return group[i].ID < group[j].ID })
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i][0].ID < groups[j][0].ID })
+	return groups
+}
+
+// duplicateKey returns the normalized name, username and URIs of the item,
+// separated by NUL characters, which cannot be part of them.
+func duplicateKey(item *Item) string {
+	var name, username string
+	if item.Name != nil {
+		name = normalize(*item.Name)
+	}
+	var uris []string
+	if item.Login != nil {
+		if item.Login.Username != nil {
+			username = normalize(*item.Login.Username)
+		}
+		for _, u := range item.Login.URIs {
+			if u.URI != nil {
+				uris = append(uris, normalizeURI(*u.URI))
+			}
+		}
+	}
+	sort.Strings(uris)
+	return fmt.Sprintf("%d\x00%s\x00%s\x00%s", item.Type, name, username, strings.Join(uris, "\x00"))
+}
+
+func normalize(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+func normalizeURI(uri string) string {
+	return strings.TrimRight(normalize(uri), "/")
+}
+
+// MergeItems consolidates the items in drop into the item with ID keep and
+// moves them to the trash, so they can still be restored. Custom fields and
+// URIs of the dropped items that keep does not have are added to it; for
+// fields with the same name, the value of keep wins. It returns keep as
+// stored by the server. If some dropped items could not be moved to the
+// trash, it returns the merged item and a BulkError.
+func (b *BitwardenServer) MergeItems(ctx context.Context, keep string, drop []string) (*Item, error) {
+	ctx, span := b.startSpan(ctx, "MergeItems", attribute.String("bitwarden.item_id", keep))
+	item, err := b.mergeItems(ctx, keep, drop)
+	return item, endSpan(span, err)
+}
+
+func (b *BitwardenServer) mergeItems(ctx context.Context, keep string, drop []string) (*Item, error) {
+	for _, id := range drop {
+		if id == keep {
+			return nil, fmt.Errorf("%w: %s", ErrMergeIntoSelf, id)
+		}
+	}
+	item, err := b.fetchItem(ctx, keep)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := false
+	for _, id := range drop {
+		other, err := b.fetchItem(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if mergeItem(item, other) {
+			changed = true
+		}
+	}
+	if changed {
+		if item, err = b.EditItem(ctx, item); err != nil {
+			return nil, err
+		}
+	}
+	return item, b.DeleteItems(ctx, drop)
+}
+
+// mergeItem adds the fields and URIs of other that item does not have to
+// item and reports whether it added any.
+func mergeItem(item, other *Item) bool {
+	changed := false
+	fields := map[string]bool{}
+	for _, f := range item.Fields {
+		fields[f.Name] = true
+	}
+	for _, f := range other.Fields {
+		if !fields[f.Name] {
+			fields[f.Name] = true
+			item.Fields = append(item.Fields, f)
+			changed = true
+		}
+	}
+
+	if other.Login == nil {
+		return changed
+	}
+	if item.Login == nil {
+		item.Login = &Login{}
+	}
+	uris := map[string]bool{}
+	for _, u := range item.Login.URIs {
+		if u.URI != nil {
+			uris[normalizeURI(*u.URI)] = true
+		}
+	}
+	for _, u := range other.Login.URIs {
+		if u.URI != nil && !uris[normalizeURI(*u.URI)] {
+			uris[normalizeURI(*u.URI)] = true
+			item.Login.URIs = append(item.Login.URIs, u)
+			changed = true
+		}
+	}
+	return changed
+}
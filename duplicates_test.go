@@ -0,0 +1,109 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	t.Run("Should group items by name, username and URIs", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items", ``))).
+			Return(listResponse(`
+				{"id":"c","type":1,"name":"GitHub ","login":{"username":"Bot","uris":[{"uri":"https://github.com/"},{"uri":"https://api.github.com"}]}},
+				{"id":"a","type":1,"name":"github","login":{"username":"bot","uris":[{"uri":"https://API.github.com"},{"uri":"https://github.com"}]}},
+				{"id":"b","type":1,"name":"github","login":{"username":"other"}},
+				{"id":"d","type":2,"name":"notes"},
+				{"id":"e","type":2,"name":"Notes"},
+				{"id":"f","type":1,"name":"notes"}`), nil).
+			Once()
+
+		groups, err := bw.FindDuplicates(context.Background())
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		ids := make([][]string, len(groups))
+		for i, group := range groups {
+			for _, item := range group {
+				ids[i] = append(ids[i], item.ID)
+			}
+		}
+		assert.Equal(t, [][]string{{"a", "c"}, {"d", "e"}}, ids)
+	})
+}
+
+func TestMergeItems(t *testing.T) {
+	response := func(data string) *http.Response {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"data":` + data + `}`))}
+	}
+
+	t.Run("Should add missing fields and URIs and trash the other items", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/a", ``))).
+			Return(response(`{"id":"a","type":1,"name":"db","fields":[{"name":"port","value":"5432"}],"login":{"uris":[{"uri":"https://db.example.com"}]}}`), nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/b", ``))).
+			Return(response(`{"id":"b","type":1,"name":"db","fields":[{"name":"port","value":"5433"},{"name":"host","value":"db"}],"login":{"uris":[{"uri":"https://db.example.com/"},{"uri":"https://admin.example.com"}]}}`), nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/c", ``))).
+			Return(response(`{"id":"c","type":2,"name":"db"}`), nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequestJSON(http.MethodPut, "http://localhost/object/item/a", func(body map[string]any) bool {
+				fields := body["fields"].([]any)
+				uris := body["login"].(map[string]any)["uris"].([]any)
+				return len(fields) == 2 && fields[0].(map[string]any)["value"] == "5432" && fields[1].(map[string]any)["name"] == "host" &&
+					len(uris) == 2 && uris[1].(map[string]any)["uri"] == "https://admin.example.com"
+			}))).
+			Return(response(`{"id":"a","type":1,"name":"db"}`), nil).
+			Once()
+		client.On("Do", mock.MatchedBy(checkRequest(http.MethodDelete, "http://localhost/object/item/b", ``))).Return(response(`null`), nil).Once()
+		client.On("Do", mock.MatchedBy(checkRequest(http.MethodDelete, "http://localhost/object/item/c", ``))).Return(response(`null`), nil).Once()
+
+		item, err := bw.MergeItems(context.Background(), "a", []string{"b", "c"})
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "a", item.ID)
+	})
+
+	t.Run("Should not edit the kept item if nothing is added", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/a", ``))).
+			Return(response(`{"id":"a","type":1,"name":"db","login":{"uris":[{"uri":"https://db.example.com"}]}}`), nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/b", ``))).
+			Return(response(`{"id":"b","type":1,"name":"db","login":{"uris":[{"uri":"HTTPS://db.example.com/"}]}}`), nil).
+			Once()
+		client.On("Do", mock.MatchedBy(checkRequest(http.MethodDelete, "http://localhost/object/item/b", ``))).Return(response(`null`), nil).Once()
+
+		_, err := bw.MergeItems(context.Background(), "a", []string{"b"})
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should refuse to merge an item into itself", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		_, err := bw.MergeItems(context.Background(), "a", []string{"b", "a"})
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrMergeIntoSelf)
+	})
+}
@@ -0,0 +1,64 @@
+package bitwarden
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"sort"
+)
+
+// RunWithSecrets resolves mapping and runs cmd with every resolved value
+// added to its environment under the mapped name. The secrets are only
+// passed to the child; neither the environment of the current process nor
+// the slice in cmd.Env is changed. If cmd.Env is nil the child inherits the
+// current environment. The process is killed when ctx is done. Afterwards
+// cmd.Env is restored; the secrets are held in strings, which cannot be
+// zeroed, until the garbage collector reuses their memory.
+func (b *BitwardenServer) RunWithSecrets(ctx context.Context, cmd *exec.Cmd, mapping map[string]SecretRef) error {
+	names := make([]string, 0, len(mapping))
+	for name := range mapping {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	d := decoder{bw: b, items: map[string]*Item{}}
+	orig := cmd.Env
+	env := slices.Clone(orig)
+	if env == nil {
+		env = os.Environ()
+	}
+	for _, name := range names {
+		ref := mapping[name]
+		value, err := d.lookup(ctx, ref)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		env = append(env, name+"="+value)
+	}
+
+	cmd.Env = env
+	defer func() { cmd.Env = orig }()
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			cmd.Process.Kill()
+		case <-done:
+		}
+	}()
+
+	if err := cmd.Wait(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	return nil
+}
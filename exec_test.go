@@ -0,0 +1,102 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRunWithSecrets(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs a POSIX shell")
+	}
+
+	itemID := "1d4cf845-8012-4b2d-a924-f9d8c9b7c44a"
+	respData := `{"data":{"id":"` + itemID + `","type":1,"login":{"username":"admin","password":"hunter2"}}}`
+	itemRequest := mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))
+	mapping := map[string]SecretRef{
+		"BW_TEST_USER":     {ItemID: itemID, Selector: "username"},
+		"BW_TEST_PASSWORD": {ItemID: itemID, Selector: "password"},
+	}
+
+	t.Run("Should only pass secrets to the child", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", itemRequest).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(respData))}, nil).
+			Once()
+
+		var out bytes.Buffer
+		cmd := exec.Command("sh", "-c", `printf '%s:%s' "$BW_TEST_USER" "$BW_TEST_PASSWORD"`)
+		cmd.Stdout = &out
+
+		err := bw.RunWithSecrets(context.Background(), cmd, mapping)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "admin:hunter2", out.String())
+		assert.Empty(t, os.Getenv("BW_TEST_PASSWORD"))
+		assert.Nil(t, cmd.Env)
+	})
+
+	t.Run("Should keep an explicit environment", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", itemRequest).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(respData))}, nil).
+			Once()
+
+		var out bytes.Buffer
+		cmd := exec.Command("sh", "-c", `printf '%s:%s' "$OTHER" "$BW_TEST_USER"`)
+		env := make([]string, 1, 4) // room the secrets must not be written to
+		env[0] = "OTHER=value"
+		cmd.Env = env
+		cmd.Stdout = &out
+
+		err := bw.RunWithSecrets(context.Background(), cmd, mapping)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "value:admin", out.String())
+		assert.Equal(t, []string{"OTHER=value"}, cmd.Env)
+		assert.Equal(t, []string{"OTHER=value", "", "", ""}, env[:4])
+	})
+
+	t.Run("Should not start the command if a secret fails", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 404}, nil).
+			Once()
+
+		cmd := exec.Command("sh", "-c", "exit 0")
+		err := bw.RunWithSecrets(context.Background(), cmd, mapping)
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Nil(t, cmd.Process)
+	})
+
+	t.Run("Should kill the command when the context is done", func(t *testing.T) {
+		bw, _ := newTestBitwarden()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err := bw.RunWithSecrets(ctx, exec.Command("sleep", "10"), nil)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
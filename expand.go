@@ -0,0 +1,41 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var ErrInvalidPlaceholder = errors.New("invalid bitwarden placeholder")
+
+var placeholderPattern = regexp.MustCompile(`\$\{bw:([^}]*)\}`)
+
+// ExpandString replaces every ${bw:<itemID>:<selector>} placeholder in s
+// with the value the selector of SecretRef selects, such as password or
+// field:token. Other text, including other ${...} expressions, is left as is.
+// Every placeholder that cannot be resolved is reported in the error.
+func (b *BitwardenServer) ExpandString(ctx context.Context, s string) (string, error) {
+	d := decoder{bw: b, items: map[string]*Item{}}
+	var errs []error
+
+	expanded := placeholderPattern.ReplaceAllStringFunc(s, func(placeholder string) string {
+		ref := placeholderPattern.FindStringSubmatch(placeholder)[1]
+		id, selector, ok := strings.Cut(ref, ":")
+		if !ok || id == "" || selector == "" {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrInvalidPlaceholder, placeholder))
+			return placeholder
+		}
+		value, err := d.lookup(ctx, SecretRef{ItemID: id, Selector: selector})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", placeholder, err))
+			return placeholder
+		}
+		return value
+	})
+	if len(errs) > 0 {
+		return "", errors.Join(errs...)
+	}
+	return expanded, nil
+}
@@ -0,0 +1,63 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExpandString(t *testing.T) {
+	itemID := "1d4cf845-8012-4b2d-a924-f9d8c9b7c44a"
+	respData := `{"data":{"id":"` + itemID + `","type":1,"login":{"username":"admin","password":"p@ss"},"fields":[{"name":"host","value":"db.internal","type":0}]}}`
+	itemRequest := mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))
+
+	t.Run("Should replace placeholders with one request per item", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", itemRequest).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(respData))}, nil).
+			Once()
+
+		s, err := bw.ExpandString(context.Background(), "postgres://${bw:"+itemID+":username}:${bw:"+itemID+":password}@${bw:"+itemID+":host}/${DB_NAME}")
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "postgres://admin:p@ss@db.internal/${DB_NAME}", s)
+	})
+
+	t.Run("Should leave strings without placeholders alone", func(t *testing.T) {
+		bw, _ := newTestBitwarden()
+
+		s, err := bw.ExpandString(context.Background(), "no $secrets here")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "no $secrets here", s)
+	})
+
+	t.Run("Should report every unresolved placeholder", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", itemRequest).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(respData))}, nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/missing", ``))).
+			Return(&http.Response{StatusCode: 404}, nil).
+			Once()
+
+		_, err := bw.ExpandString(context.Background(), "${bw:"+itemID+":port} ${bw:missing:password} ${bw:"+itemID+"}")
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrFieldNotFound)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.ErrorIs(t, err, ErrInvalidPlaceholder)
+		assert.ErrorContains(t, err, "${bw:missing:password}")
+	})
+}
@@ -0,0 +1,184 @@
+package bitwarden
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/floriaanpost/go-bitwarden-client/internal/encstring"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+type ExportFormat string
+
+const (
+	ExportJSON ExportFormat = "json"
+	ExportCSV  ExportFormat = "csv"
+	// ExportEncryptedJSON is encrypted with the account key, or with the
+	// password set with WithExportPassword.
+	ExportEncryptedJSON ExportFormat = "encrypted_json"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported format")
+	ErrDecrypt           = encstring.ErrDecrypt
+)
+
+type exportOptions struct {
+	password       string
+	organizationID string
+	buf            []byte
+}
+
+// ExportOption configures an export.
+type ExportOption func(*exportOptions)
+
+// WithExportPassword encrypts an ExportEncryptedJSON export with password
+// instead of the account key, so it can be imported into another account
+// and decrypted with DecryptExport.
+// bw only accepts the password as an argument, so it is visible in the
+// process list while the export runs.
+func WithExportPassword(password string) ExportOption {
+	return func(o *exportOptions) { o.password = password }
+}
+
+// WithExportOrganization exports the vault of an organization instead of
+// the individual vault.
+func WithExportOrganization(id string) ExportOption {
+	return func(o *exportOptions) { o.organizationID = id }
+}
+
+// WithExportStream copies the export to w in chunks through buf instead of
+// with io.Copy, so concurrent exports can reuse buffers. Export always
+// streams; a nil buf is the same as not using the option.
+func WithExportStream(buf []byte) ExportOption {
+	if len(buf) == 0 {
+		buf = nil // io.CopyBuffer refuses empty buffers
+	}
+	return func(o *exportOptions) { o.buf = buf }
+}
+
+// Export writes an export of the vault in the given format to w. The export
+// is copied to w as bw prints it instead of being held in memory, so w may
+// hold a partial export if the export fails; write to a temporary file, as
+// backup.Dir does, to keep only complete exports. bw serve has no export
+// endpoint, so this is only available through the CLI.
+func (c *CLI) Export(ctx context.Context, format ExportFormat, w io.Writer, opts ...ExportOption) error {
+	var o exportOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	switch format {
+	case ExportJSON, ExportCSV, ExportEncryptedJSON:
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+	if o.password != "" && format != ExportEncryptedJSON {
+		return fmt.Errorf("%w: a password can only be used with %s", ErrUnsupportedFormat, ExportEncryptedJSON)
+	}
+
+	args := []string{"export", "--format", string(format), "--raw"}
+	if o.password != "" {
+		args = append(args, "--password", o.password)
+	}
+	if o.organizationID != "" {
+		args = append(args, "--organizationid", o.organizationID)
+	}
+	return c.stream(ctx, w, o.buf, args...)
+}
+
+const (
+	kdfPBKDF2   = 0
+	kdfArgon2id = 1
+)
+
+// The KDF settings an export may ask for, the ranges Bitwarden allows. An
+// export file is untrusted input, and larger values would let it demand
+// any amount of work or memory.
+const (
+	minPBKDF2Iterations  = 5000
+	maxPBKDF2Iterations  = 2_000_000
+	minArgon2Iterations  = 1
+	maxArgon2Iterations  = 10
+	minArgon2Memory      = 15 // MiB
+	maxArgon2Memory      = 1024
+	minArgon2Parallelism = 1
+	maxArgon2Parallelism = 16
+)
+
+// passwordProtectedExport is an ExportEncryptedJSON export made with
+// WithExportPassword.
+type passwordProtectedExport struct {
+	Encrypted         bool   `json:"encrypted"`
+	PasswordProtected bool   `json:"passwordProtected"`
+	Salt              string `json:"salt"`
+	KdfType           int    `json:"kdfType"`
+	KdfIterations     uint32 `json:"kdfIterations"`
+	KdfMemory         uint32 `json:"kdfMemory"`
+	KdfParallelism    uint8  `json:"kdfParallelism"`
+	Validation        string `json:"encKeyValidation_DO_NOT_EDIT"`
+	Data              string `json:"data"`
+}
+
+// DecryptExport decrypts an ExportEncryptedJSON export that was made with
+// WithExportPassword and returns the ExportJSON export it contains, which
+// Import reads as bitwardenjson. It returns ErrWrongPassword if password
+// does not match. Exports encrypted with the account key cannot be
+// decrypted without the account and return ErrUnsupportedFormat, as do
+// exports with KDF settings outside the ranges Bitwarden allows.
+func DecryptExport(r io.Reader, password string) ([]byte, error) {
+	var export passwordProtectedExport
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		return nil, err
+	}
+	if !export.Encrypted || !export.PasswordProtected {
+		return nil, fmt.Errorf("%w: not a password protected export", ErrUnsupportedFormat)
+	}
+	key, err := exportKey(&export, password)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := encstring.Decrypt(key, export.Validation); err != nil {
+		return nil, ErrWrongPassword
+	}
+	return encstring.Decrypt(key, export.Data)
+}
+
+// exportKey derives the key of the export from the password like bw does:
+// the KDF of the export stretched into an encryption and a MAC key.
+func exportKey(export *passwordProtectedExport, password string) ([]byte, error) {
+	var master []byte
+	switch export.KdfType {
+	case kdfPBKDF2:
+		if export.KdfIterations < minPBKDF2Iterations || export.KdfIterations > maxPBKDF2Iterations {
+			return nil, fmt.Errorf("%w: %d PBKDF2 iterations", ErrUnsupportedFormat, export.KdfIterations)
+		}
+		master = pbkdf2.Key([]byte(password), []byte(export.Salt), int(export.KdfIterations), 32, sha256.New)
+	case kdfArgon2id:
+		switch {
+		case export.KdfIterations < minArgon2Iterations || export.KdfIterations > maxArgon2Iterations:
+			return nil, fmt.Errorf("%w: %d Argon2id iterations", ErrUnsupportedFormat, export.KdfIterations)
+		case export.KdfMemory < minArgon2Memory || export.KdfMemory > maxArgon2Memory:
+			return nil, fmt.Errorf("%w: %d MiB of Argon2id memory", ErrUnsupportedFormat, export.KdfMemory)
+		case export.KdfParallelism < minArgon2Parallelism || export.KdfParallelism > maxArgon2Parallelism:
+			return nil, fmt.Errorf("%w: Argon2id parallelism %d", ErrUnsupportedFormat, export.KdfParallelism)
+		}
+		salt := sha256.Sum256([]byte(export.Salt))
+		master = argon2.IDKey([]byte(password), salt[:], export.KdfIterations, export.KdfMemory*1024, export.KdfParallelism, 32)
+	default:
+		return nil, fmt.Errorf("%w: kdf type %d", ErrUnsupportedFormat, export.KdfType)
+	}
+	key := make([]byte, 64)
+	if _, err := io.ReadFull(hkdf.Expand(sha256.New, master, []byte("enc")), key[:32]); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(hkdf.Expand(sha256.New, master, []byte("mac")), key[32:]); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
@@ -0,0 +1,133 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/floriaanpost/go-bitwarden-client/internal/encstring"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCLIExport(t *testing.T) {
+	t.Run("Should write the export of bw", func(t *testing.T) {
+		cli, calls := fakeBW(t)
+		var buf bytes.Buffer
+
+		err := cli.Export(context.Background(), ExportJSON, &buf)
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"encrypted":false,"folders":[],"items":[]}`, buf.String())
+		assert.Equal(t, []string{"s3ss10n export --format json --raw --nointeraction"}, calls())
+	})
+
+	t.Run("Should pass the password and organization", func(t *testing.T) {
+		cli, calls := fakeBW(t)
+		var buf bytes.Buffer
+
+		err := cli.Export(context.Background(), ExportEncryptedJSON, &buf, WithExportPassword("backup"), WithExportOrganization("org"))
+
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), `"passwordProtected":true`)
+		assert.Equal(t, []string{"s3ss10n export --format encrypted_json --raw --password backup --organizationid org --nointeraction"}, calls())
+	})
+
+	t.Run("Should return the error of a failed export", func(t *testing.T) {
+		cli, _ := fakeBW(t)
+		var buf bytes.Buffer
+
+		err := cli.Export(context.Background(), ExportCSV, &buf)
+
+		assert.ErrorIs(t, err, ErrNotLoggedIn)
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("Should stream the export through the buffer", func(t *testing.T) {
+		cli, calls := fakeBW(t)
+		var w chunkWriter
+
+		err := cli.Export(context.Background(), ExportJSON, &w, WithExportStream(make([]byte, 8)))
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"encrypted":false,"folders":[],"items":[]}`, string(w.data))
+		for _, size := range w.chunks {
+			assert.LessOrEqual(t, size, 8)
+		}
+		assert.Equal(t, []string{"s3ss10n export --format json --raw --nointeraction"}, calls())
+	})
+
+	t.Run("Should refuse unsupported formats and options", func(t *testing.T) {
+		cli, _ := fakeBW(t)
+
+		assert.ErrorIs(t, cli.Export(context.Background(), "xml", &bytes.Buffer{}), ErrUnsupportedFormat)
+		assert.ErrorIs(t, cli.Export(context.Background(), ExportCSV, &bytes.Buffer{}, WithExportPassword("x")), ErrUnsupportedFormat)
+	})
+}
+
+// encryptExport encrypts plain the way bw export --password does.
+func encryptExport(t *testing.T, export passwordProtectedExport, password string, plain string) string {
+	key, err := exportKey(&export, password)
+	assert.NoError(t, err)
+	encrypt := func(plain string) string {
+		s, err := encstring.Encrypt(key, []byte(plain))
+		assert.NoError(t, err)
+		return s
+	}
+	export.Encrypted = true
+	export.PasswordProtected = true
+	export.Validation = encrypt("validation")
+	export.Data = encrypt(plain)
+	data, err := json.Marshal(export)
+	assert.NoError(t, err)
+	return string(data)
+}
+
+func TestDecryptExport(t *testing.T) {
+	plain := `{"encrypted":false,"folders":[],"items":[]}`
+
+	t.Run("Should decrypt exports with either kdf", func(t *testing.T) {
+		for _, export := range []passwordProtectedExport{
+			{Salt: "c2FsdA==", KdfType: kdfPBKDF2, KdfIterations: 5000},
+			{Salt: "c2FsdA==", KdfType: kdfArgon2id, KdfIterations: 1, KdfMemory: 64, KdfParallelism: 1},
+		} {
+			encrypted := encryptExport(t, export, "backup", plain)
+
+			data, err := DecryptExport(strings.NewReader(encrypted), "backup")
+
+			assert.NoError(t, err)
+			assert.Equal(t, plain, string(data))
+		}
+	})
+
+	t.Run("Should return ErrWrongPassword for another password", func(t *testing.T) {
+		encrypted := encryptExport(t, passwordProtectedExport{Salt: "c2FsdA==", KdfIterations: 5000}, "backup", plain)
+
+		_, err := DecryptExport(strings.NewReader(encrypted), "guess")
+
+		assert.ErrorIs(t, err, ErrWrongPassword)
+	})
+
+	t.Run("Should refuse kdf settings outside the allowed ranges", func(t *testing.T) {
+		for _, settings := range []string{
+			`"kdfType":0,"kdfIterations":4294967295`,
+			`"kdfType":1,"kdfIterations":4294967295,"kdfMemory":64,"kdfParallelism":1`,
+			`"kdfType":1,"kdfIterations":3,"kdfMemory":4194304,"kdfParallelism":1`,
+			`"kdfType":1,"kdfIterations":3,"kdfMemory":64,"kdfParallelism":0`,
+		} {
+			start := time.Now()
+			_, err := DecryptExport(strings.NewReader(`{"encrypted":true,"passwordProtected":true,"salt":"c2FsdA==",`+settings+`}`), "backup")
+
+			assert.ErrorIs(t, err, ErrUnsupportedFormat, settings)
+			assert.Less(t, time.Since(start), time.Second, settings)
+		}
+	})
+
+	t.Run("Should refuse exports encrypted with the account key", func(t *testing.T) {
+		_, err := DecryptExport(strings.NewReader(`{"encrypted":true,"passwordProtected":false}`), "backup")
+
+		assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	})
+}
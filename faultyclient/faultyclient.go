@@ -0,0 +1,198 @@
+// Package faultyclient provides an http.RoundTripper that injects faults
+// into the requests of another transport, to test how code that fetches
+// secrets copes with a misbehaving bw serve:
+//
+//	t := faultyclient.New(http.DefaultTransport, faultyclient.Config{
+//		ErrorRate: 0.1,
+//		Latency:   faultyclient.Uniform(10*time.Millisecond, 500*time.Millisecond),
+//	})
+//	bw := bitwarden.New(bitwarden.WithHTTPClient(t.Client()))
+package faultyclient
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var ErrInjected = errors.New("injected fault")
+
+// Distribution draws a latency.
+type Distribution func(r *rand.Rand) time.Duration
+
+// Fixed always returns d.
+func Fixed(d time.Duration) Distribution {
+	return func(*rand.Rand) time.Duration { return d }
+}
+
+// Uniform returns latencies evenly spread between min and max.
+func Uniform(min, max time.Duration) Distribution {
+	return func(r *rand.Rand) time.Duration {
+		if max <= min {
+			return min
+		}
+		return min + time.Duration(r.Int63n(int64(max-min)))
+	}
+}
+
+// Exponential returns latencies with the given mean, which are mostly short
+// with the occasional long one.
+func Exponential(mean time.Duration) Distribution {
+	return func(r *rand.Rand) time.Duration {
+		return time.Duration(r.ExpFloat64() * float64(mean))
+	}
+}
+
+// Config sets which faults are injected. Rates are probabilities between 0
+// and 1 and are drawn for every request.
+type Config struct {
+	// ErrorRate is the rate of requests that fail with ErrInjected without
+	// being sent.
+	ErrorRate float64
+	// Latency delays every request. Nil means no delay.
+	Latency Distribution
+	// TruncateRate is the rate of responses whose body ends halfway with
+	// io.ErrUnexpectedEOF.
+	TruncateRate float64
+	// BurstRate is the rate of requests that start a burst of BurstLength
+	// responses with status BurstStatus, which are not sent either.
+	BurstRate float64
+	// BurstLength defaults to 5.
+	BurstLength int
+	// BurstStatus defaults to 503 Service Unavailable.
+	BurstStatus int
+	// Seed makes the faults reproducible. Zero uses a random seed.
+	Seed int64
+}
+
+// Transport injects faults into the requests of Base.
+type Transport struct {
+	base http.RoundTripper
+	cfg  Config
+
+	mu    sync.Mutex
+	rand  *rand.Rand
+	burst int
+}
+
+// New returns a transport that sends requests with base, or
+// http.DefaultTransport if nil.
+func New(base http.RoundTripper, cfg Config) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if cfg.BurstLength <= 0 {
+		cfg.BurstLength = 5
+	}
+	if cfg.BurstStatus == 0 {
+		cfg.BurstStatus = http.StatusServiceUnavailable
+	}
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &Transport{base: base, cfg: cfg, rand: rand.New(rand.NewSource(seed))}
+}
+
+// Client returns an http.Client using the transport.
+func (t *Transport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+type faults struct {
+	latency  time.Duration
+	err      bool
+	status   int
+	truncate bool
+}
+
+func (t *Transport) draw() faults {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var f faults
+	if t.cfg.Latency != nil {
+		f.latency = t.cfg.Latency(t.rand)
+	}
+	if t.burst == 0 && t.rand.Float64() < t.cfg.BurstRate {
+		t.burst = t.cfg.BurstLength
+	}
+	if t.burst > 0 {
+		t.burst--
+		f.status = t.cfg.BurstStatus
+		return f
+	}
+	f.err = t.rand.Float64() < t.cfg.ErrorRate
+	f.truncate = t.rand.Float64() < t.cfg.TruncateRate
+	return f
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	f := t.draw()
+
+	if f.latency > 0 {
+		timer := time.NewTimer(f.latency)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			closeRequest(req)
+			return nil, req.Context().Err()
+		}
+	}
+	if f.err {
+		closeRequest(req)
+		return nil, ErrInjected
+	}
+	if f.status != 0 {
+		closeRequest(req)
+		return &http.Response{
+			Status:     http.StatusText(f.status),
+			StatusCode: f.status,
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     http.Header{},
+			Body:       http.NoBody,
+			Request:    req,
+		}, nil
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || !f.truncate {
+		return resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = &truncatedBody{r: bytes.NewReader(body[:len(body)/2])}
+	return resp, nil
+}
+
+func closeRequest(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
+
+// truncatedBody fails with io.ErrUnexpectedEOF once r is drained, like a
+// connection that was cut.
+type truncatedBody struct {
+	r io.Reader
+}
+
+func (b *truncatedBody) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+func (b *truncatedBody) Close() error { return nil }
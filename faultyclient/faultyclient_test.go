@@ -0,0 +1,130 @@
+package faultyclient
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+	"github.com/floriaanpost/go-bitwarden-client/bitwardentest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"success":true,"data":{"object":"message"}}`)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestTransport(t *testing.T) {
+	t.Run("Should pass requests through without faults", func(t *testing.T) {
+		server := newTestServer(t)
+		client := New(nil, Config{}).Client()
+
+		resp, err := client.Get(server.URL)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("Should fail requests", func(t *testing.T) {
+		server := newTestServer(t)
+		client := New(nil, Config{ErrorRate: 1}).Client()
+
+		_, err := client.Get(server.URL)
+
+		assert.ErrorIs(t, err, ErrInjected)
+	})
+
+	t.Run("Should answer bursts of server errors", func(t *testing.T) {
+		server := newTestServer(t)
+		tr := New(nil, Config{BurstRate: 1, BurstLength: 3, BurstStatus: http.StatusBadGateway})
+
+		for i := 0; i < 3; i++ {
+			resp, err := tr.Client().Get(server.URL)
+			assert.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+		}
+	})
+
+	t.Run("Should truncate bodies", func(t *testing.T) {
+		server := newTestServer(t)
+		client := New(nil, Config{TruncateRate: 1}).Client()
+
+		resp, err := client.Get(server.URL)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		assert.Equal(t, `{"success":true,"data"`, string(body))
+	})
+
+	t.Run("Should delay requests until the context is done", func(t *testing.T) {
+		server := newTestServer(t)
+		client := New(nil, Config{Latency: Fixed(time.Second)}).Client()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		_, err := client.Do(req)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("Should inject the same faults for the same seed", func(t *testing.T) {
+		server := newTestServer(t)
+		run := func() []bool {
+			client := New(nil, Config{ErrorRate: 0.5, Seed: 42}).Client()
+			var failed []bool
+			for i := 0; i < 20; i++ {
+				resp, err := client.Get(server.URL)
+				if err == nil {
+					resp.Body.Close()
+				}
+				failed = append(failed, err != nil)
+			}
+			return failed
+		}
+
+		assert.Equal(t, run(), run())
+	})
+
+	t.Run("Should work with the vault client", func(t *testing.T) {
+		srv := bitwardentest.NewServer(bitwardentest.WithItems(bitwarden.Item{ID: "db"}))
+		defer srv.Close()
+		bw := srv.Client(bitwarden.WithHTTPClient(New(nil, Config{BurstRate: 1}).Client()))
+
+		_, err := bw.GetItem(context.Background(), "db")
+
+		assert.ErrorIs(t, err, bitwarden.ErrUnexpectedStatusCode)
+	})
+}
+
+func TestDistributions(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	t.Run("Should stay within the uniform bounds", func(t *testing.T) {
+		d := Uniform(10*time.Millisecond, 20*time.Millisecond)
+		for i := 0; i < 100; i++ {
+			l := d(r)
+			assert.GreaterOrEqual(t, l, 10*time.Millisecond)
+			assert.Less(t, l, 20*time.Millisecond)
+		}
+	})
+
+	t.Run("Should never return negative exponential latencies", func(t *testing.T) {
+		d := Exponential(time.Millisecond)
+		for i := 0; i < 100; i++ {
+			assert.GreaterOrEqual(t, d(r), time.Duration(0))
+		}
+	})
+}
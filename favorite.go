@@ -0,0 +1,23 @@
+package bitwarden
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// SetFavorite marks or unmarks the item as favorite, which makes the
+// Bitwarden clients show it at the top of the vault.
+func (b *BitwardenServer) SetFavorite(ctx context.Context, id string, fav bool) error {
+	ctx, span := b.startSpan(ctx, "SetFavorite", attribute.String("bitwarden.item_id", id), attribute.Bool("bitwarden.favorite", fav))
+	item, err := b.fetchItem(ctx, id)
+	if err != nil {
+		return endSpan(span, err)
+	}
+	if item.Favorite == fav {
+		return endSpan(span, nil)
+	}
+	item.Favorite = fav
+	_, err = b.EditItem(ctx, item)
+	return endSpan(span, err)
+}
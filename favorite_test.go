@@ -0,0 +1,50 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSetFavorite(t *testing.T) {
+	itemID := "382a9d7b-f6b5-4eaa-92a1-1f3c7d89e48f"
+
+	t.Run("Should mark the item as favorite", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))).
+			Return(itemResponse(itemID), nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequestJSON(http.MethodPut, "http://localhost/object/item/"+itemID, func(body map[string]any) bool {
+				return body["favorite"] == true && body["name"] == "ENV"
+			}))).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"data":{"id":"` + itemID + `","favorite":true}}`))}, nil).
+			Once()
+
+		err := bw.SetFavorite(context.Background(), itemID, true)
+
+		assert.NoError(t, err)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("Should not edit an item that is already in the wanted state", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))).
+			Return(itemResponse(itemID), nil).
+			Once()
+
+		err := bw.SetFavorite(context.Background(), itemID, false)
+
+		assert.NoError(t, err)
+		client.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,85 @@
+package bitwarden
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// SetField sets the custom field with the given name, adding it if the item
+// has none, and reports whether the item changed. Like the Bitwarden clients,
+// the previous value of a hidden field is kept in the password history. The
+// item is only changed locally; store it with EditItem.
+func (item *Item) SetField(name, value string, typ FieldType) bool {
+	return item.setField(name, value, typ, time.Now())
+}
+
+// setField is SetField with the time of the change.
+func (item *Item) setField(name, value string, typ FieldType, now time.Time) bool {
+	for i := range item.Fields {
+		f := &item.Fields[i]
+		if f.Name != name {
+			continue
+		}
+		if f.Value == value && f.Type == typ {
+			return false
+		}
+		if f.Type == FieldHidden && f.Value != "" && f.Value != value {
+			entry := PasswordHistory{LastUsedDate: now.UTC(), Password: name + ": " + f.Value}
+			item.PasswordHistory = append([]PasswordHistory{entry}, item.PasswordHistory...)
+		}
+		f.Value, f.Type = value, typ
+		return true
+	}
+	item.Fields = append(item.Fields, Field{Name: name, Value: value, Type: typ})
+	return true
+}
+
+// DeleteField removes the custom fields with the given name and reports
+// whether there were any. The item is only changed locally; store it with
+// EditItem.
+func (item *Item) DeleteField(name string) bool {
+	kept := item.Fields[:0]
+	for _, f := range item.Fields {
+		if f.Name != name {
+			kept = append(kept, f)
+		}
+	}
+	deleted := len(kept) != len(item.Fields)
+	clear(item.Fields[len(kept):])
+	item.Fields = kept
+	return deleted
+}
+
+// SetField sets a custom field of the item, such as an owner or a rotation
+// date, and stores the item with everything else unchanged. Nothing is
+// stored if the field already has the value.
+func (b *BitwardenServer) SetField(ctx context.Context, itemID, name, value string, typ FieldType) error {
+	ctx, span := b.startSpan(ctx, "SetField", attribute.String("bitwarden.item_id", itemID), attribute.String("bitwarden.field", name))
+	item, err := b.fetchItem(ctx, itemID)
+	if err != nil {
+		return endSpan(span, err)
+	}
+	if !item.setField(name, value, typ, b.clock.Now()) {
+		return endSpan(span, nil)
+	}
+	_, err = b.EditItem(ctx, item)
+	return endSpan(span, err)
+}
+
+// DeleteField removes a custom field from the item and stores the item with
+// everything else unchanged. Nothing is stored if the item has no such
+// field.
+func (b *BitwardenServer) DeleteField(ctx context.Context, itemID, name string) error {
+	ctx, span := b.startSpan(ctx, "DeleteField", attribute.String("bitwarden.item_id", itemID), attribute.String("bitwarden.field", name))
+	item, err := b.fetchItem(ctx, itemID)
+	if err != nil {
+		return endSpan(span, err)
+	}
+	if !item.DeleteField(name) {
+		return endSpan(span, nil)
+	}
+	_, err = b.EditItem(ctx, item)
+	return endSpan(span, err)
+}
@@ -0,0 +1,139 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestItemSetField(t *testing.T) {
+	t.Run("Should add missing fields and update existing ones", func(t *testing.T) {
+		item := &Item{Fields: []Field{{Name: "owner", Value: "ops", Type: FieldText}}}
+
+		assert.True(t, item.SetField("rotated", "2024-06-01", FieldText))
+		assert.True(t, item.SetField("owner", "platform", FieldText))
+		assert.False(t, item.SetField("owner", "platform", FieldText))
+
+		assert.Equal(t, []Field{{Name: "owner", Value: "platform"}, {Name: "rotated", Value: "2024-06-01"}}, item.Fields)
+		assert.Empty(t, item.PasswordHistory)
+	})
+
+	t.Run("Should keep the previous value of hidden fields in the history", func(t *testing.T) {
+		item := &Item{Fields: []Field{{Name: "token", Value: "old", Type: FieldHidden}}}
+
+		assert.True(t, item.SetField("token", "new", FieldHidden))
+
+		if assert.Len(t, item.PasswordHistory, 1) {
+			assert.Equal(t, "token: old", item.PasswordHistory[0].Password)
+		}
+	})
+
+	t.Run("Should delete every field with the name", func(t *testing.T) {
+		item := &Item{Fields: []Field{{Name: "a"}, {Name: "b"}, {Name: "a"}}}
+
+		assert.True(t, item.DeleteField("a"))
+		assert.False(t, item.DeleteField("a"))
+
+		assert.Equal(t, []Field{{Name: "b"}}, item.Fields)
+	})
+}
+
+func TestSetField(t *testing.T) {
+	itemID := "382a9d7b-f6b5-4eaa-92a1-1f3c7d89e48f"
+	itemRequest := mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))
+	withField := func() *http.Response {
+		respData := `{"data":{"id":"` + itemID + `","type":2,"name":"ENV","notes":"A=B","secureNote":{"type":0},"fields":[{"name":"owner","value":"ops","type":0}]}}`
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(respData))}
+	}
+	edited := func() *http.Response {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"data":{"id":"` + itemID + `"}}`))}
+	}
+
+	t.Run("Should store the field with the rest of the item", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.On("Do", itemRequest).Return(withField(), nil).Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequestJSON(http.MethodPut, "http://localhost/object/item/"+itemID, func(body map[string]any) bool {
+				fields, _ := body["fields"].([]any)
+				return body["name"] == "ENV" && body["notes"] == "A=B" && len(fields) == 2
+			}))).
+			Return(edited(), nil).
+			Once()
+
+		err := bw.SetField(context.Background(), itemID, "rotated", "2024-06-01", FieldText)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should date the history entry of a hidden field with the clock", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithClock(newFakeClock()))
+
+		client.
+			On("Do", itemRequest).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"data":{"id":"` + itemID + `","type":2,"name":"ENV","secureNote":{"type":0},"fields":[{"name":"token","value":"old","type":1}]}}`))}, nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequestJSON(http.MethodPut, "http://localhost/object/item/"+itemID, func(body map[string]any) bool {
+				history, _ := body["passwordHistory"].([]any)
+				if len(history) != 1 {
+					return false
+				}
+				entry, _ := history[0].(map[string]any)
+				return entry["password"] == "token: old" && entry["lastUsedDate"] == "2024-01-01T00:00:00Z"
+			}))).
+			Return(edited(), nil).
+			Once()
+
+		err := bw.SetField(context.Background(), itemID, "token", "new", FieldHidden)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should not store an unchanged field", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.On("Do", itemRequest).Return(withField(), nil).Once()
+
+		err := bw.SetField(context.Background(), itemID, "owner", "ops", FieldText)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should delete the field", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.On("Do", itemRequest).Return(withField(), nil).Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequestJSON(http.MethodPut, "http://localhost/object/item/"+itemID, func(body map[string]any) bool {
+				fields, _ := body["fields"].([]any)
+				return body["notes"] == "A=B" && len(fields) == 0
+			}))).
+			Return(edited(), nil).
+			Once()
+
+		err := bw.DeleteField(context.Background(), itemID, "owner")
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should not store an item without the field", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.On("Do", itemRequest).Return(withField(), nil).Once()
+
+		err := bw.DeleteField(context.Background(), itemID, "rotated")
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,204 @@
+// Package fixtures holds bw serve responses for several CLI versions and a
+// transport that replays them, for offline contract tests:
+//
+//	rec, err := fixtures.Load("2024.6.0")
+//	...
+//	bw := bitwarden.NewFromURL(fixtures.URL, bitwarden.WithHTTPClient(rec.Client()))
+//	item, err := bw.GetItem(ctx, fixtures.LoginItemID)
+//
+// The shipped responses follow the format of the listed CLI versions,
+// including fields this module does not decode, but are written by hand with
+// made up data. Every version has the items below; the SSH key item only
+// exists from 2025.2.0 on and is not found in older versions, which did not
+// support SSH keys.
+//
+// Recorder captures real exchanges in the same format, to add a CLI version
+// or to check the shipped responses against one:
+//
+//	rec := fixtures.NewRecorder("2025.6.0", nil)
+//	bw := bitwarden.NewFromURL(fixtures.URL, bitwarden.WithHTTPClient(&http.Client{Transport: rec}))
+//	// make the requests ...
+//	err := rec.Recording().Save("data/2025.6.0.json")
+//
+// Record against a throwaway vault holding the items above only: responses
+// are stored as they are, secrets and session keys included.
+package fixtures
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// URL is the server URL the requests are made to.
+	URL = "http://localhost:8087"
+
+	LoginItemID      = "1d4cf845-8012-4b2d-a924-f9d8c9b7c44a"
+	SecureNoteItemID = "382a9d7b-f6b5-4eaa-92a1-1f3c7d89e48f"
+	CardItemID       = "7a5f4c2e-3b1d-4e8f-9a6c-0d2e1f3b4c5d"
+	IdentityItemID   = "9c8b7a6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"
+	SSHKeyItemID     = "4e3d2c1b-0a9f-4e8d-b7c6-5a4b3c2d1e0f"
+	MissingItemID    = "00000000-0000-4000-8000-000000000000"
+
+	// FolderID is the folder of the login item.
+	FolderID = "e1d2c3b4-a596-4877-8695-a4b3c2d1e0f9"
+	// AttachmentID is the attachment of the login item.
+	AttachmentID = "b9f0c1d2e3"
+)
+
+var ErrNoFixture = errors.New("no recorded response")
+
+//go:embed data/*.json
+var data embed.FS
+
+// Exchange is a single recorded request and its response.
+type Exchange struct {
+	Method string `json:"method"`
+	// Path is the request URI, including the query.
+	Path   string          `json:"path"`
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body"`
+	// RawBody is used instead of Body for responses that are not JSON.
+	RawBody string `json:"rawBody,omitempty"`
+}
+
+// Recording holds the exchanges recorded for a CLI version.
+type Recording struct {
+	CLIVersion string     `json:"cliVersion"`
+	Exchanges  []Exchange `json:"exchanges"`
+}
+
+// Versions returns the CLI versions with a recording, oldest first.
+func Versions() []string {
+	entries, _ := data.ReadDir("data")
+	versions := make([]string, 0, len(entries))
+	for _, e := range entries {
+		versions = append(versions, strings.TrimSuffix(e.Name(), ".json"))
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// Load returns the recording for a CLI version.
+func Load(version string) (*Recording, error) {
+	b, err := data.ReadFile("data/" + version + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("%w for cli version %s", ErrNoFixture, version)
+	}
+	var r Recording
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// Client returns an http.Client that replays the recording.
+func (r *Recording) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip answers a request with the recorded response for the same method
+// and request URI, or fails with ErrNoFixture.
+func (r *Recording) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	for _, e := range r.Exchanges {
+		if e.Method != req.Method || e.Path != req.URL.RequestURI() {
+			continue
+		}
+		body, contentType := []byte(e.RawBody), "application/octet-stream"
+		if e.RawBody == "" {
+			body, contentType = e.Body, "application/json; charset=utf-8"
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", e.Status, http.StatusText(e.Status)),
+			StatusCode:    e.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": {contentType}},
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s (cli version %s)", ErrNoFixture, req.Method, req.URL.RequestURI(), r.CLIVersion)
+}
+
+// Save writes the recording to path in the format Load reads.
+func (r *Recording) Save(path string) error {
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(b, '\n'), 0o644)
+}
+
+// Recorder is an http.RoundTripper that sends requests on to a real bw
+// serve and records every exchange.
+type Recorder struct {
+	transport http.RoundTripper
+
+	mu  sync.Mutex
+	rec Recording
+}
+
+// NewRecorder returns a recorder for a CLI version. A nil transport uses
+// http.DefaultTransport.
+func NewRecorder(cliVersion string, transport http.RoundTripper) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Recorder{transport: transport, rec: Recording{CLIVersion: cliVersion}}
+}
+
+// RoundTrip sends the request and records the response. A request that is
+// made again replaces the earlier exchange, since replaying only finds the
+// first one.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	e := Exchange{Method: req.Method, Path: req.URL.RequestURI(), Status: resp.StatusCode}
+	if json.Valid(body) {
+		e.Body = json.RawMessage(body)
+	} else {
+		e.RawBody = string(body)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.rec.Exchanges {
+		if r.rec.Exchanges[i].Method == e.Method && r.rec.Exchanges[i].Path == e.Path {
+			r.rec.Exchanges[i] = e
+			return resp, nil
+		}
+	}
+	r.rec.Exchanges = append(r.rec.Exchanges, e)
+	return resp, nil
+}
+
+// Recording returns a copy of the exchanges recorded so far.
+func (r *Recorder) Recording() *Recording {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &Recording{CLIVersion: r.rec.CLIVersion, Exchanges: append([]Exchange(nil), r.rec.Exchanges...)}
+}
@@ -0,0 +1,99 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersions(t *testing.T) {
+	t.Run("Should list the recorded versions oldest first", func(t *testing.T) {
+		assert.Equal(t, []string{"2023.1.0", "2024.6.0", "2025.2.0"}, Versions())
+	})
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("Should fail for unknown versions", func(t *testing.T) {
+		_, err := Load("1.0.0")
+
+		assert.ErrorIs(t, err, ErrNoFixture)
+	})
+}
+
+func TestRoundTrip(t *testing.T) {
+	rec, err := Load("2024.6.0")
+	assert.NoError(t, err)
+	client := rec.Client()
+
+	t.Run("Should replay the recorded response", func(t *testing.T) {
+		resp, err := client.Get(URL + "/object/item/" + MissingItemID)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		assert.JSONEq(t, `{"success":false,"message":"Not found."}`, string(body))
+	})
+
+	t.Run("Should replay raw bodies", func(t *testing.T) {
+		resp, err := client.Get(URL + "/object/attachment/" + AttachmentID + "?itemid=" + LoginItemID)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+
+		assert.Equal(t, "1. 4f8a-2b9c\n2. 7d1e-0c3f\n", string(body))
+	})
+
+	t.Run("Should fail for requests that were not recorded", func(t *testing.T) {
+		_, err := client.Get(URL + "/list/object/folders")
+
+		assert.ErrorIs(t, err, ErrNoFixture)
+	})
+}
+
+func TestRecorder(t *testing.T) {
+	replay, err := Load("2024.6.0")
+	assert.NoError(t, err)
+	rec := NewRecorder("2024.6.0", replay)
+	client := &http.Client{Transport: rec}
+
+	for _, path := range []string{
+		"/object/item/" + MissingItemID,
+		"/object/attachment/" + AttachmentID + "?itemid=" + LoginItemID,
+		"/object/item/" + MissingItemID,
+	} {
+		resp, err := client.Get(URL + path)
+		assert.NoError(t, err)
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+
+	t.Run("Should record each request once", func(t *testing.T) {
+		got := rec.Recording()
+
+		assert.Equal(t, "2024.6.0", got.CLIVersion)
+		assert.Len(t, got.Exchanges, 2)
+		assert.Equal(t, http.StatusNotFound, got.Exchanges[0].Status)
+		assert.JSONEq(t, `{"success":false,"message":"Not found."}`, string(got.Exchanges[0].Body))
+		assert.Equal(t, "1. 4f8a-2b9c\n2. 7d1e-0c3f\n", got.Exchanges[1].RawBody)
+	})
+
+	t.Run("Should save in the format Load reads", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "2024.6.0.json")
+		assert.NoError(t, rec.Recording().Save(path))
+
+		b, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		var saved Recording
+		assert.NoError(t, json.Unmarshal(b, &saved))
+		resp, err := saved.Client().Get(URL + "/object/attachment/" + AttachmentID + "?itemid=" + LoginItemID)
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "1. 4f8a-2b9c\n2. 7d1e-0c3f\n", string(body))
+	})
+}
@@ -0,0 +1,83 @@
+package bitwarden
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// ServerFlavor is the server implementation behind bw serve.
+type ServerFlavor int
+
+const (
+	Bitwarden ServerFlavor = iota
+	Vaultwarden
+)
+
+var ErrUnsupportedByServer = errors.New("not supported by the server")
+
+// WithServerFlavor adjusts the client to known differences of the server
+// that bw is logged in to. With Vaultwarden, error messages relayed in the
+// shape of Vaultwarden are unpacked and mapped to typed errors, and
+// endpoints that Vaultwarden does not implement fail with
+// ErrUnsupportedByServer without being sent. Defaults to Bitwarden.
+func WithServerFlavor(f ServerFlavor) Option {
+	return func(b *BitwardenServer) { b.flavor = f }
+}
+
+// unsupportedEndpoints lists, per flavor, the path prefixes of endpoints the
+// server does not implement.
+var unsupportedEndpoints = map[ServerFlavor][]string{
+	// Admin approval of trusted devices needs enterprise SSO.
+	Vaultwarden: {"/device-approval"},
+}
+
+// flavorMessageErrors maps messages specific to a flavor to typed errors.
+var flavorMessageErrors = map[ServerFlavor]map[string]error{
+	Vaultwarden: {
+		"Cipher doesn't exist": ErrNotFound,
+		// Sent when sends are disabled in the Vaultwarden config.
+		"Due to an Enterprise Policy, you are only able to delete an existing Send.": ErrUnsupportedByServer,
+	},
+}
+
+func (f ServerFlavor) supports(path string) bool {
+	for _, prefix := range unsupportedEndpoints[f] {
+		if strings.HasPrefix(path, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// adjust unpacks and types the message of e for the flavor.
+func (f ServerFlavor) adjust(e *APIError) {
+	if f != Vaultwarden {
+		return
+	}
+	e.Message = vaultwardenMessage(e.Message)
+	if e.typed == nil {
+		e.typed = flavorMessageErrors[f][e.Message]
+	}
+}
+
+// vaultwardenMessage returns the message of an error body of Vaultwarden,
+// which bw sometimes relays as is, or msg if it is not one.
+func vaultwardenMessage(msg string) string {
+	var body struct {
+		Message          string `json:"message"`
+		ErrorDescription string `json:"error_description"`
+		ErrorModel       struct {
+			Message string `json:"message"`
+		} `json:"errorModel"`
+	}
+	if !strings.HasPrefix(msg, "{") || json.Unmarshal([]byte(msg), &body) != nil {
+		return msg
+	}
+	for _, m := range []string{body.ErrorModel.Message, body.Message, body.ErrorDescription} {
+		if m != "" {
+			return m
+		}
+	}
+	return msg
+}
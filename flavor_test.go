@@ -0,0 +1,76 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestServerFlavor(t *testing.T) {
+	itemID := "382a9d7b-f6b5-4eaa-92a1-1f3c7d89e48f"
+	errorResponse := func(status int, body string) *http.Response {
+		return &http.Response{StatusCode: status, Body: io.NopCloser(bytes.NewBufferString(body))}
+	}
+
+	t.Run("Should unpack relayed Vaultwarden errors", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithServerFlavor(Vaultwarden))
+
+		client.
+			On("Do", mock.Anything).
+			Return(errorResponse(400, `{"success":false,"message":"{\"message\":\"Cipher doesn't exist\",\"errorModel\":{\"message\":\"Cipher doesn't exist\",\"object\":\"error\"},\"object\":\"error\"}"}`), nil).
+			Once()
+
+		_, err := bw.GetItem(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrBadRequest)
+		assert.ErrorIs(t, err, ErrNotFound)
+		var apiErr *APIError
+		if assert.ErrorAs(t, err, &apiErr) {
+			assert.Equal(t, "Cipher doesn't exist", apiErr.Message)
+		}
+	})
+
+	t.Run("Should report features disabled in Vaultwarden as unsupported", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithServerFlavor(Vaultwarden))
+
+		client.
+			On("Do", mock.Anything).
+			Return(errorResponse(400, `{"success":false,"message":"Due to an Enterprise Policy, you are only able to delete an existing Send."}`), nil).
+			Once()
+
+		_, err := Do[any](context.Background(), bw, http.MethodPost, "/object/send", map[string]any{"type": 1})
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrUnsupportedByServer)
+	})
+
+	t.Run("Should not send requests for endpoints Vaultwarden lacks", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithServerFlavor(Vaultwarden))
+
+		_, err := Do[any](context.Background(), bw, http.MethodGet, "/device-approval/org", nil)
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrUnsupportedByServer)
+	})
+
+	t.Run("Should leave errors alone for Bitwarden", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.Anything).
+			Return(errorResponse(400, `{"success":false,"message":"Cipher doesn't exist"}`), nil).
+			Once()
+
+		_, err := bw.GetItem(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrBadRequest)
+		assert.NotErrorIs(t, err, ErrNotFound)
+	})
+}
@@ -0,0 +1,38 @@
+package bitwarden
+
+import (
+	"context"
+	"net/http"
+)
+
+type Folder struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+// ListFolders returns all folders of the vault.
+func (b *BitwardenServer) ListFolders(ctx context.Context) ([]Folder, error) {
+	resp := struct {
+		Data struct {
+			Data []Folder `json:"data"`
+		} `json:"data"`
+	}{}
+	ctx, span := b.startSpan(ctx, "ListFolders")
+	if err := endSpan(span, b.request(ctx, http.MethodGet, "/list/object/folders", nil, &resp)); err != nil {
+		return nil, err
+	}
+	return resp.Data.Data, nil
+}
+
+// CreateFolder creates a folder with the given name and returns it as
+// stored by the server.
+func (b *BitwardenServer) CreateFolder(ctx context.Context, name string) (*Folder, error) {
+	resp := struct {
+		Data Folder `json:"data"`
+	}{}
+	ctx, span := b.startSpan(ctx, "CreateFolder")
+	if err := endSpan(span, b.request(ctx, http.MethodPost, "/object/folder", Folder{Name: name}, &resp)); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
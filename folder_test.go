@@ -0,0 +1,44 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFolders(t *testing.T) {
+	t.Run("Should list folders", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/folders", ``))).
+			Return(listResponse(`{"object":"folder","id":"f1","name":"infra"}`), nil).
+			Once()
+
+		folders, err := bw.ListFolders(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Folder{{ID: "f1", Name: "infra"}}, folders)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("Should create a folder", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodPost, "http://localhost/object/folder", `{"name":"infra"}`))).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"success":true,"data":{"object":"folder","id":"f1","name":"infra"}}`))}, nil).
+			Once()
+
+		folder, err := bw.CreateFolder(context.Background(), "infra")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &Folder{ID: "f1", Name: "infra"}, folder)
+		client.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,148 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var ErrInvalidFolderPath = errors.New("invalid folder path")
+
+// FolderNode is a level of the folder hierarchy.
+type FolderNode struct {
+	// Folder is nil for the root and for levels that only exist because a
+	// nested folder names them, such as "team" if there is a folder
+	// "team/prod" but no folder "team".
+	Folder *Folder
+	// Path is the full name of the folder, such as "team/prod". It is empty
+	// for the root.
+	Path string
+	// Name is the last level of the path, such as "prod".
+	Name string
+
+	Parent   *FolderNode
+	Children []*FolderNode // sorted by name
+}
+
+// FolderTree is the folder hierarchy, which Bitwarden encodes in folder names
+// separated by slashes.
+type FolderTree struct {
+	Root   *FolderNode
+	byPath map[string]*FolderNode
+}
+
+// NewFolderTree builds the hierarchy of the folders.
+func NewFolderTree(folders []Folder) *FolderTree {
+	t := &FolderTree{Root: &FolderNode{}, byPath: map[string]*FolderNode{}}
+	for i := range folders {
+		segments, err := splitFolderPath(folders[i].Name)
+		if err != nil {
+			continue // not a path, such as "a//b"; it cannot be nested
+		}
+		t.node(segments).Folder = &folders[i]
+	}
+	t.sort(t.Root)
+	return t
+}
+
+// Find returns the node with the given path.
+func (t *FolderTree) Find(path string) (*FolderNode, bool) {
+	segments, err := splitFolderPath(path)
+	if err != nil {
+		return nil, false
+	}
+	n, ok := t.byPath[strings.Join(segments, "/")]
+	return n, ok
+}
+
+// node returns the node of the path, adding it and its missing parents.
+func (t *FolderTree) node(segments []string) *FolderNode {
+	n := t.Root
+	for i := range segments {
+		path := strings.Join(segments[:i+1], "/")
+		child, ok := t.byPath[path]
+		if !ok {
+			child = &FolderNode{Path: path, Name: segments[i], Parent: n}
+			n.Children = append(n.Children, child)
+			t.byPath[path] = child
+		}
+		n = child
+	}
+	return n
+}
+
+func (t *FolderTree) sort(n *FolderNode) {
+	sort.Slice(n.Children, func(i, j int) bool { return n.Children[i].Name < n.Children[j].Name })
+	for _, c := range n.Children {
+		t.sort(c)
+	}
+}
+
+// splitFolderPath returns the levels of the path, ignoring a leading or
+// trailing slash.
+func splitFolderPath(path string) ([]string, error) {
+	trimmed := strings.Trim(path, "/")
+	if trimmed == "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidFolderPath, path)
+	}
+	segments := strings.Split(trimmed, "/")
+	for _, s := range segments {
+		if strings.TrimSpace(s) == "" {
+			return nil, fmt.Errorf("%w: %q has an empty level", ErrInvalidFolderPath, path)
+		}
+	}
+	return segments, nil
+}
+
+// GetFolderTree returns the folder hierarchy of the vault.
+func (b *BitwardenServer) GetFolderTree(ctx context.Context) (*FolderTree, error) {
+	folders, err := b.ListFolders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewFolderTree(folders), nil
+}
+
+// EnsureFolderPath returns the folder with the given path, such as
+// "team/prod". If there is none, it is created after any missing parent
+// folders, so the Bitwarden clients show it nested.
+func (b *BitwardenServer) EnsureFolderPath(ctx context.Context, path string) (*Folder, error) {
+	segments, err := splitFolderPath(path)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := b.GetFolderTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	n := tree.node(segments)
+	if n.Folder != nil {
+		return n.Folder, nil
+	}
+	var missing []*FolderNode
+	for p := n; p != tree.Root; p = p.Parent {
+		if p.Folder == nil {
+			missing = append(missing, p)
+		}
+	}
+	for i := len(missing) - 1; i >= 0; i-- { // parents first
+		folder, err := b.CreateFolder(ctx, missing[i].Path)
+		if err != nil {
+			return nil, err
+		}
+		missing[i].Folder = folder
+	}
+	return n.Folder, nil
+}
+
+// MoveItemsToFolderPath is like MoveItemsToFolder, but takes the path of the
+// folder, which is created with EnsureFolderPath if it does not exist.
+func (b *BitwardenServer) MoveItemsToFolderPath(ctx context.Context, ids []string, path string) error {
+	folder, err := b.EnsureFolderPath(ctx, path)
+	if err != nil {
+		return err
+	}
+	return b.MoveItemsToFolder(ctx, ids, folder.ID)
+}
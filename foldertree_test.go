@@ -0,0 +1,115 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFolderTree(t *testing.T) {
+	t.Run("Should nest folders by path", func(t *testing.T) {
+		tree := NewFolderTree([]Folder{
+			{ID: "1", Name: "team/prod"},
+			{ID: "2", Name: "team"},
+			{ID: "3", Name: "infra/db/primary"},
+			{ID: "4", Name: "team/dev"},
+		})
+
+		assert.Len(t, tree.Root.Children, 2)
+		infra := tree.Root.Children[0]
+		assert.Equal(t, "infra", infra.Path)
+		assert.Nil(t, infra.Folder)
+		team, ok := tree.Find("/team/")
+		assert.True(t, ok)
+		assert.Equal(t, "2", team.Folder.ID)
+		assert.Equal(t, []string{"dev", "prod"}, []string{team.Children[0].Name, team.Children[1].Name})
+		primary, ok := tree.Find("infra/db/primary")
+		assert.True(t, ok)
+		assert.Equal(t, "3", primary.Folder.ID)
+		assert.Equal(t, "infra/db", primary.Parent.Path)
+		_, ok = tree.Find("team/staging")
+		assert.False(t, ok)
+	})
+}
+
+func TestEnsureFolderPath(t *testing.T) {
+	folderResponse := func(id, name string) *http.Response {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"success":true,"data":{"object":"folder","id":"` + id + `","name":"` + name + `"}}`))}
+	}
+
+	t.Run("Should create the missing levels, parents first", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/folders", ``))).
+			Return(listResponse(`{"id":"1","name":"team"}`), nil).
+			Once()
+		created := client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodPost, "http://localhost/object/folder", `{"name":"team/prod"}`))).
+			Return(folderResponse("2", "team/prod"), nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodPost, "http://localhost/object/folder", `{"name":"team/prod/db"}`))).
+			Return(folderResponse("3", "team/prod/db"), nil).
+			Once().
+			NotBefore(created)
+
+		folder, err := bw.EnsureFolderPath(context.Background(), "team/prod/db")
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, &Folder{ID: "3", Name: "team/prod/db"}, folder)
+	})
+
+	t.Run("Should return an existing folder", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/folders", ``))).
+			Return(listResponse(`{"id":"2","name":"team/prod"}`), nil).
+			Once()
+
+		folder, err := bw.EnsureFolderPath(context.Background(), "team/prod")
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "2", folder.ID)
+	})
+
+	t.Run("Should refuse paths with empty levels", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		_, err := bw.EnsureFolderPath(context.Background(), "team//prod")
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrInvalidFolderPath)
+	})
+}
+
+func TestMoveItemsToFolderPath(t *testing.T) {
+	t.Run("Should move the items to the folder of the path", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/folders", ``))).
+			Return(listResponse(`{"id":"6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a","name":"team/prod"}`), nil).
+			Once()
+		client.On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/1", ``))).Return(itemResponse("1"), nil).Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequestJSON(http.MethodPut, "http://localhost/object/item/1", func(body map[string]any) bool {
+				return body["folderId"] == "6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a"
+			}))).
+			Return(itemResponse("1"), nil).
+			Once()
+
+		err := bw.MoveItemsToFolderPath(context.Background(), []string{"1"}, "team/prod")
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,224 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	fsNotesFile      = "notes"
+	fsAttachmentsDir = "attachments"
+)
+
+// FS returns a read-only file system view of the vault. Every item is a
+// directory named after its ID, holding the item notes as a file named
+// "notes" and its attachments in a directory named "attachments":
+//
+//	<item id>/notes
+//	<item id>/attachments/<file name>
+//
+// All requests are made with ctx. Attachments are read into memory when
+// opened so the files support seeking, as http.FileServer requires.
+func (b *BitwardenServer) FS(ctx context.Context) fs.FS {
+	return &vaultFS{ctx: ctx, bw: b}
+}
+
+type vaultFS struct {
+	ctx context.Context
+	bw  *BitwardenServer
+}
+
+var (
+	_ fs.ReadDirFS  = (*vaultFS)(nil)
+	_ fs.ReadFileFS = (*vaultFS)(nil)
+)
+
+func (v *vaultFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	f, err := v.open(name)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			err = fs.ErrNotExist
+		}
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return f, nil
+}
+
+func (v *vaultFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	f, err := v.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	d, ok := f.(*vaultDir)
+	if !ok {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+	return d.ReadDir(-1)
+}
+
+func (v *vaultFS) ReadFile(name string) ([]byte, error) {
+	f, err := v.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
+
+func (v *vaultFS) open(name string) (fs.File, error) {
+	if name == "." {
+		return v.openRoot()
+	}
+
+	parts := strings.Split(name, "/")
+	item, err := v.bw.GetItem(v.ctx, parts[0])
+	if err != nil {
+		return nil, err
+	}
+	modTime := itemModTime(item)
+
+	switch {
+	case len(parts) == 1:
+		var entries []fs.DirEntry
+		if item.Notes != nil {
+			entries = append(entries, fs.FileInfoToDirEntry(fileInfo{name: fsNotesFile, size: int64(len(*item.Notes)), modTime: modTime}))
+		}
+		if len(item.Attachments) > 0 {
+			entries = append(entries, fs.FileInfoToDirEntry(dirInfo(fsAttachmentsDir, modTime)))
+		}
+		return newVaultDir(dirInfo(item.ID, modTime), entries), nil
+	case len(parts) == 2 && parts[1] == fsNotesFile && item.Notes != nil:
+		return newVaultFile(fsNotesFile, []byte(*item.Notes), modTime), nil
+	case len(parts) == 2 && parts[1] == fsAttachmentsDir && len(item.Attachments) > 0:
+		entries := make([]fs.DirEntry, 0, len(item.Attachments))
+		for _, a := range item.Attachments {
+			entries = append(entries, fs.FileInfoToDirEntry(fileInfo{name: a.FileName, size: attachmentSize(a), modTime: modTime}))
+		}
+		return newVaultDir(dirInfo(fsAttachmentsDir, modTime), entries), nil
+	case len(parts) == 3 && parts[1] == fsAttachmentsDir:
+		for _, a := range item.Attachments {
+			if a.FileName == parts[2] {
+				return v.openAttachment(item.ID, a, modTime)
+			}
+		}
+	}
+	return nil, fs.ErrNotExist
+}
+
+func (v *vaultFS) openRoot() (fs.File, error) {
+	items, err := v.bw.ListItems(v.ctx)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]fs.DirEntry, 0, len(items))
+	for i := range items {
+		entries = append(entries, fs.FileInfoToDirEntry(dirInfo(items[i].ID, itemModTime(&items[i]))))
+	}
+	return newVaultDir(dirInfo(".", time.Time{}), entries), nil
+}
+
+func (v *vaultFS) openAttachment(itemID string, a Attachment, modTime time.Time) (fs.File, error) {
+	r, err := v.bw.DownloadAttachment(v.ctx, itemID, a.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return newVaultFile(a.FileName, data, modTime), nil
+}
+
+func itemModTime(i *Item) time.Time {
+	if i.RevisionDate != nil {
+		return *i.RevisionDate
+	}
+	return i.CreationDate
+}
+
+func attachmentSize(a Attachment) int64 {
+	n, _ := strconv.ParseInt(a.Size, 10, 64)
+	return n
+}
+
+type fileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+	dir     bool
+}
+
+func dirInfo(name string, modTime time.Time) fileInfo {
+	return fileInfo{name: name, modTime: modTime, dir: true}
+}
+
+func (i fileInfo) Name() string       { return i.name }
+func (i fileInfo) Size() int64        { return i.size }
+func (i fileInfo) ModTime() time.Time { return i.modTime }
+func (i fileInfo) IsDir() bool        { return i.dir }
+func (i fileInfo) Sys() any           { return nil }
+
+func (i fileInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0o500
+	}
+	return 0o400
+}
+
+type vaultFile struct {
+	*bytes.Reader
+	info fileInfo
+}
+
+func newVaultFile(name string, data []byte, modTime time.Time) *vaultFile {
+	return &vaultFile{Reader: bytes.NewReader(data), info: fileInfo{name: name, size: int64(len(data)), modTime: modTime}}
+}
+
+func (f *vaultFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *vaultFile) Close() error               { return nil }
+
+type vaultDir struct {
+	info    fileInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func newVaultDir(info fileInfo, entries []fs.DirEntry) *vaultDir {
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return &vaultDir{info: info, entries: entries}
+}
+
+func (d *vaultDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *vaultDir) Close() error               { return nil }
+
+func (d *vaultDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: errors.New("is a directory")}
+}
+
+func (d *vaultDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	remaining := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return remaining, nil
+	}
+	if len(remaining) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(remaining) {
+		n = len(remaining)
+	}
+	d.offset += n
+	return remaining[:n], nil
+}
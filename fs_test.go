@@ -0,0 +1,88 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/fs"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestFS() (fs.FS, *Mockclient) {
+	bw, client := newTestBitwarden()
+
+	items := map[string]string{
+		"a": `{"id":"a","type":2,"revisionDate":"2023-05-06T07:08:09Z","notes":"KEY=VALUE"}`,
+		"b": `{"id":"b","type":1,"revisionDate":"2023-05-06T07:08:09Z","login":{},"attachments":[{"id":"att1","fileName":"tls.crt","size":"4","sizeName":"4 Bytes"}]}`,
+	}
+	client.
+		On("Do", mock.Anything).
+		Return(func(req *http.Request) (*http.Response, error) {
+			respond := func(body string) (*http.Response, error) {
+				return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(body))}, nil
+			}
+			switch path := req.URL.Path; {
+			case path == "/list/object/items":
+				return respond(`{"data":{"data":[` + items["a"] + `,` + items["b"] + `]}}`)
+			case strings.HasPrefix(path, "/object/item/"):
+				if item, ok := items[strings.TrimPrefix(path, "/object/item/")]; ok {
+					return respond(`{"data":` + item + `}`)
+				}
+			case path == "/object/attachment/att1" && req.URL.Query().Get("itemid") == "b":
+				return respond("cert")
+			}
+			return &http.Response{StatusCode: 404}, nil
+		})
+
+	return bw.FS(context.Background()), client
+}
+
+func TestFS(t *testing.T) {
+	t.Run("Should pass the fstest checks", func(t *testing.T) {
+		fsys, _ := newTestFS()
+
+		assert.NoError(t, fstest.TestFS(fsys, "a/notes", "b/attachments/tls.crt"))
+	})
+
+	t.Run("Should read notes and attachments", func(t *testing.T) {
+		fsys, _ := newTestFS()
+
+		notes, err := fs.ReadFile(fsys, "a/notes")
+		assert.NoError(t, err)
+		assert.Equal(t, "KEY=VALUE", string(notes))
+
+		cert, err := fs.ReadFile(fsys, "b/attachments/tls.crt")
+		assert.NoError(t, err)
+		assert.Equal(t, "cert", string(cert))
+	})
+
+	t.Run("Should list items as directories", func(t *testing.T) {
+		fsys, _ := newTestFS()
+
+		var paths []string
+		err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+			paths = append(paths, path)
+			return err
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{".", "a", "a/notes", "b", "b/attachments", "b/attachments/tls.crt"}, paths)
+	})
+
+	t.Run("Should report missing files", func(t *testing.T) {
+		fsys, _ := newTestFS()
+
+		for _, name := range []string{"c", "a/attachments", "b/notes", "b/attachments/other", "a/notes/x"} {
+			_, err := fsys.Open(name)
+			assert.ErrorIs(t, err, fs.ErrNotExist, name)
+		}
+		_, err := fsys.Open("/a")
+		assert.ErrorIs(t, err, fs.ErrInvalid)
+	})
+}
@@ -0,0 +1,95 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+var ErrTooWeak = errors.New("generated password is below the minimum strength")
+
+// generateAttempts is how often Generate asks for a new password before it
+// gives up on reaching MinStrength.
+const generateAttempts = 10
+
+// GenerateOptions configures a generated password or passphrase. The zero
+// value uses the defaults of bw: a password of 14 upper and lower case
+// letters and numbers.
+type GenerateOptions struct {
+	Length    int
+	Uppercase bool
+	Lowercase bool
+	Numbers   bool
+	Special   bool
+
+	// Passphrase generates words instead of characters.
+	Passphrase    bool
+	Words         int
+	Separator     string
+	Capitalize    bool
+	IncludeNumber bool
+
+	// MinStrength makes Generate ask again until ScorePassword scores the
+	// result at least this strong, for policies such as "all machine
+	// credentials are strong". Options that cannot reach it fail with
+	// ErrTooWeak.
+	MinStrength Strength
+}
+
+func (o GenerateOptions) query() url.Values {
+	q := url.Values{}
+	flags := map[string]bool{
+		"uppercase":     o.Uppercase,
+		"lowercase":     o.Lowercase,
+		"number":        o.Numbers,
+		"special":       o.Special,
+		"passphrase":    o.Passphrase,
+		"capitalize":    o.Capitalize,
+		"includeNumber": o.IncludeNumber,
+	}
+	for flag, set := range flags {
+		if set {
+			q.Set(flag, "")
+		}
+	}
+	if o.Length > 0 {
+		q.Set("length", strconv.Itoa(o.Length))
+	}
+	if o.Words > 0 {
+		q.Set("words", strconv.Itoa(o.Words))
+	}
+	if o.Separator != "" {
+		q.Set("separator", o.Separator)
+	}
+	return q
+}
+
+// Generate returns a new password or passphrase.
+func (b *BitwardenServer) Generate(ctx context.Context, opts GenerateOptions) (string, error) {
+	for attempt := 0; attempt < generateAttempts; attempt++ {
+		password, err := b.generate(ctx, opts)
+		if err != nil || ScorePassword(password) >= opts.MinStrength {
+			return password, err
+		}
+	}
+	return "", fmt.Errorf("%w: %s after %d attempts", ErrTooWeak, opts.MinStrength, generateAttempts)
+}
+
+func (b *BitwardenServer) generate(ctx context.Context, opts GenerateOptions) (string, error) {
+	endpoint := "/generate"
+	if q := opts.query(); len(q) > 0 {
+		endpoint += "?" + q.Encode()
+	}
+	resp := struct {
+		Data struct {
+			Data string `json:"data"`
+		} `json:"data"`
+	}{}
+	if err := b.request(ctx, http.MethodGet, endpoint, nil, &resp); err != nil {
+		return "", err
+	}
+	return resp.Data.Data, nil
+}
@@ -0,0 +1,80 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGenerate(t *testing.T) {
+	generated := func() *http.Response {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"success":true,"data":{"object":"string","data":"s3cr3t"}}`))}
+	}
+
+	t.Run("Should use the defaults of bw for zero options", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/generate", ``))).
+			Return(generated(), nil).
+			Once()
+
+		password, err := bw.Generate(context.Background(), GenerateOptions{})
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "s3cr3t", password)
+	})
+
+	t.Run("Should pass the options", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/generate?length=32&special=&uppercase=", ``))).
+			Return(generated(), nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/generate?capitalize=&passphrase=&separator=-&words=5", ``))).
+			Return(generated(), nil).
+			Once()
+
+		_, err := bw.Generate(context.Background(), GenerateOptions{Length: 32, Uppercase: true, Special: true})
+		assert.NoError(t, err)
+		_, err = bw.Generate(context.Background(), GenerateOptions{Passphrase: true, Words: 5, Separator: "-", Capitalize: true})
+		assert.NoError(t, err)
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("Should generate again until the minimum strength", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+		response := func(password string) *http.Response {
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"success":true,"data":{"object":"string","data":"` + password + `"}}`))}
+		}
+
+		client.On("Do", mock.Anything).Return(response("password"), nil).Once()
+		client.On("Do", mock.Anything).Return(response("hT7$kq9Lz!3vRmP2"), nil).Once()
+
+		password, err := bw.Generate(context.Background(), GenerateOptions{MinStrength: StrengthStrong})
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "hT7$kq9Lz!3vRmP2", password)
+	})
+
+	t.Run("Should give up on options that are too weak", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.On("Do", mock.Anything).Return(func(*http.Request) (*http.Response, error) { return generated(), nil }).Times(generateAttempts)
+
+		_, err := bw.Generate(context.Background(), GenerateOptions{Length: 6, MinStrength: StrengthVeryStrong})
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrTooWeak)
+	})
+}
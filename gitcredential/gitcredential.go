@@ -0,0 +1,165 @@
+// Package gitcredential implements the git credential helper protocol on top
+// of the vault, so git can fetch HTTPS credentials from login items. A helper
+// binary only needs to unlock a client and call Serve:
+//
+//	func main() {
+//		bw := bitwarden.New()
+//		defer bw.Close()
+//		// unlock the vault ...
+//		if err := gitcredential.Serve(ctx, bw, os.Args[1:], os.Stdin, os.Stdout); err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+//
+// and can then be configured with git config credential.helper.
+package gitcredential
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+)
+
+var (
+	ErrUnknownAction = errors.New("unknown credential helper action")
+	ErrInvalidInput  = errors.New("invalid credential helper input")
+)
+
+type Client interface {
+	ListItems(ctx context.Context, opts ...bitwarden.ListOption) ([]bitwarden.Item, error)
+}
+
+// Request holds the attributes git sends to a credential helper.
+type Request struct {
+	Protocol string
+	Host     string
+	Path     string
+	Username string
+	Password string
+}
+
+// URL returns the request as a URL, which is what login URIs are matched
+// against.
+func (r Request) URL() string {
+	u := url.URL{Scheme: r.Protocol, Host: r.Host, Path: r.Path}
+	if u.Path != "" && !strings.HasPrefix(u.Path, "/") {
+		u.Path = "/" + u.Path
+	}
+	return u.String()
+}
+
+// ReadRequest parses the key=value lines git writes to a helper, up to an
+// empty line or the end of input.
+func ReadRequest(r io.Reader) (Request, error) {
+	var req Request
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := s.Text()
+		if line == "" {
+			break
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return req, fmt.Errorf("%w: %q", ErrInvalidInput, line)
+		}
+		switch key {
+		case "protocol":
+			req.Protocol = value
+		case "host":
+			req.Host = value
+		case "path":
+			req.Path = value
+		case "username":
+			req.Username = value
+		case "password":
+			req.Password = value
+		}
+	}
+	return req, s.Err()
+}
+
+// Get finds the login for the request. Logins with a URI for the same
+// protocol and host are considered, and if the request contains a username
+// only logins with that username. It returns bitwarden.ErrNotFound if no
+// login matches.
+func Get(ctx context.Context, c Client, req Request) (*bitwarden.Login, error) {
+	items, err := c.ListItems(ctx, bitwarden.MatchingURL(req.URL()))
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range items {
+		if i.Type != bitwarden.TypeLogin || i.Login == nil || i.Login.Password == nil {
+			continue
+		}
+		if !matchesHost(i.Login, req) {
+			continue
+		}
+		if req.Username != "" && (i.Login.Username == nil || *i.Login.Username != req.Username) {
+			continue
+		}
+		return i.Login, nil
+	}
+	return nil, bitwarden.ErrNotFound
+}
+
+// matchesHost double checks the server side URL matching, which depending on
+// the match settings of the item may be looser than git expects.
+func matchesHost(login *bitwarden.Login, req Request) bool {
+	for _, uri := range login.URIs {
+		if uri.URI == nil {
+			continue
+		}
+		u, err := url.Parse(*uri.URI)
+		if err != nil || u.Host == "" {
+			continue
+		}
+		if strings.EqualFold(u.Host, req.Host) && (u.Scheme == "" || u.Scheme == req.Protocol) {
+			return true
+		}
+	}
+	return false
+}
+
+// Serve runs the helper action given in args (get, store or erase) reading
+// the request from in and writing the answer to out. Storing and erasing are
+// accepted and ignored, credentials are managed in the vault. When no login
+// matches, nothing is written so git falls through to its next helper.
+func Serve(ctx context.Context, c Client, args []string, in io.Reader, out io.Writer) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%w: expected exactly one argument", ErrUnknownAction)
+	}
+	req, err := ReadRequest(in)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "get":
+		login, err := Get(ctx, c, req)
+		if errors.Is(err, bitwarden.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return writeCredential(out, req, login)
+	case "store", "erase":
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrUnknownAction, args[0])
+}
+
+func writeCredential(w io.Writer, req Request, login *bitwarden.Login) error {
+	username := req.Username
+	if login.Username != nil {
+		username = *login.Username
+	}
+	_, err := fmt.Fprintf(w, "protocol=%s\nhost=%s\nusername=%s\npassword=%s\n", req.Protocol, req.Host, username, *login.Password)
+	return err
+}
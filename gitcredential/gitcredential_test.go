@@ -0,0 +1,97 @@
+package gitcredential
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Client = (*bitwarden.BitwardenServer)(nil)
+
+type fakeClient struct {
+	items []bitwarden.Item
+}
+
+func (f *fakeClient) ListItems(context.Context, ...bitwarden.ListOption) ([]bitwarden.Item, error) {
+	return f.items, nil
+}
+
+func ptr(s string) *string { return &s }
+
+func login(uri, username, password string) bitwarden.Item {
+	return bitwarden.Item{Type: bitwarden.TypeLogin, Login: &bitwarden.Login{
+		URIs:     []bitwarden.URI{{URI: ptr(uri)}},
+		Username: ptr(username),
+		Password: ptr(password),
+	}}
+}
+
+func TestReadRequest(t *testing.T) {
+	t.Run("Should parse attributes up to the empty line", func(t *testing.T) {
+		req, err := ReadRequest(strings.NewReader("protocol=https\nhost=git.example.com\npath=team/repo.git\nwwwauth[]=Basic\n\nignored=1\n"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, Request{Protocol: "https", Host: "git.example.com", Path: "team/repo.git"}, req)
+		assert.Equal(t, "https://git.example.com/team/repo.git", req.URL())
+	})
+
+	t.Run("Should reject lines without a value", func(t *testing.T) {
+		_, err := ReadRequest(strings.NewReader("protocol\n"))
+
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+}
+
+func TestServe(t *testing.T) {
+	c := &fakeClient{items: []bitwarden.Item{
+		login("https://other.example.com", "other", "nope"),
+		login("https://git.example.com", "ci", "ci-token"),
+		login("https://git.example.com/login", "alice", "alice-token"),
+	}}
+
+	t.Run("Should answer get with the matching login", func(t *testing.T) {
+		var out bytes.Buffer
+		err := Serve(context.Background(), c, []string{"get"}, strings.NewReader("protocol=https\nhost=git.example.com\n\n"), &out)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "protocol=https\nhost=git.example.com\nusername=ci\npassword=ci-token\n", out.String())
+	})
+
+	t.Run("Should honor the requested username", func(t *testing.T) {
+		var out bytes.Buffer
+		err := Serve(context.Background(), c, []string{"get"}, strings.NewReader("protocol=https\nhost=git.example.com\nusername=alice\n"), &out)
+
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "password=alice-token\n")
+	})
+
+	t.Run("Should write nothing when no login matches", func(t *testing.T) {
+		var out bytes.Buffer
+		err := Serve(context.Background(), c, []string{"get"}, strings.NewReader("protocol=http\nhost=git.example.com\n"), &out)
+
+		assert.NoError(t, err)
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("Should ignore store and erase", func(t *testing.T) {
+		for _, action := range []string{"store", "erase"} {
+			var out bytes.Buffer
+			err := Serve(context.Background(), c, []string{action}, strings.NewReader("protocol=https\nhost=git.example.com\nusername=ci\npassword=x\n"), &out)
+
+			assert.NoError(t, err)
+			assert.Empty(t, out.String())
+		}
+	})
+
+	t.Run("Should reject unknown actions", func(t *testing.T) {
+		err := Serve(context.Background(), c, []string{"list"}, strings.NewReader(""), &bytes.Buffer{})
+		assert.ErrorIs(t, err, ErrUnknownAction)
+
+		err = Serve(context.Background(), c, nil, strings.NewReader(""), &bytes.Buffer{})
+		assert.ErrorIs(t, err, ErrUnknownAction)
+	})
+}
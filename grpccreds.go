@@ -0,0 +1,109 @@
+package bitwarden
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const defaultPerRPCMaxAge = 5 * time.Minute
+
+// PerRPCCredentials sends the token stored in a vault item as bearer token
+// with every gRPC call. It implements credentials.PerRPCCredentials from
+// google.golang.org/grpc and can be passed to grpc.WithPerRPCCredentials.
+//
+// The token is the custom field named "token", or the login password if the
+// item has no such field. It is cached for MaxAge and picked up immediately
+// when a running Watch sees the item change, so rotated tokens are used
+// without restarting the client.
+type PerRPCCredentials struct {
+	// MaxAge is how long the token is used before it is fetched again.
+	// Defaults to five minutes.
+	MaxAge time.Duration
+	// AllowInsecure allows the token to be sent over connections without
+	// transport security.
+	AllowInsecure bool
+
+	bw          *BitwardenServer
+	itemID      string
+	unsubscribe func()
+
+	mu      sync.Mutex
+	token   string
+	fetched time.Time
+	gen     uint64 // incremented when the token is replaced or invalidated
+}
+
+// NewPerRPCCredentials returns gRPC credentials for the token in the item.
+// Call Close to stop following changes of the item.
+func NewPerRPCCredentials(bw *BitwardenServer, itemID string) *PerRPCCredentials {
+	c := &PerRPCCredentials{bw: bw, itemID: itemID, MaxAge: defaultPerRPCMaxAge}
+	c.unsubscribe = bw.OnItemChange(itemID, func(item *Item) {
+		token, err := bearerToken(item)
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.gen++
+		if err != nil {
+			c.token = ""
+			return
+		}
+		c.token, c.fetched = token, c.bw.clock.Now()
+	})
+	return c
+}
+
+// GetRequestMetadata returns the authorization header for a call. The item
+// is read like GetItem, subject to WithAccessPolicy, but never from the item
+// cache.
+func (c *PerRPCCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	c.mu.Lock()
+	token, gen := c.token, c.gen
+	if token != "" && c.bw.clock.Now().Sub(c.fetched) <= c.MaxAge {
+		c.mu.Unlock()
+		return map[string]string{"authorization": "Bearer " + token}, nil
+	}
+	c.mu.Unlock()
+
+	item, err := c.bw.freshItem(ctx, c.itemID)
+	if err != nil {
+		return nil, err
+	}
+	token, err = bearerToken(item)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	if c.gen == gen { // else a newer token came in during the fetch
+		c.token, c.fetched = token, c.bw.clock.Now()
+	}
+	c.mu.Unlock()
+	return map[string]string{"authorization": "Bearer " + token}, nil
+}
+
+// RequireTransportSecurity reports whether the token may only be sent over
+// secure connections, which is the case unless AllowInsecure is set.
+func (c *PerRPCCredentials) RequireTransportSecurity() bool {
+	return !c.AllowInsecure
+}
+
+// Invalidate forces the token to be fetched again on the next call, for
+// example after a call failed with codes.Unauthenticated.
+func (c *PerRPCCredentials) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	c.token = ""
+}
+
+// Close stops following changes of the item.
+func (c *PerRPCCredentials) Close() {
+	c.unsubscribe()
+}
+
+// bearerToken returns the custom field named "token", or else the password.
+func bearerToken(item *Item) (string, error) {
+	if token, err := item.Value("token"); err == nil {
+		return token, nil
+	}
+	return item.Value("password")
+}
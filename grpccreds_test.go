@@ -0,0 +1,124 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPerRPCCredentials(t *testing.T) {
+	itemID := "5b0a3c38-6d86-4bb5-a5b3-2a0f3b1e9d17"
+	itemRequest := mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))
+	tokenResponse := func(token string) *http.Response {
+		respData := `{"data":{"id":"` + itemID + `","type":1,"login":{"password":"pw"},"fields":[{"name":"token","value":"` + token + `","type":1}]}}`
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(respData))}
+	}
+
+	t.Run("Should send the token and reuse it", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", itemRequest).
+			Return(tokenResponse("t0k3n"), nil).
+			Once()
+
+		creds := NewPerRPCCredentials(bw, itemID)
+		defer creds.Close()
+		for i := 0; i < 2; i++ {
+			md, err := creds.GetRequestMetadata(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, map[string]string{"authorization": "Bearer t0k3n"}, md)
+		}
+
+		client.AssertExpectations(t)
+		assert.True(t, creds.RequireTransportSecurity())
+	})
+
+	t.Run("Should fetch the token again when it expires or is invalidated", func(t *testing.T) {
+		clock := newFakeClock()
+		bw, client := newTestBitwarden(WithClock(clock))
+
+		client.
+			On("Do", itemRequest).
+			Return(tokenResponse("old"), nil).
+			Once()
+		client.
+			On("Do", itemRequest).
+			Return(tokenResponse("new"), nil).
+			Once()
+		client.
+			On("Do", itemRequest).
+			Return(tokenResponse("newer"), nil).
+			Once()
+
+		creds := NewPerRPCCredentials(bw, itemID)
+		defer creds.Close()
+		creds.MaxAge = time.Millisecond
+
+		md, err := creds.GetRequestMetadata(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer old", md["authorization"])
+
+		clock.Advance(2 * time.Millisecond)
+		md, err = creds.GetRequestMetadata(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer new", md["authorization"])
+
+		creds.MaxAge = time.Hour
+		creds.Invalidate()
+		md, err = creds.GetRequestMetadata(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer newer", md["authorization"])
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("Should use a rotated token seen by Watch", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		creds := NewPerRPCCredentials(bw, itemID)
+		defer creds.Close()
+		rotated := "rotated"
+		bw.subs.notify(ChangeEvent{Type: ChangeUpdated, ItemID: itemID, Item: &Item{ID: itemID, Fields: []Field{{Name: "token", Value: rotated}}}})
+
+		md, err := creds.GetRequestMetadata(context.Background())
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer rotated", md["authorization"])
+	})
+
+	t.Run("Should return request errors", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", itemRequest).
+			Return(&http.Response{StatusCode: 404}, nil).
+			Once()
+
+		creds := NewPerRPCCredentials(bw, itemID)
+		defer creds.Close()
+		_, err := creds.GetRequestMetadata(context.Background())
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Should apply the access policy", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithAccessPolicy(func(AccessRequest) error { return errors.New("no tokens") }))
+
+		creds := NewPerRPCCredentials(bw, itemID)
+		defer creds.Close()
+		_, err := creds.GetRequestMetadata(context.Background())
+
+		client.AssertNotCalled(t, "Do", mock.Anything)
+		assert.ErrorIs(t, err, ErrAccessDenied)
+	})
+}
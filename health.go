@@ -0,0 +1,123 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+var ErrServeNotRunning = errors.New("bw serve is not running")
+
+// VaultStatus is the status of the vault as reported by bw status.
+type VaultStatus struct {
+	ServerURL string     `json:"serverUrl"`
+	LastSync  *time.Time `json:"lastSync"`
+	UserEmail string     `json:"userEmail"`
+	UserID    string     `json:"userId"`
+	// Status is unlocked, locked or unauthenticated.
+	Status string `json:"status"`
+}
+
+// StatusError is returned when the vault cannot serve secrets because it is
+// locked or nobody is logged in. It matches ErrVaultLocked or ErrNotLoggedIn
+// with errors.Is.
+type StatusError struct {
+	VaultStatus
+	err error
+}
+
+func (e *StatusError) Error() string {
+	account := e.UserEmail
+	if e.ServerURL != "" {
+		account += " on " + e.ServerURL
+	}
+	if e.err == ErrNotLoggedIn {
+		return fmt.Sprintf("%s: run bw login before starting bw serve", e.err)
+	}
+	return fmt.Sprintf("%s (%s): call Unlock or run bw unlock first", e.err, account)
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.err
+}
+
+// Status returns the status of the vault.
+func (b *BitwardenServer) Status(ctx context.Context) (*VaultStatus, error) {
+	resp := struct {
+		Data struct {
+			Template VaultStatus `json:"template"`
+		} `json:"data"`
+	}{}
+	if err := b.request(ctx, http.MethodGet, "/status", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data.Template, nil
+}
+
+// Healthy returns nil if the vault can serve secrets: the bw serve process
+// started by New is still running, the API answers /status and the vault is
+// unlocked. Otherwise it returns ErrServeNotRunning, a StatusError or the
+// error of the request, which makes it suitable for readiness probes.
+func (b *BitwardenServer) Healthy(ctx context.Context) error {
+	if b.exited != nil {
+		select {
+		case <-b.exited:
+			return ErrServeNotRunning
+		default:
+		}
+	}
+
+	status, err := b.Status(ctx)
+	if err != nil {
+		return err
+	}
+	return status.err()
+}
+
+// err returns the StatusError for a vault that cannot serve secrets, or nil.
+func (s *VaultStatus) err() error {
+	switch s.Status {
+	case "unlocked":
+		return nil
+	case "locked":
+		return &StatusError{VaultStatus: *s, err: ErrVaultLocked}
+	case "unauthenticated":
+		return &StatusError{VaultStatus: *s, err: ErrNotLoggedIn}
+	default:
+		return fmt.Errorf("%w: unknown vault status %q", ErrUnexpectedStatusCode, s.Status)
+	}
+}
+
+// noStatusCheck lists the endpoints that work without an unlocked vault.
+var noStatusCheck = map[string]bool{"/status": true, "/unlock": true, "/lock": true, "/generate": true}
+
+// checkStatus checks the status of the vault before the first request that
+// needs it unlocked, so a locked vault or missing login is reported clearly
+// instead of as a bad request. Once the vault was seen unlocked, it does
+// nothing until the vault is locked again. If the status cannot be read,
+// the request is sent anyway and reports its own error. A locked vault is
+// unlocked if there is a PasswordProvider, see WithPasswordProvider.
+func (b *BitwardenServer) checkStatus(ctx context.Context, endpoint string) error {
+	if b.unlocked == nil || b.unlocked.Load() || noStatusCheck[endpoint] {
+		return nil
+	}
+	status, err := b.Status(ctx)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			b.unlocked.Store(true) // a bw serve without /status, don't ask again
+		}
+		return nil
+	}
+	var statusErr *StatusError
+	if err := status.err(); errors.As(err, &statusErr) {
+		if errors.Is(err, ErrVaultLocked) {
+			return b.autoUnlock(ctx, err)
+		}
+		return err
+	}
+	b.unlocked.Store(true)
+	return nil
+}
@@ -0,0 +1,158 @@
+// Package hibp checks passwords against the Have I Been Pwned Pwned
+// Passwords API. It uses the k-anonymity range API: only the first five
+// characters of the SHA-1 hash of a password are sent, and the match is
+// made locally.
+//
+//	c := hibp.New()
+//	findings, err := c.CheckVault(ctx, bw)
+package hibp
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+)
+
+const (
+	defaultURL = "https://api.pwnedpasswords.com"
+	userAgent  = "go-bitwarden-client"
+)
+
+type Lister interface {
+	ListItems(ctx context.Context, opts ...bitwarden.ListOption) ([]bitwarden.Item, error)
+}
+
+type Client struct {
+	url        string
+	httpClient *http.Client
+	padding    bool
+}
+
+// Option configures optional behaviour of a Client.
+type Option func(*Client)
+
+// WithURL sets the URL of the API, for example of a mirror.
+func WithURL(url string) Option {
+	return func(c *Client) { c.url = strings.TrimSuffix(url, "/") }
+}
+
+// WithHTTPClient sets the client used for requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// WithPadding asks the API to pad responses with fake entries, so the size
+// of a response does not reveal the prefix that was requested.
+func WithPadding() Option {
+	return func(c *Client) { c.padding = true }
+}
+
+func New(opts ...Option) *Client {
+	c := &Client{url: defaultURL, httpClient: http.DefaultClient}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Count returns how often password appears in known breaches, or 0 if it
+// does not.
+func (c *Client) Count(ctx context.Context, password string) (int, error) {
+	prefix, suffix := hash(password)
+	counts, err := c.rangeCounts(ctx, prefix)
+	if err != nil {
+		return 0, err
+	}
+	return counts[suffix], nil
+}
+
+// Finding is a login with a password that appears in known breaches.
+type Finding struct {
+	ItemID string
+	Name   string
+	// Count is how often the password appears in breaches.
+	Count int
+}
+
+// CheckVault checks the passwords of all logins returned by l and returns
+// the compromised ones. Every hash prefix is requested once.
+func (c *Client) CheckVault(ctx context.Context, l Lister) ([]Finding, error) {
+	items, err := l.ListItems(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ranges := map[string]map[string]int{}
+	var findings []Finding
+	for _, item := range items {
+		if item.Type != bitwarden.TypeLogin || item.Login == nil || item.Login.Password == nil || *item.Login.Password == "" {
+			continue
+		}
+		prefix, suffix := hash(*item.Login.Password)
+		counts, ok := ranges[prefix]
+		if !ok {
+			if counts, err = c.rangeCounts(ctx, prefix); err != nil {
+				return nil, err
+			}
+			ranges[prefix] = counts
+		}
+		if n := counts[suffix]; n > 0 {
+			f := Finding{ItemID: item.ID, Count: n}
+			if item.Name != nil {
+				f.Name = *item.Name
+			}
+			findings = append(findings, f)
+		}
+	}
+	return findings, nil
+}
+
+// hash returns the first five and the remaining characters of the upper
+// case SHA-1 hex digest of password.
+func hash(password string) (prefix, suffix string) {
+	sum := sha1.Sum([]byte(password))
+	h := strings.ToUpper(hex.EncodeToString(sum[:]))
+	return h[:5], h[5:]
+}
+
+// rangeCounts returns the breach counts of all hash suffixes with prefix.
+func (c *Client) rangeCounts(ctx context.Context, prefix string) (map[string]int, error) {
+	endpoint := "/range/" + prefix
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	if c.padding {
+		req.Header.Set("Add-Padding", "true")
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, bitwarden.NewAPIError(http.MethodGet, endpoint, resp)
+	}
+
+	counts := map[string]int{}
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		suffix, count, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(count)
+		if err != nil || n == 0 { // padding entries have a count of 0
+			continue
+		}
+		counts[suffix] = n
+	}
+	return counts, scanner.Err()
+}
@@ -0,0 +1,108 @@
+package hibp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+	"github.com/floriaanpost/go-bitwarden-client/bitwardentest"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Lister = (*bitwarden.BitwardenServer)(nil)
+
+func ptr(s string) *string { return &s }
+
+// newTestServer serves the range API for the given breached passwords and
+// records the requested prefixes.
+func newTestServer(t *testing.T, breached map[string]int) (*Client, func() []string) {
+	var (
+		mu       sync.Mutex
+		prefixes []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		prefix := strings.TrimPrefix(r.URL.Path, "/range/")
+		assert.Equal(t, userAgent, r.Header.Get("User-Agent"))
+		mu.Lock()
+		prefixes = append(prefixes, prefix)
+		mu.Unlock()
+
+		fmt.Fprintf(w, "0018A45C4D1DEF81644B54AB7F969B88D65:0\r\n") // padding
+		for password, n := range breached {
+			if p, s := hash(password); p == prefix {
+				fmt.Fprintf(w, "%s:%d\r\n", s, n)
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return New(WithURL(server.URL), WithPadding()), func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return prefixes
+	}
+}
+
+func TestCount(t *testing.T) {
+	t.Run("Should only send the hash prefix", func(t *testing.T) {
+		c, prefixes := newTestServer(t, map[string]int{"password": 10434004})
+
+		n, err := c.Count(context.Background(), "password")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 10434004, n)
+		assert.Equal(t, []string{"5BAA6"}, prefixes())
+	})
+
+	t.Run("Should return 0 for unknown passwords", func(t *testing.T) {
+		c, _ := newTestServer(t, nil)
+
+		n, err := c.Count(context.Background(), "correct horse battery staple")
+
+		assert.NoError(t, err)
+		assert.Zero(t, n)
+	})
+
+	t.Run("Should return errors of the API", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"statusCode": 429, "message": "Rate limit is exceeded."}`))
+		}))
+		defer server.Close()
+
+		_, err := New(WithURL(server.URL)).Count(context.Background(), "password")
+
+		assert.ErrorIs(t, err, bitwarden.ErrUnexpectedStatusCode)
+		var apiErr *bitwarden.APIError
+		if assert.ErrorAs(t, err, &apiErr) {
+			assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+			assert.Equal(t, "/range/5BAA6", apiErr.Endpoint)
+			assert.Equal(t, "Rate limit is exceeded.", apiErr.Message)
+		}
+	})
+}
+
+func TestCheckVault(t *testing.T) {
+	t.Run("Should report compromised logins and request every prefix once", func(t *testing.T) {
+		c, prefixes := newTestServer(t, map[string]int{"password": 3, "123456": 5})
+		srv := bitwardentest.NewServer(bitwardentest.WithItems(
+			bitwarden.Item{ID: "a", Type: bitwarden.TypeLogin, Name: ptr("mail"), Login: &bitwarden.Login{Password: ptr("password")}},
+			bitwarden.Item{ID: "b", Type: bitwarden.TypeLogin, Name: ptr("shop"), Login: &bitwarden.Login{Password: ptr("password")}},
+			bitwarden.Item{ID: "c", Type: bitwarden.TypeLogin, Login: &bitwarden.Login{Password: ptr("correct horse battery staple")}},
+			bitwarden.Item{ID: "d", Type: bitwarden.TypeLogin, Login: &bitwarden.Login{}},
+			bitwarden.Item{ID: "e", Type: bitwarden.TypeSecureNote, Notes: ptr("123456")},
+		))
+		defer srv.Close()
+
+		findings, err := c.CheckVault(context.Background(), srv.Client())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Finding{{ItemID: "a", Name: "mail", Count: 3}, {ItemID: "b", Name: "shop", Count: 3}}, findings)
+		assert.Len(t, prefixes(), 2)
+	})
+}
@@ -0,0 +1,31 @@
+package bitwarden
+
+import (
+	"context"
+	"io"
+	"os"
+)
+
+// Import imports the data read from r into the vault. format is a format
+// known to bw import, such as bitwardenjson, bitwardencsv, lastpasscsv or
+// 1pux; bw import --formats lists them all. bw only reads imports from a
+// file, so r is copied to a temporary file that only the current user can
+// read and that is removed afterwards. Like Export, this is only available
+// through the CLI.
+func (c *CLI) Import(ctx context.Context, format string, r io.Reader) error {
+	f, err := os.CreateTemp("", "bw-import-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	_, err = io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	_, err = c.output(ctx, "import", format, f.Name())
+	return err
+}
@@ -0,0 +1,38 @@
+package bitwarden
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCLIImport(t *testing.T) {
+	t.Run("Should pass the data in a temporary file and remove it", func(t *testing.T) {
+		cli, calls := fakeBW(t)
+		data := "folder,favorite,type,name,notes,fields,reprompt,login_uri,login_username,login_password,login_totp\n,,login,db,,,0,,admin,hunter2,\n"
+
+		err := cli.Import(context.Background(), "bitwardencsv", strings.NewReader(data))
+
+		assert.NoError(t, err)
+		imported, _ := os.ReadFile(filepath.Join(filepath.Dir(cli.path), "imported"))
+		assert.Equal(t, data, string(imported))
+		args := strings.Fields(calls()[0])
+		if assert.Len(t, args, 5) {
+			assert.Equal(t, []string{"s3ss10n", "import", "bitwardencsv"}, args[:3])
+			assert.NoFileExists(t, args[3])
+		}
+	})
+
+	t.Run("Should return the error of bw", func(t *testing.T) {
+		cli, _ := fakeBW(t)
+
+		err := cli.Import(context.Background(), "unknown", strings.NewReader("{}"))
+
+		assert.ErrorIs(t, err, ErrBadRequest)
+		assert.ErrorContains(t, err, "Format is not valid.")
+	})
+}
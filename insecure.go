@@ -0,0 +1,40 @@
+package bitwarden
+
+import (
+	"errors"
+	"net"
+	"net/url"
+)
+
+var ErrInsecureRemote = errors.New("refusing to send secrets over plain http to a remote host")
+
+// WithAllowInsecureRemote allows NewFromURL to use an http:// URL of a host
+// other than the loopback address. Without it, every request to such a URL
+// fails with ErrInsecureRemote before anything is sent, because bw serve
+// has no authentication of its own and the master password would cross the
+// network in cleartext.
+func WithAllowInsecureRemote(allow bool) Option {
+	return func(b *BitwardenServer) { b.allowInsecureRemote = allow }
+}
+
+// checkURL returns ErrInsecureRemote if rawURL is plain http to a host that
+// is not the loopback address.
+func checkURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "http" {
+		return nil // other schemes are encrypted or fail when used
+	}
+	if isLoopback(u) {
+		return nil
+	}
+	return ErrInsecureRemote
+}
+
+func isLoopback(u *url.URL) bool {
+	host := u.Hostname()
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,62 @@
+package bitwarden
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCheckURL(t *testing.T) {
+	t.Run("Should allow loopback and https URLs", func(t *testing.T) {
+		for _, u := range []string{
+			"http://localhost:8087",
+			"http://127.0.0.1:8087",
+			"http://127.1.2.3",
+			"http://[::1]:8087",
+			"https://vault.example.com",
+		} {
+			assert.NoError(t, checkURL(u), u)
+		}
+	})
+
+	t.Run("Should refuse plain http to remote hosts", func(t *testing.T) {
+		for _, u := range []string{
+			"http://vault.example.com:8087",
+			"http://10.0.0.5:8087",
+			"http://localhost.example.com",
+		} {
+			assert.ErrorIs(t, checkURL(u), ErrInsecureRemote, u)
+		}
+	})
+}
+
+func TestWithAllowInsecureRemote(t *testing.T) {
+	t.Run("Should fail requests to a remote http URL before sending them", func(t *testing.T) {
+		client := &Mockclient{}
+		bw := new(nil, client, "http://vault.example.com:8087")
+
+		err := bw.Unlock(context.Background(), "hunter2")
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrInsecureRemote)
+	})
+
+	t.Run("Should send requests when allowed", func(t *testing.T) {
+		client := &Mockclient{}
+		bw := new(nil, client, "http://vault.example.com:8087", WithAllowInsecureRemote(true))
+		bw.unlocked.Store(true)
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodPost, "http://vault.example.com:8087/sync", `{}`))).
+			Return(&http.Response{StatusCode: 200, Body: http.NoBody}, nil).
+			Once()
+
+		err := bw.Sync(context.Background())
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+}
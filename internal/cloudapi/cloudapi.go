@@ -0,0 +1,53 @@
+// Package cloudapi sends the JSON requests of the clients for the Bitwarden
+// cloud APIs, so bwpublic and secretsmanager report errors the same way.
+package cloudapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+)
+
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Request sends req, if not nil, as JSON to baseURL+endpoint and decodes the
+// response into resp, if not nil. A status other than 200 OK returns a
+// *bitwarden.APIError, which matches bitwarden.ErrNotFound and friends.
+func Request(ctx context.Context, c Doer, baseURL, method, endpoint string, req, resp any) error {
+	var body io.Reader = http.NoBody
+	if req != nil {
+		data, err := json.Marshal(req)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewBuffer(data)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, baseURL+endpoint, body)
+	if err != nil {
+		return err
+	}
+	if req != nil {
+		request.Header.Add("Content-Type", "application/json")
+	}
+
+	r, err := c.Do(request)
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		return bitwarden.NewAPIError(method, endpoint, r)
+	}
+	if resp != nil {
+		return json.NewDecoder(r.Body).Decode(resp)
+	}
+	return nil
+}
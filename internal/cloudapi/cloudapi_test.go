@@ -0,0 +1,49 @@
+package cloudapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequest(t *testing.T) {
+	t.Run("Should send and decode JSON", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/things", r.URL.Path)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.JSONEq(t, `{"name":"a"}`, string(body))
+			io.WriteString(w, `{"id":"1"}`)
+		}))
+		defer server.Close()
+
+		var resp struct {
+			ID string `json:"id"`
+		}
+		err := Request(context.Background(), server.Client(), server.URL, http.MethodPost, "/things", map[string]string{"name": "a"}, &resp)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "1", resp.ID)
+	})
+
+	t.Run("Should return an APIError for other statuses", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message":"Resource not found."}`)
+		}))
+		defer server.Close()
+
+		err := Request(context.Background(), server.Client(), server.URL, http.MethodGet, "/things/2", nil, nil)
+
+		var apiErr *bitwarden.APIError
+		assert.ErrorAs(t, err, &apiErr)
+		assert.ErrorIs(t, err, bitwarden.ErrNotFound)
+		assert.Equal(t, "Resource not found.", apiErr.Message)
+	})
+}
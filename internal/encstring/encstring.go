@@ -0,0 +1,96 @@
+// Package encstring encrypts and decrypts Bitwarden encrypted strings of the
+// form "2.<iv>|<data>|<mac>", AES-256-CBC with HMAC-SHA256, which both
+// password protected exports and Secrets Manager use.
+package encstring
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// TypeAesCbc256HmacSha256 is the only supported encryption type.
+const TypeAesCbc256HmacSha256 = "2"
+
+// KeySize is the size of a key: a 32 byte encryption key followed by a 32
+// byte MAC key.
+const KeySize = 64
+
+var ErrDecrypt = errors.New("decryption failed")
+
+// Decrypt decrypts an encrypted string with key.
+func Decrypt(key []byte, s string) ([]byte, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("%w: key is %d bytes", ErrDecrypt, len(key))
+	}
+	encType, rest, ok := strings.Cut(s, ".")
+	if !ok || encType != TypeAesCbc256HmacSha256 {
+		return nil, fmt.Errorf("%w: unsupported encryption type", ErrDecrypt)
+	}
+	parts := strings.Split(rest, "|")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed encrypted string", ErrDecrypt)
+	}
+	var raw [3][]byte
+	for i, p := range parts {
+		var err error
+		if raw[i], err = base64.StdEncoding.DecodeString(p); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrDecrypt, err)
+		}
+	}
+	iv, data, mac := raw[0], raw[1], raw[2]
+
+	if !hmac.Equal(mac, sign(key, iv, data)) {
+		return nil, fmt.Errorf("%w: mac mismatch", ErrDecrypt)
+	}
+	if len(iv) != aes.BlockSize || len(data) == 0 || len(data)%aes.BlockSize != 0 {
+		return nil, fmt.Errorf("%w: malformed cipher text", ErrDecrypt)
+	}
+	block, err := aes.NewCipher(key[:32])
+	if err != nil {
+		return nil, err
+	}
+	plain := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plain, data)
+
+	pad := int(plain[len(plain)-1])
+	if pad == 0 || pad > aes.BlockSize || !bytes.Equal(plain[len(plain)-pad:], bytes.Repeat([]byte{byte(pad)}, pad)) {
+		return nil, fmt.Errorf("%w: invalid padding", ErrDecrypt)
+	}
+	return plain[:len(plain)-pad], nil
+}
+
+// Encrypt encrypts plain with key and a random IV.
+func Encrypt(key []byte, plain []byte) (string, error) {
+	if len(key) != KeySize {
+		return "", fmt.Errorf("key is %d bytes", len(key))
+	}
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return "", err
+	}
+	block, err := aes.NewCipher(key[:32])
+	if err != nil {
+		return "", err
+	}
+	pad := aes.BlockSize - len(plain)%aes.BlockSize
+	data := append(append([]byte{}, plain...), bytes.Repeat([]byte{byte(pad)}, pad)...)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(data, data)
+
+	enc := base64.StdEncoding
+	return TypeAesCbc256HmacSha256 + "." + enc.EncodeToString(iv) + "|" + enc.EncodeToString(data) + "|" + enc.EncodeToString(sign(key, iv, data)), nil
+}
+
+func sign(key, iv, data []byte) []byte {
+	h := hmac.New(sha256.New, key[32:])
+	h.Write(iv)
+	h.Write(data)
+	return h.Sum(nil)
+}
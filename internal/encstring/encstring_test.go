@@ -0,0 +1,51 @@
+package encstring
+
+import (
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newKey(t *testing.T) []byte {
+	key := make([]byte, KeySize)
+	_, err := rand.Read(key)
+	assert.NoError(t, err)
+	return key
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	t.Run("Should decrypt what it encrypted", func(t *testing.T) {
+		key := newKey(t)
+
+		for _, plain := range []string{"", "hunter2", "exactly 16 bytes"} {
+			s, err := Encrypt(key, []byte(plain))
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(s, "2."))
+			dec, err := Decrypt(key, s)
+			assert.NoError(t, err)
+			assert.Equal(t, plain, string(dec))
+		}
+	})
+
+	t.Run("Should reject data encrypted with another key", func(t *testing.T) {
+		s, err := Encrypt(newKey(t), []byte("hunter2"))
+		assert.NoError(t, err)
+
+		_, err = Decrypt(newKey(t), s)
+
+		assert.ErrorIs(t, err, ErrDecrypt)
+	})
+
+	t.Run("Should reject unsupported and malformed strings and keys", func(t *testing.T) {
+		key := newKey(t)
+
+		for _, s := range []string{"", "0.abc|def", "2.abc", "2.!|!|!"} {
+			_, err := Decrypt(key, s)
+			assert.ErrorIs(t, err, ErrDecrypt, s)
+		}
+		_, err := Decrypt(key[:32], "2.abc|def|ghi")
+		assert.ErrorIs(t, err, ErrDecrypt)
+	})
+}
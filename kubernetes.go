@@ -0,0 +1,68 @@
+package bitwarden
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+var ErrInvalidSecretKey = errors.New("invalid kubernetes secret key")
+
+var kubernetesSecretKey = regexp.MustCompile(`^[-._a-zA-Z0-9]+$`)
+
+// KubernetesSecret is an Opaque Kubernetes Secret. It marshals to the same
+// JSON as a corev1.Secret, so it can be applied with kubectl or converted
+// with a Kubernetes client without this package depending on one.
+type KubernetesSecret struct {
+	APIVersion string             `json:"apiVersion"`
+	Kind       string             `json:"kind"`
+	Metadata   KubernetesMetadata `json:"metadata"`
+	Type       string             `json:"type"`
+	Data       map[string][]byte  `json:"data"`
+}
+
+type KubernetesMetadata struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Manifest returns the secret as a JSON manifest, which kubectl apply accepts
+// like YAML.
+func (s *KubernetesSecret) Manifest() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// ToKubernetesSecret resolves mapping into a Secret with a key for every
+// mapped name.
+func (b *BitwardenServer) ToKubernetesSecret(ctx context.Context, mapping map[string]SecretRef, name, namespace string) (*KubernetesSecret, error) {
+	keys := make([]string, 0, len(mapping))
+	for key := range mapping {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	d := decoder{bw: b, items: map[string]*Item{}}
+	data := make(map[string][]byte, len(mapping))
+	for _, key := range keys {
+		if !kubernetesSecretKey.MatchString(key) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidSecretKey, key)
+		}
+		ref := mapping[key]
+		value, err := d.lookup(ctx, ref)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		data[key] = []byte(value)
+	}
+
+	return &KubernetesSecret{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Metadata:   KubernetesMetadata{Name: name, Namespace: namespace},
+		Type:       "Opaque",
+		Data:       data,
+	}, nil
+}
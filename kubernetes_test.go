@@ -0,0 +1,71 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestToKubernetesSecret(t *testing.T) {
+	itemID := "1d4cf845-8012-4b2d-a924-f9d8c9b7c44a"
+	respData := `{"data":{"id":"` + itemID + `","type":1,"login":{"username":"admin","password":"hunter2"}}}`
+	itemRequest := mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))
+
+	t.Run("Should resolve every key with a single request per item", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", itemRequest).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(respData))}, nil).
+			Once()
+
+		secret, err := bw.ToKubernetesSecret(context.Background(), map[string]SecretRef{
+			"username": {ItemID: itemID, Selector: "username"},
+			"password": {ItemID: itemID, Selector: "password"},
+		}, "db", "prod")
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		manifest, err := secret.Manifest()
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{
+			"apiVersion": "v1",
+			"kind": "Secret",
+			"metadata": {"name": "db", "namespace": "prod"},
+			"type": "Opaque",
+			"data": {"username": "YWRtaW4=", "password": "aHVudGVyMg=="}
+		}`, string(manifest))
+	})
+
+	t.Run("Should reject invalid keys", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		_, err := bw.ToKubernetesSecret(context.Background(), map[string]SecretRef{
+			"db password": {ItemID: itemID, Selector: "password"},
+		}, "db", "")
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrInvalidSecretKey)
+	})
+
+	t.Run("Should return lookup errors", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", itemRequest).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(respData))}, nil).
+			Once()
+
+		_, err := bw.ToKubernetesSecret(context.Background(), map[string]SecretRef{
+			"token": {ItemID: itemID, Selector: "token"},
+		}, "db", "")
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrFieldNotFound)
+	})
+}
@@ -0,0 +1,301 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+)
+
+var ErrInvalidListOptions = errors.New("invalid list options")
+
+type listOptions struct {
+	query url.Values
+	// filters are applied to the results, for filters bw does not support.
+	filters []func(*Item) bool
+	// page is 1-based; perPage is 0 to list everything.
+	page    int
+	perPage int
+}
+
+// ListOption filters the results of a list request.
+type ListOption func(*listOptions)
+
+// InFolder only lists objects in the folder with the given ID. Like the
+// other ID filters it also accepts "null", for objects without a folder, and
+// "notnull", for objects in any folder.
+func InFolder(folderID string) ListOption {
+	return func(o *listOptions) { o.query.Set("folderid", folderID) }
+}
+
+// InCollection only lists objects in the collection with the given ID.
+func InCollection(collectionID string) ListOption {
+	return func(o *listOptions) { o.query.Set("collectionid", collectionID) }
+}
+
+// InOrganization only lists objects owned by the organization with the given ID.
+func InOrganization(organizationID string) ListOption {
+	return func(o *listOptions) { o.query.Set("organizationid", organizationID) }
+}
+
+// Search only lists objects matching the search term.
+func Search(term string) ListOption {
+	return func(o *listOptions) { o.query.Set("search", term) }
+}
+
+// MatchingURL only lists logins with a URI matching u.
+func MatchingURL(u string) ListOption {
+	return func(o *listOptions) { o.query.Set("url", u) }
+}
+
+// InTrash lists deleted objects instead of active ones.
+func InTrash() ListOption {
+	return func(o *listOptions) { o.query.Set("trash", "true") }
+}
+
+// Favorites only lists items marked as favorite. bw cannot filter on this,
+// so the items are filtered after listing.
+func Favorites() ListOption {
+	return func(o *listOptions) {
+		o.filters = append(o.filters, func(item *Item) bool { return item.Favorite })
+	}
+}
+
+// Page only lists the nth page of the results, counting from 1, with PerPage
+// items per page. bw serve has no paging and always returns every object, so
+// the pages are cut by the client: every page asks for the whole list and
+// decodes it from the response as it arrives, up to the end of the page, so
+// only the Items of the page are held in memory. The work per page still
+// grows with its number. With WithCodec or WithDecodeHook the response is
+// read in full before it is decoded. Pages are not a snapshot: when the
+// vault changes between two pages, items shift and may be skipped or listed
+// twice, so list without Page to see every item once.
+func Page(n int) ListOption {
+	return func(o *listOptions) { o.page = n }
+}
+
+// PerPage sets the size of the pages of Page. Without Page, it lists the
+// first page.
+func PerPage(n int) ListOption {
+	return func(o *listOptions) { o.perPage = n }
+}
+
+func newListOptions(opts []ListOption) (listOptions, error) {
+	o := listOptions{query: url.Values{}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o, o.validate()
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// validate checks the filters before they are sent, because bw answers
+// invalid filters with unhelpful errors or an empty list.
+func (o *listOptions) validate() error {
+	for _, key := range []string{"folderid", "collectionid", "organizationid"} {
+		if !o.query.Has(key) {
+			continue
+		}
+		switch id := o.query.Get(key); {
+		case id == "null" || id == "notnull" || uuidPattern.MatchString(id):
+		default:
+			return fmt.Errorf("%w: %s %q is not an ID", ErrInvalidListOptions, key, id)
+		}
+	}
+	if o.query.Has("trash") && o.query.Has("collectionid") {
+		return fmt.Errorf("%w: deleted items are not in collections, so InTrash cannot be combined with InCollection", ErrInvalidListOptions)
+	}
+	switch {
+	case o.page < 0 || o.perPage < 0:
+		return fmt.Errorf("%w: page %d with %d per page", ErrInvalidListOptions, o.page, o.perPage)
+	case o.page > 0 && o.perPage == 0:
+		return fmt.Errorf("%w: Page needs PerPage", ErrInvalidListOptions)
+	}
+	return nil
+}
+
+func (o *listOptions) endpoint(object string) string {
+	endpoint := "/list/object/" + object
+	if len(o.query) > 0 {
+		endpoint += "?" + o.query.Encode()
+	}
+	return endpoint
+}
+
+// filter removes the items rejected by the filters and those outside the
+// page.
+func (o *listOptions) filter(items []Item) []Item {
+	if len(o.filters) == 0 && o.perPage == 0 {
+		return items
+	}
+	kept := items[:0]
+	for i := range items {
+		if o.keep(&items[i]) {
+			kept = append(kept, items[i])
+		}
+	}
+	return o.paginate(kept)
+}
+
+func (o *listOptions) keep(item *Item) bool {
+	for _, keep := range o.filters {
+		if !keep(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// bounds returns the indexes of the first item of the page and the one after
+// the last.
+func (o *listOptions) bounds() (first, end int) {
+	page := max(o.page, 1)
+	return (page - 1) * o.perPage, page * o.perPage
+}
+
+func (o *listOptions) paginate(items []Item) []Item {
+	if o.perPage == 0 {
+		return items
+	}
+	first, end := o.bounds()
+	if first >= len(items) {
+		return []Item{}
+	}
+	return items[first:min(end, len(items))]
+}
+
+// pagedItems decodes a list of items with decodePage, for responses that
+// are read in full because of a codec or decode hook.
+type pagedItems struct {
+	o     *listOptions
+	items []Item
+}
+
+func (p *pagedItems) UnmarshalJSON(data []byte) error {
+	var err error
+	p.items, err = p.o.decodePage(json.NewDecoder(bytes.NewReader(data)))
+	return err
+}
+
+// decodePage decodes a list of items one at a time, keeping only those of
+// the page that pass the filters. Items after the page are not decoded.
+func (o *listOptions) decodePage(dec *json.Decoder) ([]Item, error) {
+	items := []Item{}
+	t, err := dec.Token()
+	if err != nil || t == nil { // null
+		return items, err
+	}
+	if t != json.Delim('[') {
+		return nil, fmt.Errorf("list: expected an array, got %v", t)
+	}
+	first, end := o.bounds()
+	for n := 0; n < end && dec.More(); {
+		var item Item
+		if err := dec.Decode(&item); err != nil {
+			return nil, err
+		}
+		if !o.keep(&item) {
+			continue
+		}
+		if n >= first {
+			items = append(items, item)
+		}
+		n++
+	}
+	return items, nil
+}
+
+// enterField reads dec up to the value of the field name of the object at
+// its position. It returns false if the value is null or has no such field.
+func enterField(dec *json.Decoder, name string) (bool, error) {
+	t, err := dec.Token()
+	if err != nil || t == nil {
+		return false, err
+	}
+	if t != json.Delim('{') {
+		return false, fmt.Errorf("list: expected an object, got %v", t)
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return false, err
+		}
+		if key == name {
+			return true, nil
+		}
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// streamPage lists the page of o, decoding the response as it is read.
+func (b *BitwardenServer) streamPage(ctx context.Context, o *listOptions) ([]Item, error) {
+	r, err := b.do(ctx, http.MethodGet, o.endpoint("items"), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(r)
+
+	dec := json.NewDecoder(r.Body)
+	for i := 0; i < 2; i++ { // {"data":{"data":[...]}}
+		if ok, err := enterField(dec, "data"); err != nil || !ok {
+			return []Item{}, err
+		}
+	}
+	return o.decodePage(dec)
+}
+
+func (b *BitwardenServer) ListItems(ctx context.Context, opts ...ListOption) ([]Item, error) {
+	items, err := b.listItems(ctx, opts...)
+	for i := range items {
+		b.record(ctx, AuditRead, "", &items[i], nil)
+	}
+	return items, err
+}
+
+// listItems lists items without recording audit entries, so that the
+// periodic snapshots of Watch do not flood the audit trail.
+func (b *BitwardenServer) listItems(ctx context.Context, opts ...ListOption) ([]Item, error) {
+	o, err := newListOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	if b.policy != nil {
+		o.filters = append(o.filters, b.allowed)
+	}
+	ctx, span := b.startSpan(ctx, "ListItems")
+	if o.perPage > 0 && b.payloads.streams() {
+		items, err := b.streamPage(ctx, &o)
+		return items, endSpan(span, err)
+	}
+	if o.perPage > 0 {
+		resp := struct {
+			Data struct {
+				Data pagedItems `json:"data"`
+			} `json:"data"`
+		}{}
+		resp.Data.Data.o = &o
+		if err := endSpan(span, b.request(ctx, http.MethodGet, o.endpoint("items"), nil, &resp)); err != nil {
+			return nil, err
+		}
+		return resp.Data.Data.items, nil
+	}
+
+	resp := struct {
+		Data struct {
+			Data []Item `json:"data"`
+		} `json:"data"`
+	}{}
+	if err := endSpan(span, b.request(ctx, http.MethodGet, o.endpoint("items"), nil, &resp)); err != nil {
+		return nil, err
+	}
+	return o.filter(resp.Data.Data), nil
+}
@@ -0,0 +1,192 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func listResponse(items string) *http.Response {
+	respData := []byte(`{"success":true,"data":{"object":"list","data":[` + items + `]}}`)
+	return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBuffer(respData))}
+}
+
+func TestListItems(t *testing.T) {
+	t.Run("Should list all items without filters", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items", ``))).
+			Return(listResponse(`{"id":"a","type":1,"name":"A"},{"id":"b","type":2,"name":"B"}`), nil).
+			Once()
+
+		items, err := bw.ListItems(context.Background())
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Len(t, items, 2)
+		assert.Equal(t, "a", items[0].ID)
+		assert.Equal(t, TypeSecureNote, items[1].Type)
+	})
+
+	t.Run("Should add filters to the query", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items?folderid=6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a&search=db+pass&trash=true", ``))).
+			Return(listResponse(``), nil).
+			Once()
+
+		items, err := bw.ListItems(context.Background(), InFolder("6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a"), Search("db pass"), InTrash())
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Empty(t, items)
+	})
+
+	t.Run("Should filter favorites after listing", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items?folderid=6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a", ``))).
+			Return(listResponse(`{"id":"a","type":1,"name":"A","favorite":true},{"id":"b","type":1,"name":"B"}`), nil).
+			Once()
+
+		items, err := bw.ListItems(context.Background(), InFolder("6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a"), Favorites())
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		if assert.Len(t, items, 1) {
+			assert.Equal(t, "a", items[0].ID)
+		}
+	})
+
+	t.Run("Should return request errors", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 500}, nil).
+			Once()
+
+		_, err := bw.ListItems(context.Background())
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrUnexpectedStatusCode)
+	})
+
+	t.Run("Should accept the null filters of bw", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items?collectionid=notnull&folderid=null", ``))).
+			Return(listResponse(``), nil).
+			Once()
+
+		_, err := bw.ListItems(context.Background(), InFolder("null"), InCollection("notnull"))
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should refuse invalid filters without a request", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		for _, opts := range [][]ListOption{
+			{InFolder("infra")},
+			{InOrganization("")},
+			{InTrash(), InCollection("6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a")},
+			{Page(2)},
+			{Page(-1), PerPage(10)},
+		} {
+			_, err := bw.ListItems(context.Background(), opts...)
+			assert.ErrorIs(t, err, ErrInvalidListOptions)
+		}
+		client.AssertNotCalled(t, "Do", mock.Anything)
+	})
+
+	t.Run("Should cut pages after filtering", func(t *testing.T) {
+		items := `{"id":"a","type":1,"favorite":true},{"id":"b","type":1},{"id":"c","type":1,"favorite":true},` +
+			`{"id":"d","type":1,"favorite":true},{"id":"e","type":1,"favorite":true},{"id":"f","type":1}`
+		for page, expected := range map[int][]string{1: {"a", "c"}, 2: {"d", "e"}, 3: {}} {
+			bw, client := newTestBitwarden()
+
+			client.
+				On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items", ``))).
+				Return(listResponse(items), nil).
+				Once()
+
+			listed, err := bw.ListItems(context.Background(), Favorites(), Page(page), PerPage(2))
+
+			client.AssertExpectations(t)
+			assert.NoError(t, err)
+			ids := []string{}
+			for _, item := range listed {
+				ids = append(ids, item.ID)
+			}
+			assert.Equal(t, expected, ids, "page %d", page)
+		}
+	})
+
+	t.Run("Should list the first page without Page", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.Anything).
+			Return(listResponse(`{"id":"a","type":1},{"id":"b","type":1}`), nil).
+			Once()
+
+		items, err := bw.ListItems(context.Background(), PerPage(1))
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		if assert.Len(t, items, 1) {
+			assert.Equal(t, "a", items[0].ID)
+		}
+	})
+
+	t.Run("Should stop reading the response after the page", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(io.MultiReader(
+				strings.NewReader(`{"success":true,"data":{"object":"list","data":[{"id":"a","type":1},{"id":"b","type":1},`),
+				iotest.ErrReader(errors.New("read past the page")),
+			))}, nil).
+			Once()
+
+		items, err := bw.ListItems(context.Background(), Page(2), PerPage(1))
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		if assert.Len(t, items, 1) {
+			assert.Equal(t, "b", items[0].ID)
+		}
+	})
+
+	t.Run("Should page responses read for a decode hook", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithDecodeHook(func(data []byte) ([]byte, error) { return data, nil }))
+
+		client.
+			On("Do", mock.Anything).
+			Return(listResponse(`{"id":"a","type":1},{"id":"b","type":1}`), nil).
+			Once()
+
+		items, err := bw.ListItems(context.Background(), Page(2), PerPage(1))
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		if assert.Len(t, items, 1) {
+			assert.Equal(t, "b", items[0].ID)
+		}
+	})
+}
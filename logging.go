@@ -0,0 +1,85 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// attemptsKey is the context key of the attempt counter of a request, so
+// that requests sent again by middleware are logged as retries.
+type attemptsKey struct{}
+
+// WithLogger logs every request to bw serve: successful requests at debug
+// level and failed requests at info level, with the method, endpoint,
+// duration, status, number of retries and the caller set with
+// ContextWithCaller. Only the path of the endpoint and the redacted server
+// message are logged, never request bodies or secrets.
+// The logger sees every attempt, so it runs after all middleware.
+func WithLogger(l *slog.Logger) Option {
+	return func(b *BitwardenServer) { b.logger = l }
+}
+
+func logging(l *slog.Logger) Middleware {
+	return func(next RequestFunc) RequestFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			ctx := req.Context()
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("endpoint", req.URL.Path),
+			}
+			if caller := CallerFromContext(ctx); caller != "" {
+				attrs = append(attrs, slog.String("caller", caller))
+			}
+			if attempts, ok := ctx.Value(attemptsKey{}).(*atomic.Int32); ok {
+				attrs = append(attrs, slog.Int("retries", int(attempts.Add(1))-1))
+			}
+
+			start := time.Now()
+			resp, err := next(req)
+			attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+
+			switch {
+			case err != nil:
+				attrs = append(attrs, slog.String("error", err.Error()))
+				l.LogAttrs(ctx, slog.LevelInfo, "bitwarden request failed", attrs...)
+			case resp.StatusCode != http.StatusOK:
+				attrs = append(attrs, slog.Int("status", resp.StatusCode))
+				if message := peekMessage(resp); message != "" {
+					attrs = append(attrs, slog.String("message", message))
+				}
+				l.LogAttrs(ctx, slog.LevelInfo, "bitwarden request failed", attrs...)
+			default:
+				attrs = append(attrs, slog.Int("status", resp.StatusCode))
+				l.LogAttrs(ctx, slog.LevelDebug, "bitwarden request", attrs...)
+			}
+			return resp, err
+		}
+	}
+}
+
+// peekMessage returns the redacted server message of a failed response and
+// leaves the body readable for the caller.
+func peekMessage(resp *http.Response) string {
+	if resp.Body == nil {
+		return ""
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+	return serverMessage(Redact(body))
+}
+
+// withAttempts adds an attempt counter to ctx when requests are logged.
+func (b *BitwardenServer) withAttempts(ctx context.Context) context.Context {
+	if b.logger == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, attemptsKey{}, &atomic.Int32{})
+}
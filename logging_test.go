@@ -0,0 +1,110 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWithLogger(t *testing.T) {
+	newLogger := func() (*slog.Logger, *bytes.Buffer) {
+		var buf bytes.Buffer
+		return slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})), &buf
+	}
+	records := func(buf *bytes.Buffer) []map[string]any {
+		var records []map[string]any
+		d := json.NewDecoder(buf)
+		for d.More() {
+			var record map[string]any
+			d.Decode(&record)
+			records = append(records, record)
+		}
+		return records
+	}
+
+	t.Run("Should log successful requests at debug level without secrets", func(t *testing.T) {
+		logger, buf := newLogger()
+		bw, client := newTestBitwarden(WithLogger(logger))
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{}`))}, nil).
+			Once()
+
+		err := bw.Unlock(context.Background(), "hunter2")
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.NotContains(t, buf.String(), "hunter2")
+		logged := records(buf)
+		if assert.Len(t, logged, 1) {
+			assert.Equal(t, "DEBUG", logged[0]["level"])
+			assert.Equal(t, "POST", logged[0]["method"])
+			assert.Equal(t, "/unlock", logged[0]["endpoint"])
+			assert.Equal(t, float64(200), logged[0]["status"])
+			assert.Equal(t, float64(0), logged[0]["retries"])
+			assert.Contains(t, logged[0], "duration")
+		}
+	})
+
+	t.Run("Should log failed requests at info level and keep the body readable", func(t *testing.T) {
+		logger, buf := newLogger()
+		bw, client := newTestBitwarden(WithLogger(logger))
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 400, Body: io.NopCloser(bytes.NewBufferString(`{"success":false,"message":"Vault is locked."}`))}, nil).
+			Once()
+
+		_, err := bw.GetItem(context.Background(), "1")
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrVaultLocked)
+		logged := records(buf)
+		if assert.Len(t, logged, 1) {
+			assert.Equal(t, "INFO", logged[0]["level"])
+			assert.Equal(t, float64(400), logged[0]["status"])
+			assert.Equal(t, "Vault is locked.", logged[0]["message"])
+		}
+	})
+
+	t.Run("Should count requests sent again by middleware as retries", func(t *testing.T) {
+		logger, buf := newLogger()
+		retry := func(next RequestFunc) RequestFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				resp, err := next(req)
+				if err == nil && resp.StatusCode == http.StatusServiceUnavailable {
+					return next(req)
+				}
+				return resp, err
+			}
+		}
+		bw, client := newTestBitwarden(WithLogger(logger), WithMiddleware(retry))
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 503}, nil).
+			Once()
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{}`))}, nil).
+			Once()
+
+		err := bw.Sync(context.Background())
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		logged := records(buf)
+		if assert.Len(t, logged, 2) {
+			assert.Equal(t, float64(0), logged[0]["retries"])
+			assert.Equal(t, float64(1), logged[1]["retries"])
+		}
+	})
+}
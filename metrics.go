@@ -0,0 +1,69 @@
+package bitwarden
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Metrics receives measurements of a BitwardenServer. Implementations must
+// be safe for concurrent use. See the bwprometheus package for a Prometheus
+// implementation.
+type Metrics interface {
+	// ObserveRequest is called after every request to bw serve. Route is the
+	// endpoint with item IDs replaced by {id}, and err is nil if the request
+	// succeeded.
+	ObserveRequest(method, route string, duration time.Duration, err error)
+	// ObserveCache is called for every lookup in the item cache.
+	ObserveCache(hit bool)
+	// ObserveUnlock is called after every unlock attempt.
+	ObserveUnlock(err error)
+}
+
+// CallerMetrics can be implemented by Metrics to also count requests by the
+// caller set with ContextWithCaller, which should therefore be one of a
+// bounded set of names.
+type CallerMetrics interface {
+	// ObserveCallerRequest is called after ObserveRequest for every request
+	// made with a caller.
+	ObserveCallerRequest(caller, method, route string, err error)
+}
+
+// WithMetrics reports request, cache and unlock measurements to m.
+func WithMetrics(m Metrics) Option {
+	return func(b *BitwardenServer) { b.metrics = m }
+}
+
+// route returns the path of an endpoint without the query and with the
+// object ID replaced by {id}, so it can be used as a metric label.
+func route(endpoint string) string {
+	path, _, _ := strings.Cut(endpoint, "?")
+	if parts := strings.SplitN(path, "/", 4); len(parts) == 4 && parts[1] == "object" {
+		return "/object/" + parts[2] + "/{id}"
+	}
+	return path
+}
+
+func (b *BitwardenServer) observeRequest(ctx context.Context, method, endpoint string, start time.Time, err error) {
+	if b.metrics == nil {
+		return
+	}
+	b.metrics.ObserveRequest(method, route(endpoint), time.Since(start), err)
+	if m, ok := b.metrics.(CallerMetrics); ok {
+		if caller := CallerFromContext(ctx); caller != "" {
+			m.ObserveCallerRequest(caller, method, route(endpoint), err)
+		}
+	}
+}
+
+func (b *BitwardenServer) observeCache(hit bool) {
+	if b.metrics != nil {
+		b.metrics.ObserveCache(hit)
+	}
+}
+
+func (b *BitwardenServer) observeUnlock(err error) {
+	if b.metrics != nil {
+		b.metrics.ObserveUnlock(err)
+	}
+}
@@ -0,0 +1,97 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type observedRequest struct {
+	method, route string
+	err           error
+}
+
+type fakeMetrics struct {
+	mu       sync.Mutex
+	requests []observedRequest
+	cache    []bool
+	unlocks  []error
+}
+
+func (m *fakeMetrics) ObserveRequest(method, route string, _ time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = append(m.requests, observedRequest{method, route, err})
+}
+
+func (m *fakeMetrics) ObserveCache(hit bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cache = append(m.cache, hit)
+}
+
+func (m *fakeMetrics) ObserveUnlock(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.unlocks = append(m.unlocks, err)
+}
+
+func TestWithMetrics(t *testing.T) {
+	itemID := "382a9d7b-f6b5-4eaa-92a1-1f3c7d89e48f"
+
+	t.Run("Should observe requests by route and cache lookups", func(t *testing.T) {
+		metrics := &fakeMetrics{}
+		bw, client := newTestBitwarden(WithMetrics(metrics), WithCache(time.Minute))
+
+		client.
+			On("Do", mock.Anything).
+			Return(itemResponse(itemID), nil).
+			Once()
+
+		for i := 0; i < 2; i++ {
+			_, err := bw.GetItem(context.Background(), itemID)
+			assert.NoError(t, err)
+		}
+
+		client.AssertExpectations(t)
+		assert.Equal(t, []observedRequest{{http.MethodGet, "/object/item/{id}", nil}}, metrics.requests)
+		assert.Equal(t, []bool{false, true}, metrics.cache)
+	})
+
+	t.Run("Should observe failed requests and unlocks", func(t *testing.T) {
+		metrics := &fakeMetrics{}
+		bw, client := newTestBitwarden(WithMetrics(metrics))
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 400, Body: io.NopCloser(bytes.NewBufferString(`{}`))}, nil).
+			Once()
+
+		err := bw.Unlock(context.Background(), "wrong")
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrWrongPassword)
+		if assert.Len(t, metrics.requests, 1) {
+			assert.Equal(t, "/unlock", metrics.requests[0].route)
+			assert.ErrorIs(t, metrics.requests[0].err, ErrBadRequest)
+		}
+		assert.Equal(t, []error{ErrWrongPassword}, metrics.unlocks)
+	})
+}
+
+func TestRoute(t *testing.T) {
+	t.Run("Should replace object IDs and drop the query", func(t *testing.T) {
+		assert.Equal(t, "/object/item/{id}", route("/object/item/1"))
+		assert.Equal(t, "/object/attachment/{id}", route("/object/attachment/a?itemid=1"))
+		assert.Equal(t, "/object/item", route("/object/item"))
+		assert.Equal(t, "/list/object/items", route("/list/object/items?search=x"))
+		assert.Equal(t, "/sync", route("/sync"))
+	})
+}
@@ -0,0 +1,39 @@
+package bitwarden
+
+import "net/http"
+
+// RequestFunc sends a request to bw serve and returns its response.
+type RequestFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps the execution of requests to bw serve. It can inspect or
+// change the request, call next, and inspect or replace the response, for
+// example to add logging, auditing, headers or metrics. Request and response
+// bodies may contain secrets; pass them through Redact before logging.
+type Middleware func(next RequestFunc) RequestFunc
+
+// WithMiddleware adds middleware around every request to bw serve. The first
+// middleware is the outermost: it sees the request first and the response
+// last. Using the option more than once appends to the chain. Requests that
+// are sent again because bw serve closed the connection pass through the
+// middleware again.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(b *BitwardenServer) { b.middleware = append(b.middleware, mw...) }
+}
+
+// chain wraps c in the middleware, so that the first middleware is called
+// first.
+func chain(c client, mw []Middleware) client {
+	if len(mw) == 0 {
+		return c
+	}
+	next := RequestFunc(c.Do)
+	for i := len(mw) - 1; i >= 0; i-- {
+		next = mw[i](next)
+	}
+	return next
+}
+
+// Do makes a RequestFunc usable as the client of a BitwardenServer.
+func (f RequestFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,79 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	t.Run("Should call the middleware in order around the request", func(t *testing.T) {
+		var calls []string
+		trace := func(name string) Middleware {
+			return func(next RequestFunc) RequestFunc {
+				return func(req *http.Request) (*http.Response, error) {
+					calls = append(calls, name+" before")
+					resp, err := next(req)
+					calls = append(calls, name+" after")
+					return resp, err
+				}
+			}
+		}
+		bw, client := newTestBitwarden(WithMiddleware(trace("first"), trace("second")), WithMiddleware(trace("third")))
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodPost, "http://localhost/sync", `{}`))).
+			Run(func(mock.Arguments) { calls = append(calls, "request") }).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{}`))}, nil).
+			Once()
+
+		err := bw.Sync(context.Background())
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"first before", "second before", "third before",
+			"request",
+			"third after", "second after", "first after",
+		}, calls)
+	})
+
+	t.Run("Should let middleware change the request", func(t *testing.T) {
+		header := func(next RequestFunc) RequestFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				req.Header.Set("X-Request-Source", "test")
+				return next(req)
+			}
+		}
+		bw, client := newTestBitwarden(WithMiddleware(header))
+
+		client.
+			On("Do", mock.MatchedBy(func(req *http.Request) bool { return req.Header.Get("X-Request-Source") == "test" })).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{}`))}, nil).
+			Once()
+
+		err := bw.Sync(context.Background())
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should let middleware answer without calling bw serve", func(t *testing.T) {
+		locked := func(RequestFunc) RequestFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: 400, Body: io.NopCloser(bytes.NewBufferString(`{"success":false,"message":"Vault is locked."}`))}, nil
+			}
+		}
+		bw, client := newTestBitwarden(WithMiddleware(locked))
+
+		err := bw.Sync(context.Background())
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrVaultLocked)
+	})
+}
@@ -0,0 +1,24 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ bitwarden.Client = (*MockClient)(nil)
+
+func TestMockClient(t *testing.T) {
+	t.Run("Should stand in for the client", func(t *testing.T) {
+		m := NewMockClient(t)
+		m.EXPECT().GetField(context.Background(), "db", "host").Return("db.internal", nil).Once()
+
+		var c bitwarden.Client = m
+		host, err := c.GetField(context.Background(), "db", "host")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "db.internal", host)
+	})
+}
@@ -0,0 +1,123 @@
+package bitwarden
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// missEntry is an item that was not found, which is not asked for again
+// until until. ttl doubles with every miss after it expired.
+type missEntry struct {
+	until time.Time
+	ttl   time.Duration
+}
+
+// maxMisses is how many misses are remembered, so callers asking for
+// random IDs cannot grow the cache without bound.
+const maxMisses = 10000
+
+// missCache remembers items that were not found, see WithNegativeCache.
+type missCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	max     time.Duration
+	limit   int
+	entries map[string]missEntry
+	clock   Clock
+}
+
+// WithNegativeCache makes GetItem remember items the server did not find,
+// so callers that keep asking for a deleted item get ErrNotFound without
+// hitting bw serve. The first miss is remembered for ttl; every further miss
+// of the same item doubles the time, up to max. An item that stays
+// unrequested for max starts over at ttl. Sync forgets all misses, and
+// fetching, editing or a Watch change of the item forgets its miss. At most
+// 10000 misses are remembered; beyond that the ones that would start over
+// are forgotten, and then the one that expires first.
+func WithNegativeCache(ttl, max time.Duration) Option {
+	return func(b *BitwardenServer) {
+		if max < ttl {
+			max = ttl
+		}
+		b.misses = &missCache{ttl: ttl, max: max, limit: maxMisses, entries: map[string]missEntry{}}
+	}
+}
+
+// get returns ErrNotFound if the item was recently not found.
+func (c *missCache) get(id string) error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[id]
+	if !ok {
+		return nil
+	}
+	now := c.clock.Now()
+	if now.Sub(e.until) > c.max {
+		delete(c.entries, id) // long unrequested, start over
+		return nil
+	}
+	if now.Before(e.until) {
+		return fmt.Errorf("%w: %s (cached miss)", ErrNotFound, id)
+	}
+	return nil
+}
+
+func (c *missCache) put(id string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	ttl := c.ttl
+	if e, ok := c.entries[id]; ok {
+		ttl = min(2*e.ttl, c.max)
+	} else if len(c.entries) >= c.limit {
+		c.evict(now)
+	}
+	c.entries[id] = missEntry{until: now.Add(ttl), ttl: ttl}
+}
+
+// evict forgets the misses that would start over anyway, and if that does
+// not make room, the miss that expires first.
+func (c *missCache) evict(now time.Time) {
+	first := ""
+	for id, e := range c.entries {
+		if now.Sub(e.until) > c.max {
+			delete(c.entries, id)
+			continue
+		}
+		if first == "" || e.until.Before(c.entries[first].until) {
+			first = id
+		}
+	}
+	if len(c.entries) >= c.limit {
+		delete(c.entries, first)
+	}
+}
+
+func (c *missCache) remove(id string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, id)
+}
+
+func (c *missCache) purge() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = map[string]missEntry{}
+}
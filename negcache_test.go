@@ -0,0 +1,137 @@
+package bitwarden
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNegativeCache(t *testing.T) {
+	itemID := "382a9d7b-f6b5-4eaa-92a1-1f3c7d89e48f"
+	itemRequest := mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))
+	notFound := func() *http.Response { return &http.Response{StatusCode: 404} }
+
+	t.Run("Should remember missing items for the ttl", func(t *testing.T) {
+		clock := newFakeClock()
+		bw, client := newTestBitwarden(WithClock(clock), WithNegativeCache(time.Second, time.Minute))
+
+		client.On("Do", itemRequest).Return(notFound(), nil).Once()
+
+		_, err := bw.GetItem(context.Background(), itemID)
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = bw.GetItem(context.Background(), itemID)
+		assert.ErrorIs(t, err, ErrNotFound)
+		client.AssertExpectations(t)
+
+		clock.Advance(time.Second)
+		client.On("Do", itemRequest).Return(itemResponse(itemID), nil).Once()
+
+		item, err := bw.GetItem(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, itemID, item.ID)
+	})
+
+	t.Run("Should double the ttl on every miss up to the max", func(t *testing.T) {
+		clock := newFakeClock()
+		bw, client := newTestBitwarden(WithClock(clock), WithNegativeCache(time.Second, 3*time.Second))
+
+		client.On("Do", itemRequest).Return(notFound(), nil).Times(3)
+
+		for _, ttl := range []time.Duration{time.Second, 2 * time.Second, 3 * time.Second} {
+			_, err := bw.GetItem(context.Background(), itemID)
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			clock.Advance(ttl - time.Millisecond)
+			_, err = bw.GetItem(context.Background(), itemID)
+			assert.ErrorIs(t, err, ErrNotFound)
+			clock.Advance(time.Millisecond)
+		}
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("Should start over once an item was not requested for the max", func(t *testing.T) {
+		clock := newFakeClock()
+		bw, client := newTestBitwarden(WithClock(clock), WithNegativeCache(time.Second, 4*time.Second))
+
+		client.On("Do", itemRequest).Return(notFound(), nil).Twice()
+
+		bw.GetItem(context.Background(), itemID)
+		clock.Advance(time.Minute)
+		bw.GetItem(context.Background(), itemID)
+		clock.Advance(time.Second)
+		client.On("Do", itemRequest).Return(itemResponse(itemID), nil).Once()
+
+		_, err := bw.GetItem(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should forget misses on sync", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithNegativeCache(time.Hour, time.Hour))
+
+		client.On("Do", itemRequest).Return(notFound(), nil).Once()
+		client.On("Do", mock.MatchedBy(checkRequest(http.MethodPost, "http://localhost/sync", `{}`))).Return(&http.Response{StatusCode: 200}, nil).Once()
+
+		bw.GetItem(context.Background(), itemID)
+		assert.NoError(t, bw.Sync(context.Background()))
+		client.On("Do", itemRequest).Return(itemResponse(itemID), nil).Once()
+
+		_, err := bw.GetItem(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should not remember other errors", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithNegativeCache(time.Hour, time.Hour))
+
+		client.On("Do", itemRequest).Return(&http.Response{StatusCode: 500}, nil).Once()
+		client.On("Do", itemRequest).Return(itemResponse(itemID), nil).Once()
+
+		_, err := bw.GetItem(context.Background(), itemID)
+		assert.Error(t, err)
+		_, err = bw.GetItem(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+}
+
+func TestMissCacheLimit(t *testing.T) {
+	t.Run("Should forget the miss that expires first when full", func(t *testing.T) {
+		clock := newFakeClock()
+		c := &missCache{ttl: time.Second, max: time.Minute, limit: 2, entries: map[string]missEntry{}, clock: clock}
+
+		c.put("a")
+		clock.Advance(time.Millisecond)
+		c.put("b")
+		c.put("c")
+
+		assert.Len(t, c.entries, 2)
+		assert.NoError(t, c.get("a"))
+		assert.Error(t, c.get("b"))
+		assert.Error(t, c.get("c"))
+	})
+
+	t.Run("Should sweep misses that would start over when full", func(t *testing.T) {
+		clock := newFakeClock()
+		c := &missCache{ttl: time.Second, max: time.Minute, limit: 3, entries: map[string]missEntry{}, clock: clock}
+
+		c.put("a")
+		c.put("b")
+		c.put("c")
+		clock.Advance(2 * time.Minute)
+		c.put("d")
+
+		assert.Len(t, c.entries, 1)
+		assert.Contains(t, c.entries, "d")
+	})
+}
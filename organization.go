@@ -0,0 +1,76 @@
+package bitwarden
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// OrganizationStatus is the status of a membership of an organization.
+type OrganizationStatus int
+
+// OrganizationType is the role of a member in an organization.
+type OrganizationType int
+
+const (
+	OrganizationInvited   OrganizationStatus = 0
+	OrganizationAccepted  OrganizationStatus = 1
+	OrganizationConfirmed OrganizationStatus = 2
+	OrganizationRevoked   OrganizationStatus = -1
+
+	OrganizationOwner   OrganizationType = 0
+	OrganizationAdmin   OrganizationType = 1
+	OrganizationUser    OrganizationType = 2
+	OrganizationManager OrganizationType = 3
+	OrganizationCustom  OrganizationType = 4
+)
+
+type Organization struct {
+	ID      string             `json:"id"`
+	Name    string             `json:"name"`
+	Status  OrganizationStatus `json:"status"`
+	Type    OrganizationType   `json:"type"`
+	Enabled bool               `json:"enabled"`
+}
+
+// ListOrganizations returns the organizations the user is a member of.
+func (b *BitwardenServer) ListOrganizations(ctx context.Context) ([]Organization, error) {
+	resp := struct {
+		Data struct {
+			Data []Organization `json:"data"`
+		} `json:"data"`
+	}{}
+	ctx, span := b.startSpan(ctx, "ListOrganizations")
+	if err := endSpan(span, b.request(ctx, http.MethodGet, "/list/object/organizations", nil, &resp)); err != nil {
+		return nil, err
+	}
+	return resp.Data.Data, nil
+}
+
+// OrgMember is a member of an organization. Members with status
+// OrganizationAccepted are waiting to be confirmed.
+type OrgMember struct {
+	ID               string             `json:"id"`
+	Email            string             `json:"email"`
+	Name             string             `json:"name"`
+	Status           OrganizationStatus `json:"status"`
+	Type             OrganizationType   `json:"type"`
+	TwoFactorEnabled bool               `json:"twoFactorEnabled"`
+}
+
+// ListOrgMembers returns the members of the organization with the given ID.
+func (b *BitwardenServer) ListOrgMembers(ctx context.Context, orgID string) ([]OrgMember, error) {
+	resp := struct {
+		Data struct {
+			Data []OrgMember `json:"data"`
+		} `json:"data"`
+	}{}
+	ctx, span := b.startSpan(ctx, "ListOrgMembers", attribute.String("bitwarden.organization_id", orgID))
+	endpoint := "/list/object/org-members?organizationid=" + url.QueryEscape(orgID)
+	if err := endSpan(span, b.request(ctx, http.MethodGet, endpoint, nil, &resp)); err != nil {
+		return nil, err
+	}
+	return resp.Data.Data, nil
+}
@@ -0,0 +1,58 @@
+package bitwarden
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListOrganizations(t *testing.T) {
+	t.Run("Should list organizations", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/organizations", ``))).
+			Return(listResponse(`{"object":"organization","id":"o1","name":"Acme","status":2,"type":1,"enabled":true}`), nil).
+			Once()
+
+		orgs, err := bw.ListOrganizations(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Organization{{ID: "o1", Name: "Acme", Status: OrganizationConfirmed, Type: OrganizationAdmin, Enabled: true}}, orgs)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("Should return request errors", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 500}, nil).
+			Once()
+
+		_, err := bw.ListOrganizations(context.Background())
+
+		assert.ErrorIs(t, err, ErrUnexpectedStatusCode)
+		client.AssertExpectations(t)
+	})
+}
+
+func TestListOrgMembers(t *testing.T) {
+	t.Run("Should list the members of an organization", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/org-members?organizationid=o1", ``))).
+			Return(listResponse(`{"object":"org-member","id":"m1","email":"jane@example.com","name":"Jane","status":1,"type":2,"twoFactorEnabled":true}`), nil).
+			Once()
+
+		members, err := bw.ListOrgMembers(context.Background(), "o1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []OrgMember{{ID: "m1", Email: "jane@example.com", Name: "Jane", Status: OrganizationAccepted, Type: OrganizationUser, TwoFactorEnabled: true}}, members)
+		client.AssertExpectations(t)
+	})
+}
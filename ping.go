@@ -0,0 +1,69 @@
+package bitwarden
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// keepAlive pings bw serve until it is stopped, see WithKeepAlive.
+type keepAlive struct {
+	interval time.Duration
+	stop     chan struct{}
+	once     sync.Once
+}
+
+// WithKeepAlive asks bw serve for the vault status every interval, so the
+// vault timeout of serve does not lock the vault in the middle of a long
+// batch job, for setups where the policy does not allow unlocking it again
+// with WithPasswordProvider. Pings stop on Close and when the context of
+// NewWithContext is done. Failed pings are ignored.
+func WithKeepAlive(interval time.Duration) Option {
+	return func(b *BitwardenServer) {
+		if interval > 0 {
+			b.keepAlive = &keepAlive{interval: interval, stop: make(chan struct{})}
+		}
+	}
+}
+
+// startKeepAlive starts pinging if WithKeepAlive is set.
+func (b *BitwardenServer) startKeepAlive() {
+	if b.keepAlive == nil {
+		return
+	}
+	k := b.keepAlive
+	ticker := b.clock.NewTicker(k.interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-k.stop:
+				return
+			case <-ticker.C():
+			}
+			b.ping(k)
+		}
+	}()
+}
+
+// ping asks for the status, giving up after an interval or when stopped.
+func (b *BitwardenServer) ping(k *keepAlive) {
+	ctx, cancel := context.WithTimeout(context.Background(), k.interval)
+	defer cancel()
+	go func() {
+		select {
+		case <-k.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	b.Status(ctx)
+}
+
+// stopKeepAlive stops pinging; it is safe to call more than once.
+func (b *BitwardenServer) stopKeepAlive() {
+	if b.keepAlive == nil {
+		return
+	}
+	b.keepAlive.once.Do(func() { close(b.keepAlive.stop) })
+}
@@ -0,0 +1,48 @@
+package bitwarden
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWithKeepAlive(t *testing.T) {
+	t.Run("Should ask for the status every interval until closed", func(t *testing.T) {
+		clock := newFakeClock()
+		bw, client := newTestBitwarden(WithClock(clock), WithKeepAlive(time.Minute))
+		pinged := make(chan struct{}, 1)
+
+		client.
+			On("Do", statusRequest).
+			Return(statusResponse("unlocked"), nil).
+			Run(func(_ mock.Arguments) { pinged <- struct{}{} }).
+			Twice()
+
+		for i := 0; i < 2; i++ {
+			clock.Advance(time.Minute)
+			select {
+			case <-pinged:
+			case <-time.After(time.Second):
+				t.Fatal("no ping")
+			}
+		}
+		bw.Close()
+		bw.Close()
+		clock.Advance(time.Minute)
+
+		select {
+		case <-pinged:
+			t.Fatal("pinged after Close")
+		case <-time.After(50 * time.Millisecond):
+		}
+		client.AssertExpectations(t)
+	})
+
+	t.Run("Should not ping without an interval", func(t *testing.T) {
+		bw, _ := newTestBitwarden(WithKeepAlive(0))
+
+		assert.Nil(t, bw.keepAlive)
+	})
+}
@@ -0,0 +1,152 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultProviderWatchInterval = time.Minute
+
+var ErrProviderNotSupported = errors.New("not supported by the bitwarden provider")
+
+// ConfigProvider exposes vault items as configuration. Every item becomes a
+// map under prefix, keyed by item name, holding its name, notes, username,
+// password, totp and uri (where set) and all custom fields, so the password
+// of an item named "db" is available as "<prefix>.db.password".
+//
+// It implements the koanf Provider interface (Read, ReadBytes) and its
+// optional Watch/Unwatch methods.
+type ConfigProvider struct {
+	// WatchInterval is how often Watch syncs the vault. Defaults to a minute.
+	WatchInterval time.Duration
+	// Delim separates the parts of a Get key. Defaults to ".". Set it, like
+	// the koanf delimiter, to a character that does not appear in item or
+	// field names, for example "/" for items named after host names.
+	Delim string
+
+	bw     *BitwardenServer
+	prefix string
+	opts   []ListOption
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+}
+
+// Provider returns a ConfigProvider for the items selected by opts, for
+// example InFolder to only expose a single folder.
+func Provider(bw *BitwardenServer, prefix string, opts ...ListOption) *ConfigProvider {
+	return &ConfigProvider{bw: bw, prefix: prefix, opts: opts, WatchInterval: defaultProviderWatchInterval, Delim: "."}
+}
+
+// ReadBytes is not supported, the provider has no raw representation.
+func (p *ConfigProvider) ReadBytes() ([]byte, error) {
+	return nil, ErrProviderNotSupported
+}
+
+// Read returns the items as a nested map.
+func (p *ConfigProvider) Read() (map[string]any, error) {
+	return p.ReadContext(context.Background())
+}
+
+// ReadContext is like Read but uses ctx for the requests.
+func (p *ConfigProvider) ReadContext(ctx context.Context) (map[string]any, error) {
+	items, err := p.bw.ListItems(ctx, p.opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]any{}
+	for _, i := range items {
+		if i.Name == nil {
+			continue
+		}
+		values[*i.Name] = itemValues(&i)
+	}
+	if p.prefix == "" {
+		return values, nil
+	}
+	return map[string]any{p.prefix: values}, nil
+}
+
+// Get returns the value for a single key such as "secrets.db.password", with
+// the parts separated by Delim.
+func (p *ConfigProvider) Get(ctx context.Context, key string) (string, error) {
+	values, err := p.ReadContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	delim := p.Delim
+	if delim == "" {
+		delim = "."
+	}
+	var v any = values
+	for _, part := range strings.Split(key, delim) {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return "", fmt.Errorf("%w: %s", ErrFieldNotFound, key)
+		}
+		if v, ok = m[part]; !ok {
+			return "", fmt.Errorf("%w: %s", ErrFieldNotFound, key)
+		}
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrFieldNotFound, key)
+	}
+	return s, nil
+}
+
+// Watch calls cb with a ChangeEvent whenever an item in the vault changes,
+// so configuration can be reloaded. Calling Watch again replaces the previous
+// watch.
+func (p *ConfigProvider) Watch(cb func(event any, err error)) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cancel != nil {
+		p.cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := p.bw.Watch(ctx, p.WatchInterval)
+	if err != nil {
+		cancel()
+		return err
+	}
+	p.cancel = cancel
+
+	go func() {
+		for e := range events {
+			cb(e, nil)
+		}
+	}()
+	return nil
+}
+
+// Unwatch stops watching for changes.
+func (p *ConfigProvider) Unwatch() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cancel != nil {
+		p.cancel()
+		p.cancel = nil
+	}
+	return nil
+}
+
+func itemValues(i *Item) map[string]any {
+	values := map[string]any{}
+	for _, property := range []string{"name", "notes", "username", "password", "totp", "uri"} {
+		if v, err := i.Value(property); err == nil {
+			values[property] = v
+		}
+	}
+	for _, f := range i.Fields {
+		values[f.Name] = f.Value
+	}
+	return values
+}
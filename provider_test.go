@@ -0,0 +1,112 @@
+package bitwarden
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestProvider(t *testing.T) {
+	items := `{"id":"a","type":1,"name":"db","login":{"username":"app","password":"secret"},"fields":[{"name":"host","value":"db.internal"}]},{"id":"b","type":2,"name":"env","notes":"A=B"},{"id":"c","type":2}`
+	listRequest := mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items?folderid=6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a", ``))
+
+	t.Run("Should read items as nested maps under the prefix", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", listRequest).
+			Return(listResponse(items), nil).
+			Once()
+
+		values, err := Provider(bw, "secrets", InFolder("6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a")).Read()
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"secrets": map[string]any{
+			"db":  map[string]any{"name": "db", "username": "app", "password": "secret", "host": "db.internal"},
+			"env": map[string]any{"name": "env", "notes": "A=B"},
+		}}, values)
+	})
+
+	t.Run("Should get single keys", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", listRequest).
+			Return(func(*http.Request) (*http.Response, error) { return listResponse(items), nil })
+
+		p := Provider(bw, "secrets", InFolder("6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a"))
+		password, err := p.Get(context.Background(), "secrets.db.password")
+		assert.NoError(t, err)
+		assert.Equal(t, "secret", password)
+
+		_, err = p.Get(context.Background(), "secrets.db")
+		assert.ErrorIs(t, err, ErrFieldNotFound)
+		_, err = p.Get(context.Background(), "secrets.db.password.x")
+		assert.ErrorIs(t, err, ErrFieldNotFound)
+		_, err = p.Get(context.Background(), "secrets.cache.password")
+		assert.ErrorIs(t, err, ErrFieldNotFound)
+	})
+
+	t.Run("Should get keys of dotted names with another delimiter", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items", ``))).
+			Return(listResponse(`{"id":"a","type":1,"name":"api.example.com","login":{"password":"secret"}}`), nil).
+			Once()
+
+		p := Provider(bw, "secrets")
+		p.Delim = "/"
+		password, err := p.Get(context.Background(), "secrets/api.example.com/password")
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "secret", password)
+	})
+
+	t.Run("Should not support raw bytes", func(t *testing.T) {
+		bw, _ := newTestBitwarden()
+
+		_, err := Provider(bw, "").ReadBytes()
+
+		assert.ErrorIs(t, err, ErrProviderNotSupported)
+	})
+
+	t.Run("Should call the watch hook on changes", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodPost, "http://localhost/sync", `{}`))).
+			Return(func(*http.Request) (*http.Response, error) { return &http.Response{StatusCode: 200}, nil })
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items", ``))).
+			Return(listResponse(`{"id":"a","revisionDate":"2023-01-01T00:00:00Z"}`), nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items", ``))).
+			Return(func(*http.Request) (*http.Response, error) {
+				return listResponse(`{"id":"a","revisionDate":"2023-02-01T00:00:00Z"}`), nil
+			})
+
+		p := Provider(bw, "secrets")
+		p.WatchInterval = time.Millisecond
+		changes := make(chan any, 1)
+		err := p.Watch(func(event any, err error) {
+			select {
+			case changes <- event:
+			default:
+			}
+		})
+		assert.NoError(t, err)
+
+		e := (<-changes).(ChangeEvent)
+		assert.NoError(t, p.Unwatch())
+		assert.Equal(t, "a", e.ItemID)
+		assert.Equal(t, ChangeUpdated, e.Type)
+	})
+}
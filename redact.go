@@ -0,0 +1,100 @@
+package bitwarden
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Redacted replaces sensitive values in redacted output.
+const Redacted = "[REDACTED]"
+
+// sensitiveFields are the JSON fields that hold secrets, in lower case: the
+// master password, session keys and the secret parts of items. Objects and
+// arrays under these fields are redacted field by field instead, so the data
+// envelope of bw serve responses is only masked where it holds a value, such
+// as the password in the {"object": "string", "data": ...} answer of
+// /generate.
+var sensitiveFields = map[string]bool{
+	"data":           true,
+	"password":       true,
+	"totp":           true,
+	"notes":          true,
+	"value":          true,
+	"raw":            true,
+	"session":        true,
+	"token":          true,
+	"privatekey":     true,
+	"keyvalue":       true,
+	"number":         true,
+	"code":           true,
+	"ssn":            true,
+	"passportnumber": true,
+	"licensenumber":  true,
+	"clientsecret":   true,
+	"client_secret":  true,
+	"access_token":   true,
+	"refresh_token":  true,
+}
+
+// Redact returns a copy of a JSON document with the values of sensitive
+// fields, such as passwords, notes and custom field values, replaced by
+// Redacted. Anything that is not a JSON object or array is returned as is,
+// so plain text messages stay readable. Use it before logging request or
+// response bodies.
+func Redact(data []byte) []byte {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return data
+	}
+	d := json.NewDecoder(bytes.NewReader(trimmed))
+	d.UseNumber()
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return []byte(Redacted) // looks like JSON, but could not be parsed
+	}
+	out, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return []byte(Redacted)
+	}
+	return out
+}
+
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if sensitiveFields[strings.ToLower(key)] && isScalar(value) {
+				v[key] = Redacted
+			} else {
+				v[key] = redactValue(value)
+			}
+		}
+	case []any:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+	}
+	return v
+}
+
+// isScalar reports whether a decoded JSON value is a string, number or
+// boolean.
+func isScalar(v any) bool {
+	switch v.(type) {
+	case nil, map[string]any, []any:
+		return false
+	}
+	return true
+}
+
+// String hides the value of the field, so fields can be printed or logged.
+func (f Field) String() string {
+	return fmt.Sprintf("{%s %s %d}", f.Name, Redacted, f.Type)
+}
+
+// GoString hides the value of the field from %#v.
+func (f Field) GoString() string {
+	return fmt.Sprintf("bitwarden.Field{Name:%q, Value:%q, Type:%d}", f.Name, Redacted, f.Type)
+}
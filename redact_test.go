@@ -0,0 +1,84 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRedact(t *testing.T) {
+	t.Run("Should mask sensitive fields at any depth", func(t *testing.T) {
+		got := Redact([]byte(`{
+			"password": "hunter2",
+			"data": {
+				"id": "1",
+				"notes": "secret note",
+				"login": {"username": "admin", "password": "hunter2", "totp": null},
+				"fields": [{"name": "token", "value": "t0k3n", "type": 1}]
+			}
+		}`))
+
+		assert.JSONEq(t, `{
+			"password": "[REDACTED]",
+			"data": {
+				"id": "1",
+				"notes": "[REDACTED]",
+				"login": {"username": "admin", "password": "[REDACTED]", "totp": null},
+				"fields": [{"name": "token", "value": "[REDACTED]", "type": 1}]
+			}
+		}`, string(got))
+	})
+
+	t.Run("Should keep plain text", func(t *testing.T) {
+		assert.Equal(t, "Vault is locked.", string(Redact([]byte("Vault is locked."))))
+	})
+
+	t.Run("Should hide malformed JSON completely", func(t *testing.T) {
+		assert.Equal(t, Redacted, string(Redact([]byte(`{"password": "hunter2"`))))
+	})
+}
+
+func TestRedactGeneratedPassword(t *testing.T) {
+	t.Run("Should mask data strings but keep data objects", func(t *testing.T) {
+		got := Redact([]byte(`{"success":true,"data":{"object":"string","data":"Tr0ub4dor&3"}}`))
+
+		assert.JSONEq(t, `{"success":true,"data":{"object":"string","data":"[REDACTED]"}}`, string(got))
+	})
+}
+
+func TestFieldString(t *testing.T) {
+	t.Run("Should not print the value", func(t *testing.T) {
+		f := Field{Name: "token", Value: "t0k3n", Type: 1}
+
+		for _, format := range []string{"%v", "%+v", "%s", "%#v"} {
+			assert.NotContains(t, fmt.Sprintf(format, f), "t0k3n", format)
+		}
+		assert.NotContains(t, fmt.Sprintf("%v", Item{Fields: []Field{f}}), "t0k3n")
+	})
+}
+
+func TestAPIErrorRedaction(t *testing.T) {
+	t.Run("Should not keep secrets of the response body", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 500, Body: io.NopCloser(bytes.NewBufferString(`{"password":"hunter2","error":"boom"}`))}, nil).
+			Once()
+
+		err := bw.Sync(context.Background())
+
+		client.AssertExpectations(t)
+		var apiErr *APIError
+		assert.True(t, errors.As(err, &apiErr))
+		assert.NotContains(t, string(apiErr.Body), "hunter2")
+		assert.NotContains(t, err.Error(), "hunter2")
+	})
+}
@@ -0,0 +1,114 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrRotationNotVerified = errors.New("rotated password was not stored")
+	ErrNoPasswordHistory   = errors.New("item has no password history")
+)
+
+type PasswordHistory struct {
+	LastUsedDate time.Time `json:"lastUsedDate"`
+	Password     string    `json:"password"`
+}
+
+// RotateLoginPassword replaces the password of a login with a generated one,
+// and fetches the item again to verify that the new password was stored. The
+// old password is added to the password history of the item, so if the
+// target system can not be updated, RevertLoginPassword restores it.
+//
+// Once the new password was generated, old and new are returned even along
+// with an error: a failed edit may have been stored anyway, and a failed
+// verification does not mean the new password is not live, so the caller
+// must be able to tell what the password might be.
+func (b *BitwardenServer) RotateLoginPassword(ctx context.Context, itemID string, gen GenerateOptions) (old, new string, err error) {
+	item, err := b.fetchItem(ctx, itemID)
+	if err != nil {
+		return "", "", err
+	}
+	login, err := item.login()
+	if err != nil {
+		return "", "", err
+	}
+	new, err = b.Generate(ctx, gen)
+	if err != nil {
+		return "", "", err
+	}
+	if login.Password != nil {
+		old = *login.Password
+	}
+
+	if err := item.SetPasswordAt(new, b.clock.Now()); err != nil {
+		return "", "", err
+	}
+	if _, err := b.EditItem(ctx, item); err != nil {
+		return old, new, err
+	}
+	stored, err := b.fetchItem(ctx, itemID)
+	if err != nil {
+		return old, new, err
+	}
+	if stored.Login == nil || stored.Login.Password == nil || *stored.Login.Password != new {
+		return old, new, ErrRotationNotVerified
+	}
+	return old, new, nil
+}
+
+// RevertLoginPassword restores the most recent password from the password
+// history of a login, for example after RotateLoginPassword when the target
+// system could not be updated, and returns it.
+func (b *BitwardenServer) RevertLoginPassword(ctx context.Context, itemID string) (string, error) {
+	item, err := b.fetchItem(ctx, itemID)
+	if err != nil {
+		return "", err
+	}
+	if _, err := item.login(); err != nil {
+		return "", err
+	}
+	if len(item.PasswordHistory) == 0 {
+		return "", ErrNoPasswordHistory
+	}
+	latest := 0
+	for i, h := range item.PasswordHistory {
+		if h.LastUsedDate.After(item.PasswordHistory[latest].LastUsedDate) {
+			latest = i
+		}
+	}
+	restored := item.PasswordHistory[latest].Password
+
+	if err := item.SetPasswordAt(restored, b.clock.Now()); err != nil {
+		return "", err
+	}
+	if _, err := b.EditItem(ctx, item); err != nil {
+		return "", err
+	}
+	return restored, nil
+}
+
+// SetPassword changes the password of a login and moves the current one to
+// the password history, like the Bitwarden clients do. The item is only
+// changed locally; store it with EditItem.
+func (item *Item) SetPassword(password string) error {
+	return item.SetPasswordAt(password, time.Now())
+}
+
+// SetPasswordAt is SetPassword with the time of the change, for callers
+// that tell the time with a Clock.
+func (item *Item) SetPasswordAt(password string, t time.Time) error {
+	login, err := item.login()
+	if err != nil {
+		return err
+	}
+	if current := login.Password; current != nil && *current != "" && *current != password {
+		now := t.UTC()
+		entry := PasswordHistory{LastUsedDate: now, Password: *current}
+		item.PasswordHistory = append([]PasswordHistory{entry}, item.PasswordHistory...)
+		login.PasswordRevisionDate = &now
+	}
+	login.Password = &password
+	return nil
+}
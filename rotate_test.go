@@ -0,0 +1,146 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRotateLoginPassword(t *testing.T) {
+	itemID := "1d4cf845-8012-4b2d-a924-f9d8c9b7c44a"
+	itemRequest := mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))
+	generateRequest := mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/generate?length=20", ``))
+	response := func(data string) *http.Response {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(data))}
+	}
+	login := func(password string) *http.Response {
+		return response(`{"data":{"id":"` + itemID + `","type":1,"name":"db","login":{"username":"admin","password":"` + password + `"}}}`)
+	}
+
+	t.Run("Should store, verify and return the new password", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.On("Do", itemRequest).Return(login("old"), nil).Once()
+		client.On("Do", generateRequest).Return(response(`{"data":{"data":"new"}}`), nil).Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequestJSON(http.MethodPut, "http://localhost/object/item/"+itemID, func(body map[string]any) bool {
+				history := body["passwordHistory"].([]any)
+				return body["login"].(map[string]any)["password"] == "new" &&
+					len(history) == 1 && history[0].(map[string]any)["password"] == "old"
+			}))).
+			Return(login("new"), nil).
+			Once()
+		client.On("Do", itemRequest).Return(login("new"), nil).Once()
+
+		old, new, err := bw.RotateLoginPassword(context.Background(), itemID, GenerateOptions{Length: 20})
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "old", old)
+		assert.Equal(t, "new", new)
+	})
+
+	t.Run("Should fail when the new password was not stored", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.On("Do", itemRequest).Return(login("old"), nil).Once()
+		client.On("Do", generateRequest).Return(response(`{"data":{"data":"new"}}`), nil).Once()
+		client.On("Do", mock.MatchedBy(func(req *http.Request) bool { return req.Method == http.MethodPut })).Return(login("new"), nil).Once()
+		client.On("Do", itemRequest).Return(login("old"), nil).Once()
+
+		old, new, err := bw.RotateLoginPassword(context.Background(), itemID, GenerateOptions{Length: 20})
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrRotationNotVerified)
+		assert.Equal(t, "old", old)
+		assert.Equal(t, "new", new, "the new password may be live")
+	})
+
+	t.Run("Should return the new password when verifying fails", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.On("Do", itemRequest).Return(login("old"), nil).Once()
+		client.On("Do", generateRequest).Return(response(`{"data":{"data":"new"}}`), nil).Once()
+		client.On("Do", mock.MatchedBy(func(req *http.Request) bool { return req.Method == http.MethodPut })).Return(login("new"), nil).Once()
+		client.On("Do", itemRequest).Return(&http.Response{StatusCode: 500}, nil).Once()
+
+		old, new, err := bw.RotateLoginPassword(context.Background(), itemID, GenerateOptions{Length: 20})
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrUnexpectedStatusCode)
+		assert.Equal(t, "old", old)
+		assert.Equal(t, "new", new)
+	})
+
+	t.Run("Should only rotate logins", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.On("Do", itemRequest).Return(response(`{"data":{"id":"`+itemID+`","type":2}}`), nil).Once()
+
+		_, _, err := bw.RotateLoginPassword(context.Background(), itemID, GenerateOptions{})
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrNotALogin)
+	})
+}
+
+func TestRevertLoginPassword(t *testing.T) {
+	itemID := "1d4cf845-8012-4b2d-a924-f9d8c9b7c44a"
+	itemRequest := mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))
+
+	t.Run("Should restore the most recent password", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		respData := `{"data":{"id":"` + itemID + `","type":1,"name":"db","login":{"password":"new"},"passwordHistory":[
+			{"lastUsedDate":"2023-01-01T00:00:00Z","password":"older"},
+			{"lastUsedDate":"2024-01-01T00:00:00Z","password":"old"}]}}`
+		client.On("Do", itemRequest).Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(respData))}, nil).Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequestJSON(http.MethodPut, "http://localhost/object/item/"+itemID, func(body map[string]any) bool {
+				return body["login"].(map[string]any)["password"] == "old" && len(body["passwordHistory"].([]any)) == 3
+			}))).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"data":{"id":"` + itemID + `"}}`))}, nil).
+			Once()
+
+		restored, err := bw.RevertLoginPassword(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "old", restored)
+	})
+
+	t.Run("Should fail without password history", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.On("Do", itemRequest).Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"data":{"id":"` + itemID + `","type":1,"login":{}}}`))}, nil).Once()
+
+		_, err := bw.RevertLoginPassword(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrNoPasswordHistory)
+	})
+}
+
+func TestSetPassword(t *testing.T) {
+	t.Run("Should move the current password to the history", func(t *testing.T) {
+		old := "old"
+		item := &Item{Type: TypeLogin, Login: &Login{Password: &old}}
+
+		assert.NoError(t, item.SetPassword("new"))
+		assert.NoError(t, item.SetPassword("new"))
+
+		assert.Equal(t, "new", *item.Login.Password)
+		if assert.Len(t, item.PasswordHistory, 1) {
+			assert.Equal(t, "old", item.PasswordHistory[0].Password)
+		}
+	})
+
+	t.Run("Should only change logins", func(t *testing.T) {
+		assert.ErrorIs(t, (&Item{Type: TypeSecureNote}).SetPassword("new"), ErrNotALogin)
+	})
+}
@@ -0,0 +1,298 @@
+// Package rotation rotates the passwords of login items together with the
+// systems that use them. For every registered item a rotation generates a
+// new password, applies it to the external system, verifies it there and
+// only then commits it to the vault. Failed steps are retried, and if the
+// rotation can not be completed, the external system is rolled back to the
+// old password when the Rotator supports it.
+//
+// The item is read with GetItemIfChanged, which bypasses the cache, so a
+// rotation never starts from a stale password, and it is read again right
+// before the new password is committed, so changes other clients made to the
+// item in the meantime are kept.
+package rotation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+)
+
+type Client interface {
+	GetItemIfChanged(ctx context.Context, id string, since time.Time) (*bitwarden.Item, bool, error)
+	EditItem(ctx context.Context, item *bitwarden.Item) (*bitwarden.Item, error)
+	Generate(ctx context.Context, opts bitwarden.GenerateOptions) (string, error)
+}
+
+// Rotator updates the external system that uses the password of an item.
+type Rotator interface {
+	// Apply sets the password in the external system.
+	Apply(ctx context.Context, item *bitwarden.Item, password string) error
+	// Verify checks that the external system accepts the password.
+	Verify(ctx context.Context, item *bitwarden.Item, password string) error
+}
+
+// Generator can be implemented by a Rotator to generate passwords itself,
+// for systems with their own password rules.
+type Generator interface {
+	Generate(ctx context.Context, item *bitwarden.Item) (string, error)
+}
+
+// RollbackRotator can be implemented by a Rotator to restore the old
+// password in the external system when a rotation fails after Apply.
+type RollbackRotator interface {
+	Rollback(ctx context.Context, item *bitwarden.Item, old string) error
+}
+
+type Status string
+
+const (
+	// StatusRotated means the new password is used by the system and stored
+	// in the vault.
+	StatusRotated Status = "rotated"
+	// StatusFailed means the rotation failed before the system was changed,
+	// or the system could not be rolled back. In the latter case the system
+	// may use the new password, which Result.Pending holds.
+	StatusFailed Status = "failed"
+	// StatusRolledBack means the rotation failed and the system uses the old
+	// password again.
+	StatusRolledBack Status = "rolled back"
+	// StatusUncommitted means the system uses the new password, but it could
+	// not be stored in the vault. Result.Pending holds the password.
+	StatusUncommitted Status = "uncommitted"
+	// StatusSkipped means the rotation did not run because the context was
+	// done.
+	StatusSkipped Status = "skipped"
+)
+
+// Result describes the rotation of one item.
+type Result struct {
+	ItemID string
+	Name   string
+	Status Status
+	// Attempts counts the calls of all steps, including retries.
+	Attempts int
+	Duration time.Duration
+	Err      error
+	// Pending is the new password if the system may use it but the vault
+	// does not hold it: always for StatusUncommitted, and for StatusFailed
+	// when Apply was called and the system could not be rolled back.
+	Pending bitwarden.SecureString
+}
+
+// Report lists the results of a run in registration order.
+type Report struct {
+	Results []Result
+}
+
+// Err returns the errors of all failed rotations, or nil.
+func (r *Report) Err() error {
+	var errs []error
+	for _, res := range r.Results {
+		if res.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", res.ItemID, res.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Report) String() string {
+	var sb strings.Builder
+	for _, res := range r.Results {
+		fmt.Fprintf(&sb, "%s (%s): %s after %d attempts in %s", res.ItemID, res.Name, res.Status, res.Attempts, res.Duration.Round(time.Millisecond))
+		if res.Err != nil {
+			fmt.Fprintf(&sb, ": %s", res.Err)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+type rotation struct {
+	itemID  string
+	rotator Rotator
+	gen     bitwarden.GenerateOptions
+}
+
+// Orchestrator runs registered rotations one after the other.
+type Orchestrator struct {
+	client    Client
+	rotations []rotation
+	retries   int
+	backoff   time.Duration
+	clock     bitwarden.Clock
+}
+
+// Option configures optional behaviour of an Orchestrator.
+type Option func(*Orchestrator)
+
+// WithRetries sets how often a failed step is retried. Defaults to 2.
+func WithRetries(n int) Option {
+	return func(o *Orchestrator) { o.retries = n }
+}
+
+// WithBackoff sets the wait before the first retry, which doubles for every
+// next retry. Defaults to one second.
+func WithBackoff(d time.Duration) Option {
+	return func(o *Orchestrator) { o.backoff = d }
+}
+
+// WithClock sets the clock of the retry backoff, the durations in the
+// Report and the password history entries. Defaults to
+// bitwarden.SystemClock.
+func WithClock(c bitwarden.Clock) Option {
+	return func(o *Orchestrator) { o.clock = c }
+}
+
+func New(c Client, opts ...Option) *Orchestrator {
+	o := &Orchestrator{client: c, retries: 2, backoff: time.Second, clock: bitwarden.SystemClock()}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Register adds the rotation of the login item with the given ID. Passwords
+// are generated with gen, unless r implements Generator. Rotations run in
+// the order they were registered.
+func (o *Orchestrator) Register(itemID string, r Rotator, gen bitwarden.GenerateOptions) {
+	o.rotations = append(o.rotations, rotation{itemID: itemID, rotator: r, gen: gen})
+}
+
+// Run rotates all registered items and reports the outcome of each. A failed
+// rotation does not stop the others; once ctx is done, the remaining
+// rotations are skipped.
+func (o *Orchestrator) Run(ctx context.Context) *Report {
+	report := &Report{}
+	for _, r := range o.rotations {
+		if ctx.Err() != nil {
+			report.Results = append(report.Results, Result{ItemID: r.itemID, Status: StatusSkipped, Err: ctx.Err()})
+			continue
+		}
+		start := o.clock.Now()
+		res := o.rotate(ctx, r)
+		res.Duration = o.clock.Now().Sub(start)
+		report.Results = append(report.Results, res)
+	}
+	return report
+}
+
+// fetch reads the item from the server, never from the cache.
+func (o *Orchestrator) fetch(ctx context.Context, id string) (*bitwarden.Item, error) {
+	item, _, err := o.client.GetItemIfChanged(ctx, id, time.Time{})
+	return item, err
+}
+
+func (o *Orchestrator) rotate(ctx context.Context, r rotation) Result {
+	res := Result{ItemID: r.itemID, Status: StatusFailed}
+	var item *bitwarden.Item
+	if res.Err = o.retry(ctx, &res, func() (err error) {
+		item, err = o.fetch(ctx, r.itemID)
+		return err
+	}); res.Err != nil {
+		return res
+	}
+	if item.Name != nil {
+		res.Name = *item.Name
+	}
+	old, err := item.Value("password")
+	if err != nil {
+		res.Err = err
+		return res
+	}
+
+	var password string
+	res.Err = o.retry(ctx, &res, func() (err error) {
+		if g, ok := r.rotator.(Generator); ok {
+			password, err = g.Generate(ctx, item)
+		} else {
+			password, err = o.client.Generate(ctx, r.gen)
+		}
+		return err
+	})
+	if res.Err != nil {
+		return res
+	}
+
+	if res.Err = o.retry(ctx, &res, func() error { return r.rotator.Apply(ctx, item, password) }); res.Err != nil {
+		if !o.rollback(ctx, &res, r.rotator, item, old) {
+			res.Pending = bitwarden.SecureString(password) // Apply may have got through
+		}
+		return res
+	}
+	if res.Err = o.retry(ctx, &res, func() error { return r.rotator.Verify(ctx, item, password) }); res.Err != nil {
+		if !o.rollback(ctx, &res, r.rotator, item, old) {
+			res.Pending = bitwarden.SecureString(password)
+		}
+		return res
+	}
+
+	res.Err = o.retry(ctx, &res, func() error {
+		current, err := o.fetch(ctx, r.itemID)
+		if err != nil {
+			return err
+		}
+		if err := current.SetPasswordAt(password, o.clock.Now()); err != nil {
+			return err
+		}
+		_, err = o.client.EditItem(ctx, current)
+		return err
+	})
+	if res.Err != nil {
+		res.Err = fmt.Errorf("committing to the vault: %w", res.Err)
+		if !o.rollback(ctx, &res, r.rotator, item, old) {
+			res.Status = StatusUncommitted
+			res.Pending = bitwarden.SecureString(password)
+		}
+		return res
+	}
+	res.Status = StatusRotated
+	return res
+}
+
+// rollback restores the old password in the external system and reports
+// whether it did.
+func (o *Orchestrator) rollback(ctx context.Context, res *Result, r Rotator, item *bitwarden.Item, old string) bool {
+	rb, ok := r.(RollbackRotator)
+	if !ok {
+		return false
+	}
+	if err := o.retry(ctx, res, func() error { return rb.Rollback(ctx, item, old) }); err != nil {
+		res.Err = errors.Join(res.Err, fmt.Errorf("rollback: %w", err))
+		return false
+	}
+	res.Status = StatusRolledBack
+	return true
+}
+
+// retry runs fn until it succeeds, the retries are used up or ctx is done.
+func (o *Orchestrator) retry(ctx context.Context, res *Result, fn func() error) error {
+	backoff := o.backoff
+	for i := 0; ; i++ {
+		res.Attempts++
+		err := fn()
+		if err == nil || i >= o.retries {
+			return err
+		}
+		if waitErr := o.wait(ctx, backoff); waitErr != nil {
+			return errors.Join(err, waitErr)
+		}
+		backoff *= 2
+	}
+}
+
+// wait waits for d on the clock, or returns the error of ctx once it is
+// done.
+func (o *Orchestrator) wait(ctx context.Context, d time.Duration) error {
+	t := o.clock.NewTicker(d)
+	defer t.Stop()
+	select {
+	case <-t.C():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
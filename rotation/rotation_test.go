@@ -0,0 +1,220 @@
+package rotation
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+	"github.com/floriaanpost/go-bitwarden-client/bitwardentest"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Client = (*bitwarden.BitwardenServer)(nil)
+
+func ptr(s string) *string { return &s }
+
+var errTarget = errors.New("target unavailable")
+
+// fakeRotator is an external system with a password.
+type fakeRotator struct {
+	password    string
+	applyFails  int
+	verifyFails int
+	noRollback  bool
+	rollbacks   int
+}
+
+func (r *fakeRotator) Apply(_ context.Context, _ *bitwarden.Item, password string) error {
+	if r.applyFails > 0 {
+		r.applyFails--
+		return errTarget
+	}
+	r.password = password
+	return nil
+}
+
+func (r *fakeRotator) Verify(_ context.Context, _ *bitwarden.Item, password string) error {
+	if r.verifyFails > 0 {
+		r.verifyFails--
+		return errTarget
+	}
+	if r.password != password {
+		return errors.New("password not accepted")
+	}
+	return nil
+}
+
+type rollbackRotator struct{ *fakeRotator }
+
+func (r rollbackRotator) Rollback(_ context.Context, _ *bitwarden.Item, old string) error {
+	r.rollbacks++
+	r.password = old
+	return nil
+}
+
+// editingRotator is a system whose Apply also has the item edited elsewhere.
+type editingRotator struct {
+	*fakeRotator
+	edit func() error
+}
+
+func (r editingRotator) Apply(ctx context.Context, item *bitwarden.Item, password string) error {
+	if err := r.edit(); err != nil {
+		return err
+	}
+	return r.fakeRotator.Apply(ctx, item, password)
+}
+
+func newServer() *bitwardentest.Server {
+	return bitwardentest.NewServer(bitwardentest.WithItems(
+		bitwarden.Item{ID: "db", Type: bitwarden.TypeLogin, Name: ptr("database"), Login: &bitwarden.Login{Password: ptr("old-db")}},
+		bitwarden.Item{ID: "mq", Type: bitwarden.TypeLogin, Name: ptr("queue"), Login: &bitwarden.Login{Password: ptr("old-mq")}},
+	))
+}
+
+func TestOrchestrator(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Should rotate all items in order and retry failed steps", func(t *testing.T) {
+		srv := newServer()
+		defer srv.Close()
+		db := &fakeRotator{password: "old-db", applyFails: 1}
+		mq := &fakeRotator{password: "old-mq", verifyFails: 2}
+
+		o := New(srv.Client(), WithBackoff(time.Millisecond))
+		o.Register("db", db, bitwarden.GenerateOptions{})
+		o.Register("mq", mq, bitwarden.GenerateOptions{})
+		report := o.Run(ctx)
+
+		assert.NoError(t, report.Err())
+		if assert.Len(t, report.Results, 2) {
+			assert.Equal(t, "db", report.Results[0].ItemID)
+			assert.Equal(t, "database", report.Results[0].Name)
+			assert.Equal(t, StatusRotated, report.Results[0].Status)
+			assert.Equal(t, StatusRotated, report.Results[1].Status)
+			assert.Equal(t, 7, report.Results[1].Attempts) // get, generate, apply, 3x verify, commit
+		}
+		for id, r := range map[string]*fakeRotator{"db": db, "mq": mq} {
+			item, _ := srv.Item(id)
+			assert.Equal(t, r.password, *item.Login.Password)
+			assert.Equal(t, "old-"+id, item.PasswordHistory[0].Password)
+		}
+		assert.Contains(t, report.String(), "db (database): rotated after 6 attempts")
+	})
+
+	t.Run("Should wait for retries and date the history with the clock", func(t *testing.T) {
+		srv := newServer()
+		defer srv.Close()
+		start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		clock := bitwardentest.NewClock(start)
+		db := &fakeRotator{password: "old-db", applyFails: 1}
+
+		o := New(srv.Client(), WithClock(clock), WithBackoff(time.Hour))
+		o.Register("db", db, bitwarden.GenerateOptions{})
+		done := make(chan *Report)
+		go func() { done <- o.Run(ctx) }()
+		var report *Report
+		for report == nil {
+			select {
+			case report = <-done:
+			case <-time.After(time.Millisecond):
+				clock.Advance(time.Hour)
+			}
+		}
+
+		assert.NoError(t, report.Err())
+		assert.GreaterOrEqual(t, report.Results[0].Duration, time.Hour)
+		item, _ := srv.Item("db")
+		assert.True(t, item.PasswordHistory[0].LastUsedDate.Before(start.Add(24*time.Hour)), item.PasswordHistory[0].LastUsedDate)
+	})
+
+	t.Run("Should roll back the system when verification fails", func(t *testing.T) {
+		srv := newServer()
+		defer srv.Close()
+		db := rollbackRotator{&fakeRotator{password: "old-db", verifyFails: 10}}
+
+		o := New(srv.Client(), WithRetries(1), WithBackoff(time.Millisecond))
+		o.Register("db", db, bitwarden.GenerateOptions{})
+		report := o.Run(ctx)
+
+		assert.ErrorIs(t, report.Err(), errTarget)
+		assert.Equal(t, StatusRolledBack, report.Results[0].Status)
+		assert.Nil(t, report.Results[0].Pending)
+		assert.Equal(t, "old-db", db.password)
+		item, _ := srv.Item("db")
+		assert.Equal(t, "old-db", *item.Login.Password)
+	})
+
+	t.Run("Should report the pending password when verification fails without rollback", func(t *testing.T) {
+		srv := newServer()
+		defer srv.Close()
+		db := &fakeRotator{password: "old-db", verifyFails: 10}
+
+		o := New(srv.Client(), WithRetries(0))
+		o.Register("db", db, bitwarden.GenerateOptions{})
+		report := o.Run(ctx)
+
+		res := report.Results[0]
+		assert.Equal(t, StatusFailed, res.Status)
+		assert.ErrorIs(t, res.Err, errTarget)
+		assert.Equal(t, db.password, res.Pending.Reveal())
+		item, _ := srv.Item("db")
+		assert.Equal(t, "old-db", *item.Login.Password)
+	})
+
+	t.Run("Should report the pending password when the vault can not be updated", func(t *testing.T) {
+		srv := newServer()
+		defer srv.Close()
+		db := &fakeRotator{password: "old-db"}
+
+		o := New(srv.Client(), WithRetries(0))
+		o.Register("db", db, bitwarden.GenerateOptions{})
+		srv.FailRequests(func(r *http.Request) bool { return r.Method == http.MethodPut }, http.StatusInternalServerError, 0)
+		report := o.Run(ctx)
+
+		res := report.Results[0]
+		assert.Equal(t, StatusUncommitted, res.Status)
+		assert.ErrorIs(t, res.Err, bitwarden.ErrUnexpectedStatusCode)
+		assert.Equal(t, db.password, res.Pending.Reveal())
+		assert.NotContains(t, report.String(), db.password)
+	})
+
+	t.Run("Should keep changes made to the item during the rotation", func(t *testing.T) {
+		srv := newServer()
+		defer srv.Close()
+		bw := srv.Client(bitwarden.WithCache(time.Hour))
+		_, err := bw.GetItem(ctx, "db") // cached before the rotation
+		assert.NoError(t, err)
+		db := &fakeRotator{password: "old-db"}
+
+		o := New(bw)
+		o.Register("db", editingRotator{db, func() error {
+			return srv.Client().SetField(ctx, "db", "owner", "team-a", bitwarden.FieldText)
+		}}, bitwarden.GenerateOptions{})
+		report := o.Run(ctx)
+
+		assert.NoError(t, report.Err())
+		item, _ := srv.Item("db")
+		assert.Equal(t, db.password, *item.Login.Password)
+		owner, err := item.Value("owner")
+		assert.NoError(t, err)
+		assert.Equal(t, "team-a", owner)
+	})
+
+	t.Run("Should skip rotations once the context is done", func(t *testing.T) {
+		srv := newServer()
+		defer srv.Close()
+
+		o := New(srv.Client())
+		o.Register("db", &fakeRotator{}, bitwarden.GenerateOptions{})
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		report := o.Run(ctx)
+
+		assert.Equal(t, StatusSkipped, report.Results[0].Status)
+		assert.ErrorIs(t, report.Err(), context.Canceled)
+	})
+}
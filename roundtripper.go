@@ -0,0 +1,112 @@
+package bitwarden
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+type AuthScheme int
+
+const (
+	// AuthBasic sends the username and password of a login item.
+	AuthBasic AuthScheme = iota
+	// AuthBearer sends the custom field named "token" as a bearer token, or
+	// the login password if the item has no such field.
+	AuthBearer
+)
+
+// AuthRoundTripper adds credentials stored in a vault item to outgoing
+// requests. The credentials are fetched on first use and fetched again when
+// a request is answered with 401 Unauthorized, after which the request is
+// retried once.
+type AuthRoundTripper struct {
+	// Base is the transport used to send requests. If nil,
+	// http.DefaultTransport is used.
+	Base http.RoundTripper
+
+	bw     *BitwardenServer
+	itemID string
+	scheme AuthScheme
+
+	mu   sync.Mutex
+	item *Item
+}
+
+func NewAuthRoundTripper(bw *BitwardenServer, itemID string, scheme AuthScheme) *AuthRoundTripper {
+	return &AuthRoundTripper{bw: bw, itemID: itemID, scheme: scheme}
+}
+
+func (t *AuthRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	item, err := t.credentials(req.Context(), false)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.send(req, item)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return resp, nil // the body is consumed and cannot be sent again
+	}
+
+	item, err = t.credentials(req.Context(), true)
+	if err != nil {
+		return resp, nil
+	}
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return resp, nil
+		}
+	}
+	resp.Body.Close()
+	return t.send(retry, item)
+}
+
+func (t *AuthRoundTripper) credentials(ctx context.Context, refresh bool) (*Item, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.item != nil && !refresh {
+		return t.item, nil
+	}
+	fetch := t.bw.GetItem
+	if refresh {
+		fetch = t.bw.fetchItem
+	}
+	item, err := fetch(ctx, t.itemID)
+	if err != nil {
+		return nil, err
+	}
+	t.item = item
+	return item, nil
+}
+
+func (t *AuthRoundTripper) send(req *http.Request, item *Item) (*http.Response, error) {
+	req = req.Clone(req.Context()) // a RoundTripper must not modify the request
+	switch t.scheme {
+	case AuthBasic:
+		username, err := item.Value("username")
+		if err != nil {
+			return nil, err
+		}
+		password, err := item.Value("password")
+		if err != nil {
+			return nil, err
+		}
+		req.SetBasicAuth(username, password)
+	case AuthBearer:
+		token, err := bearerToken(item)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(req)
+}
@@ -0,0 +1,118 @@
+package bitwarden
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthRoundTripper(t *testing.T) {
+	itemID := "1d4cf845-8012-4b2d-a924-f9d8c9b7c44a"
+	itemRequest := mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))
+	loginResponse := func(password string) *http.Response {
+		respData := `{"data":{"id":"` + itemID + `","type":1,"login":{"username":"admin","password":"` + password + `"}}}`
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(respData))}
+	}
+
+	t.Run("Should add basic auth and reuse the credentials", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", itemRequest).
+			Return(loginResponse("hunter2"), nil).
+			Once()
+
+		var auths []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			auths = append(auths, r.Header.Get("Authorization"))
+		}))
+		defer server.Close()
+
+		httpClient := &http.Client{Transport: NewAuthRoundTripper(bw, itemID, AuthBasic)}
+		for i := 0; i < 2; i++ {
+			resp, err := httpClient.Get(server.URL)
+			assert.NoError(t, err)
+			resp.Body.Close()
+		}
+
+		client.AssertExpectations(t)
+		assert.Equal(t, []string{"Basic YWRtaW46aHVudGVyMg==", "Basic YWRtaW46aHVudGVyMg=="}, auths)
+	})
+
+	t.Run("Should prefer the token field for bearer auth", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		respData := `{"data":{"id":"` + itemID + `","type":1,"login":{"password":"pw"},"fields":[{"name":"token","value":"t0k3n","type":1}]}}`
+		client.
+			On("Do", itemRequest).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(respData))}, nil).
+			Once()
+
+		var auth string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			auth = r.Header.Get("Authorization")
+		}))
+		defer server.Close()
+
+		httpClient := &http.Client{Transport: NewAuthRoundTripper(bw, itemID, AuthBearer)}
+		resp, err := httpClient.Get(server.URL)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, "Bearer t0k3n", auth)
+	})
+
+	t.Run("Should refetch the credentials and retry on 401", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", itemRequest).
+			Return(loginResponse("old"), nil).
+			Once()
+		client.
+			On("Do", itemRequest).
+			Return(loginResponse("new"), nil).
+			Once()
+
+		var bodies []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(data))
+			if r.Header.Get("Authorization") != "Bearer new" {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		}))
+		defer server.Close()
+
+		httpClient := &http.Client{Transport: NewAuthRoundTripper(bw, itemID, AuthBearer)}
+		resp, err := httpClient.Post(server.URL, "text/plain", strings.NewReader("payload"))
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []string{"payload", "payload"}, bodies)
+	})
+
+	t.Run("Should return vault errors", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", itemRequest).
+			Return(&http.Response{StatusCode: 404}, nil).
+			Once()
+
+		httpClient := &http.Client{Transport: NewAuthRoundTripper(bw, itemID, AuthBasic)}
+		_, err := httpClient.Get("http://example.invalid")
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
@@ -0,0 +1,160 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrOutOfScope   = errors.New("item is outside the scope")
+	ErrInvalidScope = errors.New("invalid scope")
+)
+
+// Scope is a folder or a collection that a ScopedClient is confined to.
+// Exactly one of FolderID and CollectionID must be set.
+type Scope struct {
+	FolderID     string
+	CollectionID string
+}
+
+// ScopedClient is a view of the vault that only reads and writes the items
+// of one folder or collection, see BitwardenServer.Scoped.
+type ScopedClient struct {
+	bw    *BitwardenServer
+	scope Scope
+	err   error // returned by every call if the scope is invalid
+}
+
+// Scoped returns a view of the vault confined to the folder or collection of
+// scope, which limits what multi-tenant automation can touch. Lists only
+// return items of the scope, and reads, edits and deletes of other items
+// fail with ErrOutOfScope. New items are put in the scope. Membership is
+// checked on the item as returned by the server, so it is no substitute for
+// the permissions of the account.
+func (b *BitwardenServer) Scoped(scope Scope) *ScopedClient {
+	s := &ScopedClient{bw: b, scope: scope}
+	if (scope.FolderID == "") == (scope.CollectionID == "") {
+		s.err = fmt.Errorf("%w: set either a folder or a collection", ErrInvalidScope)
+	}
+	return s
+}
+
+// contains reports whether the item is in the scope.
+func (s *ScopedClient) contains(item *Item) bool {
+	if s.scope.FolderID != "" {
+		return item.FolderID != nil && *item.FolderID == s.scope.FolderID
+	}
+	return slices.Contains(item.CollectionIDs, s.scope.CollectionID) ||
+		(item.CollectionID != nil && *item.CollectionID == s.scope.CollectionID)
+}
+
+func (s *ScopedClient) outOfScope(id string) error {
+	if s.scope.FolderID != "" {
+		return fmt.Errorf("%w: item %s is not in folder %s", ErrOutOfScope, id, s.scope.FolderID)
+	}
+	return fmt.Errorf("%w: item %s is not in collection %s", ErrOutOfScope, id, s.scope.CollectionID)
+}
+
+// ListItems lists the items of the scope, see BitwardenServer.ListItems.
+func (s *ScopedClient) ListItems(ctx context.Context, opts ...ListOption) ([]Item, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	filter := InFolder(s.scope.FolderID)
+	if s.scope.CollectionID != "" {
+		filter = InCollection(s.scope.CollectionID)
+	}
+	opts = append(opts[:len(opts):len(opts)], filter, func(o *listOptions) {
+		o.filters = append(o.filters, s.contains) // in case bw ignores the filter
+	})
+	return s.bw.ListItems(ctx, opts...)
+}
+
+// GetItem returns the item if it is in the scope. It always asks the server,
+// like EditItem and DeleteItem, as the cache may not know that the item was
+// moved out of the scope.
+func (s *ScopedClient) GetItem(ctx context.Context, id string) (*Item, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	item, err := s.bw.freshItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !s.contains(item) {
+		return nil, s.outOfScope(id)
+	}
+	return item, nil
+}
+
+// Resolve returns the value ref points to if its item is in the scope.
+func (s *ScopedClient) Resolve(ctx context.Context, ref SecretRef) (string, error) {
+	if err := s.bw.authorize(ctx, ref); err != nil {
+		return "", err
+	}
+	item, err := s.GetItem(withAccess(ctx, ref), ref.ItemID)
+	if err != nil {
+		return "", err
+	}
+	if kind, name := splitSelector(ref.Selector); kind == "attachment" {
+		return s.bw.attachmentValue(ctx, item, name)
+	}
+	return item.Value(ref.Selector)
+}
+
+// CreateItem creates the item in the scope. An item without a folder, or
+// without collections, is put in the scope; an item that names another
+// folder, or collections but not the scope, is refused with ErrOutOfScope.
+// Items of a collection scope need an OrganizationID.
+func (s *ScopedClient) CreateItem(ctx context.Context, item *Item) (*Item, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	req := *item
+	if s.scope.FolderID != "" {
+		if req.FolderID == nil {
+			req.FolderID = &s.scope.FolderID
+		}
+	} else if len(req.CollectionIDs) == 0 && req.CollectionID == nil {
+		req.CollectionIDs = []string{s.scope.CollectionID}
+	}
+	if !s.contains(&req) {
+		return nil, s.outOfScope("new")
+	}
+	return s.bw.CreateItem(ctx, &req)
+}
+
+// EditItem updates the item if it is in the scope and stays in it.
+func (s *ScopedClient) EditItem(ctx context.Context, item *Item) (*Item, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if !s.contains(item) {
+		return nil, s.outOfScope(item.ID)
+	}
+	current, err := s.bw.fetchItem(ctx, item.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.contains(current) {
+		return nil, s.outOfScope(item.ID)
+	}
+	return s.bw.EditItem(ctx, item)
+}
+
+// DeleteItem moves the item to the trash if it is in the scope.
+func (s *ScopedClient) DeleteItem(ctx context.Context, id string) error {
+	if s.err != nil {
+		return s.err
+	}
+	current, err := s.bw.fetchItem(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !s.contains(current) {
+		return s.outOfScope(id)
+	}
+	return s.bw.DeleteItem(ctx, id)
+}
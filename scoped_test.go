@@ -0,0 +1,131 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestScoped(t *testing.T) {
+	folderID := "6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a"
+	collectionID := "0b7e5a4c-3d2f-4e1a-9c8b-7a6f5e4d3c2b"
+	itemID := "382a9d7b-f6b5-4eaa-92a1-1f3c7d89e48f"
+	itemRequest := mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))
+	inFolder := func(folder string) *http.Response {
+		respData := `{"data":{"id":"` + itemID + `","type":1,"name":"db","folderId":"` + folder + `","login":{"password":"hunter2"}}}`
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(respData))}
+	}
+
+	t.Run("Should list with the filter of the scope", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items?collectionid="+collectionID+"&search=db", ``))).
+			Return(listResponse(`{"id":"a","type":1,"collectionIds":["`+collectionID+`"]},{"id":"b","type":1,"collectionIds":[]}`), nil).
+			Once()
+
+		items, err := bw.Scoped(Scope{CollectionID: collectionID}).ListItems(context.Background(), Search("db"))
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		if assert.Len(t, items, 1) {
+			assert.Equal(t, "a", items[0].ID)
+		}
+	})
+
+	t.Run("Should only read items of the scope", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+		scoped := bw.Scoped(Scope{FolderID: folderID})
+
+		client.On("Do", itemRequest).Return(inFolder(folderID), nil).Once()
+		value, err := scoped.Resolve(context.Background(), SecretRef{ItemID: itemID, Selector: "password"})
+		assert.NoError(t, err)
+		assert.Equal(t, "hunter2", value)
+
+		client.On("Do", itemRequest).Return(inFolder("b4d2c1e0-8f7a-4b6c-9d5e-3f2a1b0c9d8e"), nil).Once()
+		_, err = scoped.GetItem(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrOutOfScope)
+	})
+
+	t.Run("Should not trust a cached copy", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithCache(time.Hour))
+		scoped := bw.Scoped(Scope{FolderID: folderID})
+
+		client.On("Do", itemRequest).Return(inFolder(folderID), nil).Once()
+		_, err := bw.GetItem(context.Background(), itemID)
+		assert.NoError(t, err)
+
+		client.On("Do", itemRequest).Return(inFolder("b4d2c1e0-8f7a-4b6c-9d5e-3f2a1b0c9d8e"), nil).Once()
+		_, err = scoped.GetItem(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrOutOfScope)
+	})
+
+	t.Run("Should create items in the scope", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+		name := "db"
+
+		client.
+			On("Do", mock.MatchedBy(checkRequestJSON(http.MethodPost, "http://localhost/object/item", func(body map[string]any) bool {
+				return body["folderId"] == folderID
+			}))).
+			Return(inFolder(folderID), nil).
+			Once()
+
+		_, err := bw.Scoped(Scope{FolderID: folderID}).CreateItem(context.Background(), &Item{Type: TypeLogin, Name: &name, Login: &Login{}})
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should refuse new items for another folder", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+		name, other := "db", "b4d2c1e0-8f7a-4b6c-9d5e-3f2a1b0c9d8e"
+
+		_, err := bw.Scoped(Scope{FolderID: folderID}).CreateItem(context.Background(), &Item{Type: TypeLogin, Name: &name, FolderID: &other})
+
+		client.AssertNotCalled(t, "Do", mock.Anything)
+		assert.ErrorIs(t, err, ErrOutOfScope)
+	})
+
+	t.Run("Should check the stored item before deleting", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.On("Do", itemRequest).Return(inFolder("b4d2c1e0-8f7a-4b6c-9d5e-3f2a1b0c9d8e"), nil).Once()
+
+		err := bw.Scoped(Scope{FolderID: folderID}).DeleteItem(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrOutOfScope)
+	})
+
+	t.Run("Should refuse moving items out of the scope", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+		other := "b4d2c1e0-8f7a-4b6c-9d5e-3f2a1b0c9d8e"
+
+		_, err := bw.Scoped(Scope{FolderID: folderID}).EditItem(context.Background(), &Item{ID: itemID, FolderID: &other})
+
+		client.AssertNotCalled(t, "Do", mock.Anything)
+		assert.ErrorIs(t, err, ErrOutOfScope)
+	})
+
+	t.Run("Should refuse an invalid scope", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		_, err := bw.Scoped(Scope{}).ListItems(context.Background())
+		assert.ErrorIs(t, err, ErrInvalidScope)
+		_, err = bw.Scoped(Scope{FolderID: folderID, CollectionID: collectionID}).GetItem(context.Background(), itemID)
+		assert.ErrorIs(t, err, ErrInvalidScope)
+
+		client.AssertNotCalled(t, "Do", mock.Anything)
+	})
+}
@@ -0,0 +1,259 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// SecretRefScheme starts the string form of a SecretRef, see ParseSecretRef.
+const SecretRefScheme = "bw://"
+
+var ErrInvalidSecretRef = errors.New("invalid secret reference")
+
+// SecretRef points to a single value in the vault. It is the reference taken
+// by Resolve, RunWithSecrets, DotenvMapping, ToKubernetesSecret, the ref
+// option of Decode, TemplateFuncs and secretsenv. Selector is one of
+//
+//	username, password, totp, notes, name or uri
+//	field:<name>       the custom field with the name
+//	attachment:<name>  the content of the attachment with the file name
+//
+// Any other selector is the name of a custom field, like the field option of
+// Decode.
+type SecretRef struct {
+	ItemID   string
+	Selector string
+}
+
+// Resolver resolves references, such as a BitwardenServer.
+type Resolver interface {
+	Resolve(ctx context.Context, ref SecretRef) (string, error)
+}
+
+// itemProperties are the selectors of well known item properties.
+var itemProperties = map[string]bool{"username": true, "password": true, "totp": true, "notes": true, "name": true, "uri": true}
+
+// propertyAliases are other spellings of item properties.
+var propertyAliases = map[string]string{"note": "notes"}
+
+// ParseSecretRef parses the string form of a reference, where the name of a
+// custom field or attachment is a percent-encoded path segment:
+//
+//	bw://<item id>/password
+//	bw://<item id>/field/api%20key
+//	bw://<item id>/attachment/cert.pem
+//
+// bw://<item id>/note is the same as bw://<item id>/notes. It also accepts
+// the older forms of secretsenv: bw://login/<id>/username,
+// bw://login/<id>/password, bw://login/<id>/totp, bw://note/<id> and
+// bw://item/<id>/field/<name>.
+func ParseSecretRef(s string) (SecretRef, error) {
+	invalid := fmt.Errorf("%w: %q", ErrInvalidSecretRef, s)
+	rest, ok := strings.CutPrefix(s, SecretRefScheme)
+	if !ok {
+		return SecretRef{}, invalid
+	}
+	parts := strings.Split(rest, "/")
+	for _, p := range parts {
+		if p == "" {
+			return SecretRef{}, invalid
+		}
+	}
+
+	switch {
+	case parts[0] == "login":
+		if len(parts) != 3 || (parts[2] != "username" && parts[2] != "password" && parts[2] != "totp") {
+			return SecretRef{}, invalid
+		}
+		return SecretRef{ItemID: parts[1], Selector: parts[2]}, nil
+	case parts[0] == "note":
+		if len(parts) != 2 {
+			return SecretRef{}, invalid
+		}
+		return SecretRef{ItemID: parts[1], Selector: "notes"}, nil
+	case parts[0] == "item":
+		if len(parts) != 4 || parts[2] != "field" {
+			return SecretRef{}, invalid
+		}
+		parts = parts[1:]
+	}
+
+	switch {
+	case len(parts) == 2 && itemProperties[parts[1]]:
+		return SecretRef{ItemID: parts[0], Selector: parts[1]}, nil
+	case len(parts) == 2 && propertyAliases[parts[1]] != "":
+		return SecretRef{ItemID: parts[0], Selector: propertyAliases[parts[1]]}, nil
+	case len(parts) == 3 && (parts[1] == "field" || parts[1] == "attachment"):
+		name, err := url.PathUnescape(parts[2])
+		if err != nil {
+			return SecretRef{}, invalid
+		}
+		return SecretRef{ItemID: parts[0], Selector: parts[1] + ":" + name}, nil
+	}
+	return SecretRef{}, invalid
+}
+
+// String returns the reference in the form ParseSecretRef parses.
+func (r SecretRef) String() string {
+	kind, name := splitSelector(r.Selector)
+	if kind == "" {
+		return SecretRefScheme + r.ItemID + "/" + name
+	}
+	return SecretRefScheme + r.ItemID + "/" + kind + "/" + url.PathEscape(name)
+}
+
+// Resolve returns the value the reference points to.
+func (r SecretRef) Resolve(ctx context.Context, bw Resolver) (string, error) {
+	return bw.Resolve(ctx, r)
+}
+
+// splitSelector returns the kind of the selector, which is field, attachment
+// or empty for a well known property, and the name of the property, field or
+// attachment.
+func splitSelector(selector string) (kind, name string) {
+	if itemProperties[selector] {
+		return "", selector
+	}
+	if name, ok := strings.CutPrefix(selector, "attachment:"); ok {
+		return "attachment", name
+	}
+	if name, ok := strings.CutPrefix(selector, "field:"); ok {
+		return "field", name
+	}
+	return "field", selector
+}
+
+// Resolve returns the value ref points to.
+func (b *BitwardenServer) Resolve(ctx context.Context, ref SecretRef) (string, error) {
+	d := decoder{bw: b, items: map[string]*Item{}}
+	return d.lookup(ctx, ref)
+}
+
+// TemplateFuncs returns the function bw for text/template and html/template,
+// which resolves a reference in the form of ParseSecretRef:
+//
+//	password={{ bw "bw://382a9d7b-f6b5-4eaa-92a1-1f3c7d89e48f/password" }}
+//
+// Every item is fetched once per FuncMap, however often it is referenced.
+func (b *BitwardenServer) TemplateFuncs(ctx context.Context) template.FuncMap {
+	d := decoder{bw: b, items: map[string]*Item{}}
+	var mu sync.Mutex
+	return template.FuncMap{
+		"bw": func(s string) (string, error) {
+			ref, err := ParseSecretRef(s)
+			if err != nil {
+				return "", err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			return d.lookup(ctx, ref)
+		},
+	}
+}
+
+// attachmentValue returns the content of the attachment of the item with
+// the given file name.
+func (b *BitwardenServer) attachmentValue(ctx context.Context, item *Item, fileName string) (string, error) {
+	for _, a := range item.Attachments {
+		if a.FileName != fileName {
+			continue
+		}
+		r, err := b.DownloadAttachment(ctx, item.ID, a.ID)
+		if err != nil {
+			return "", err
+		}
+		defer r.Close()
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+	return "", fmt.Errorf("%w: attachment %s", ErrFieldNotFound, fileName)
+}
+
+const resolveConcurrency = 8
+
+type resolveOptions struct {
+	concurrency int
+	partial     bool
+}
+
+// ResolveOption configures ResolveAll.
+type ResolveOption func(*resolveOptions)
+
+// WithResolveConcurrency sets how many references are resolved at the same
+// time. Defaults to 8.
+func WithResolveConcurrency(n int) ResolveOption {
+	return func(o *resolveOptions) { o.concurrency = n }
+}
+
+// WithPartialResults resolves all references even if some fail, and returns
+// the values that were resolved together with a BulkError that maps the
+// names of the others to their errors.
+func WithPartialResults() ResolveOption {
+	return func(o *resolveOptions) { o.partial = true }
+}
+
+// ResolveAll resolves the named references concurrently and returns the
+// values by the same names. By default it fails fast: the first error
+// cancels the references still being resolved and is returned without
+// values.
+func (b *BitwardenServer) ResolveAll(ctx context.Context, refs map[string]SecretRef, opts ...ResolveOption) (map[string]string, error) {
+	o := resolveOptions{concurrency: resolveConcurrency}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.concurrency < 1 {
+		o.concurrency = 1
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		values = make(map[string]string, len(refs))
+		errs   = BulkError{}
+	)
+	sem := make(chan struct{}, o.concurrency)
+	for name, ref := range refs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(name string, ref SecretRef) {
+			defer func() { <-sem; wg.Done() }()
+			if err := context.Cause(ctx); err != nil && !o.partial {
+				return
+			}
+			v, err := b.Resolve(ctx, ref)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[name] = err
+				if !o.partial {
+					cancel(fmt.Errorf("%s: %w", name, err))
+				}
+				return
+			}
+			values[name] = v
+		}(name, ref)
+	}
+	wg.Wait()
+
+	if !o.partial {
+		if err := context.Cause(ctx); err != nil {
+			return nil, err
+		}
+		return values, nil
+	}
+	if len(errs) > 0 {
+		return values, errs
+	}
+	return values, nil
+}
@@ -0,0 +1,205 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResolve(t *testing.T) {
+	itemID := "1d4cf845-8012-4b2d-a924-f9d8c9b7c44a"
+	respData := `{"data":{"id":"` + itemID + `","type":1,"login":{"username":"admin","password":"hunter2"}}}`
+
+	t.Run("Should resolve a login property", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(respData))}, nil).
+			Once()
+
+		value, err := bw.Resolve(context.Background(), SecretRef{ItemID: itemID, Selector: "password"})
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "hunter2", value)
+	})
+
+	t.Run("Should report missing fields", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(respData))}, nil).
+			Once()
+
+		_, err := bw.Resolve(context.Background(), SecretRef{ItemID: itemID, Selector: "token"})
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrFieldNotFound)
+	})
+
+	t.Run("Should resolve attachments by file name", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/item1", ``))).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"data":{"id":"item1","type":2,"attachments":[{"id":"att1","fileName":"cert.pem"}]}}`))}, nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/attachment/att1?itemid=item1", ``))).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString("-----BEGIN CERTIFICATE-----"))}, nil).
+			Once()
+
+		value, err := SecretRef{ItemID: "item1", Selector: "attachment:cert.pem"}.Resolve(context.Background(), bw)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "-----BEGIN CERTIFICATE-----", value)
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"data":{"id":"item1","type":2}}`))}, nil).
+			Once()
+		_, err = bw.Resolve(context.Background(), SecretRef{ItemID: "item1", Selector: "attachment:key.pem"})
+		assert.ErrorIs(t, err, ErrFieldNotFound)
+	})
+}
+
+func TestParseSecretRef(t *testing.T) {
+	for s, expected := range map[string]SecretRef{
+		"bw://abc/password":                {ItemID: "abc", Selector: "password"},
+		"bw://abc/notes":                   {ItemID: "abc", Selector: "notes"},
+		"bw://abc/note":                    {ItemID: "abc", Selector: "notes"},
+		"bw://abc/field/api%20key":         {ItemID: "abc", Selector: "field:api key"},
+		"bw://abc/attachment/cert.pem":     {ItemID: "abc", Selector: "attachment:cert.pem"},
+		"bw://login/abc/username":          {ItemID: "abc", Selector: "username"},
+		"bw://note/abc":                    {ItemID: "abc", Selector: "notes"},
+		"bw://item/abc/field/token":        {ItemID: "abc", Selector: "field:token"},
+		"bw://abc/field/a%2Fb":             {ItemID: "abc", Selector: "field:a/b"},
+		"bw://abc/attachment/my%20key.pem": {ItemID: "abc", Selector: "attachment:my key.pem"},
+	} {
+		t.Run("Should parse "+s, func(t *testing.T) {
+			ref, err := ParseSecretRef(s)
+
+			assert.NoError(t, err)
+			assert.Equal(t, expected, ref)
+		})
+	}
+
+	t.Run("Should reject malformed references", func(t *testing.T) {
+		for _, s := range []string{"abc/password", "bw://abc", "bw://abc/email", "bw:///password", "bw://abc/field", "bw://abc/field/%zz", "bw://login/abc/notes", "bw://note/abc/x"} {
+			_, err := ParseSecretRef(s)
+			assert.ErrorIs(t, err, ErrInvalidSecretRef, s)
+		}
+	})
+
+	t.Run("Should format references that parse back", func(t *testing.T) {
+		for _, ref := range []SecretRef{
+			{ItemID: "abc", Selector: "password"},
+			{ItemID: "abc", Selector: "field:api key"},
+			{ItemID: "abc", Selector: "attachment:cert.pem"},
+		} {
+			parsed, err := ParseSecretRef(ref.String())
+			assert.NoError(t, err)
+			assert.Equal(t, ref, parsed)
+		}
+		assert.Equal(t, "bw://abc/field/token", SecretRef{ItemID: "abc", Selector: "token"}.String())
+	})
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	itemID := "1d4cf845-8012-4b2d-a924-f9d8c9b7c44a"
+
+	t.Run("Should resolve references once per item", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"data":{"id":"` + itemID + `","type":1,"login":{"username":"admin","password":"hunter2"}}}`))}, nil).
+			Once()
+
+		tmpl := template.Must(template.New("").Funcs(bw.TemplateFuncs(context.Background())).
+			Parse(`{{ bw "bw://` + itemID + `/username" }}:{{ bw "bw://` + itemID + `/password" }}`))
+		var sb strings.Builder
+		err := tmpl.Execute(&sb, nil)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "admin:hunter2", sb.String())
+	})
+
+	t.Run("Should fail on malformed references", func(t *testing.T) {
+		bw, _ := newTestBitwarden()
+
+		tmpl := template.Must(template.New("").Funcs(bw.TemplateFuncs(context.Background())).Parse(`{{ bw "db" }}`))
+		err := tmpl.Execute(&strings.Builder{}, nil)
+
+		assert.ErrorIs(t, err, ErrInvalidSecretRef)
+	})
+}
+
+func TestResolveAll(t *testing.T) {
+	item := func(id string) *http.Response {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"data":{"id":"` + id + `","type":1,"login":{"username":"admin","password":"` + id + `-password"}}}`))}
+	}
+	refs := map[string]SecretRef{
+		"DB_USER":     {ItemID: "db", Selector: "username"},
+		"DB_PASSWORD": {ItemID: "db", Selector: "password"},
+		"API_KEY":     {ItemID: "api", Selector: "password"},
+	}
+
+	t.Run("Should resolve all references by name", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/db", ``))).Return(func(*http.Request) *http.Response { return item("db") }, nil)
+		client.On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/api", ``))).Return(item("api"), nil).Once()
+
+		values, err := bw.ResolveAll(context.Background(), refs, WithResolveConcurrency(2))
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"DB_USER": "admin", "DB_PASSWORD": "db-password", "API_KEY": "api-password"}, values)
+	})
+
+	t.Run("Should fail fast without values", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/db", ``))).Return(func(*http.Request) *http.Response { return item("db") }, nil)
+		client.On("Do", mock.Anything).Return(&http.Response{StatusCode: 404}, nil)
+
+		values, err := bw.ResolveAll(context.Background(), refs)
+
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.ErrorContains(t, err, "API_KEY")
+		assert.Nil(t, values)
+	})
+
+	t.Run("Should return partial results", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/db", ``))).Return(func(*http.Request) *http.Response { return item("db") }, nil)
+		client.On("Do", mock.Anything).Return(&http.Response{StatusCode: 404}, nil)
+
+		values, err := bw.ResolveAll(context.Background(), refs, WithPartialResults())
+
+		var bulkErr BulkError
+		assert.ErrorAs(t, err, &bulkErr)
+		assert.Equal(t, []string{"API_KEY"}, keys(bulkErr))
+		assert.Equal(t, map[string]string{"DB_USER": "admin", "DB_PASSWORD": "db-password"}, values)
+	})
+}
+
+func keys[V any](m map[string]V) []string {
+	var ks []string
+	for k := range m {
+		ks = append(ks, k)
+	}
+	return ks
+}
@@ -0,0 +1,143 @@
+// Package secretsenv resolves Bitwarden references in environment variables,
+// so configuration can hold references like bw://login/<id>/password instead
+// of the secrets themselves.
+//
+// Supported references:
+//
+//	bw://login/<id>/username
+//	bw://login/<id>/password
+//	bw://login/<id>/totp
+//	bw://note/<id>
+//	bw://item/<id>/field/<name>
+//
+// as well as the forms of bitwarden.ParseSecretRef, such as
+// bw://<id>/password and bw://<id>/field/<name>. Field names may be
+// percent-encoded.
+package secretsenv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+)
+
+const Scheme = "bw://"
+
+var ErrInvalidReference = errors.New("invalid bitwarden reference")
+
+type Client interface {
+	GetLogin(ctx context.Context, id string) (*bitwarden.Login, error)
+	GetSecureNote(ctx context.Context, id string) (string, error)
+	GetField(ctx context.Context, id string, name string) (string, error)
+}
+
+// IsReference reports whether value looks like a Bitwarden reference.
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, Scheme)
+}
+
+// ResolveValue returns the secret a single reference points to. References
+// are parsed with bitwarden.ParseSecretRef; selectors that Client cannot
+// resolve, such as attachments, are invalid.
+func ResolveValue(ctx context.Context, c Client, ref string) (string, error) {
+	r, err := bitwarden.ParseSecretRef(ref)
+	if err != nil {
+		return "", fmt.Errorf("%w: %q", ErrInvalidReference, ref)
+	}
+
+	switch r.Selector {
+	case "username", "password", "totp":
+		login, err := c.GetLogin(ctx, r.ItemID)
+		if err != nil {
+			return "", err
+		}
+		return loginValue(login, r.Selector)
+	case "notes":
+		return c.GetSecureNote(ctx, r.ItemID)
+	}
+	if name, ok := strings.CutPrefix(r.Selector, "field:"); ok {
+		return c.GetField(ctx, r.ItemID, name)
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidReference, ref)
+}
+
+func loginValue(login *bitwarden.Login, property string) (string, error) {
+	var value *string
+	switch property {
+	case "username":
+		value = login.Username
+	case "password":
+		value = login.Password
+	case "totp":
+		value = login.TOTP
+	}
+	if value == nil {
+		return "", fmt.Errorf("%w: %s", bitwarden.ErrFieldNotFound, property)
+	}
+	return *value, nil
+}
+
+// Resolve returns a copy of env in which every reference is replaced by the
+// secret it points to. Other values are copied unchanged. All failing keys
+// are reported in the returned error.
+func Resolve(ctx context.Context, c Client, env map[string]string) (map[string]string, error) {
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	resolved := make(map[string]string, len(env))
+	var errs []error
+	for _, k := range keys {
+		v := env[k]
+		if !IsReference(v) {
+			resolved[k] = v
+			continue
+		}
+		secret, err := ResolveValue(ctx, c, v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", k, err))
+			continue
+		}
+		resolved[k] = secret
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return resolved, nil
+}
+
+// ResolveEnviron resolves the references in the process environment and
+// returns the complete environment. The process environment is not changed.
+func ResolveEnviron(ctx context.Context, c Client) (map[string]string, error) {
+	env := map[string]string{}
+	for _, kv := range os.Environ() {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			env[k] = v
+		}
+	}
+	return Resolve(ctx, c, env)
+}
+
+// Load replaces every reference in the process environment by its secret.
+// Nothing is changed if any of the references fails to resolve.
+func Load(ctx context.Context, c Client) error {
+	env, err := ResolveEnviron(ctx, c)
+	if err != nil {
+		return err
+	}
+	for k, v := range env {
+		if IsReference(os.Getenv(k)) {
+			if err := os.Setenv(k, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package secretsenv
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Client = (*bitwarden.BitwardenServer)(nil)
+
+type fakeClient struct {
+	logins map[string]*bitwarden.Login
+	notes  map[string]string
+	fields map[string]map[string]string
+}
+
+func (f fakeClient) GetLogin(_ context.Context, id string) (*bitwarden.Login, error) {
+	if l, ok := f.logins[id]; ok {
+		return l, nil
+	}
+	return nil, bitwarden.ErrNotFound
+}
+
+func (f fakeClient) GetSecureNote(_ context.Context, id string) (string, error) {
+	if n, ok := f.notes[id]; ok {
+		return n, nil
+	}
+	return "", bitwarden.ErrNotFound
+}
+
+func (f fakeClient) GetField(_ context.Context, id string, name string) (string, error) {
+	fields, ok := f.fields[id]
+	if !ok {
+		return "", bitwarden.ErrNotFound
+	}
+	if v, ok := fields[name]; ok {
+		return v, nil
+	}
+	return "", bitwarden.ErrFieldNotFound
+}
+
+func ptr(s string) *string { return &s }
+
+func newFakeClient() fakeClient {
+	return fakeClient{
+		logins: map[string]*bitwarden.Login{"db": {Username: ptr("admin"), Password: ptr("hunter2")}},
+		notes:  map[string]string{"env": "A=B"},
+		fields: map[string]map[string]string{"api": {"token": "t0k3n", "api key": "k3y"}},
+	}
+}
+
+func TestResolveValue(t *testing.T) {
+	c := newFakeClient()
+
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{"bw://login/db/username", "admin"},
+		{"bw://login/db/password", "hunter2"},
+		{"bw://note/env", "A=B"},
+		{"bw://item/api/field/token", "t0k3n"},
+		{"bw://item/api/field/api%20key", "k3y"},
+		{"bw://db/password", "hunter2"},
+		{"bw://api/field/token", "t0k3n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := ResolveValue(context.Background(), c, tt.ref)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("Should reject malformed references", func(t *testing.T) {
+		for _, ref := range []string{"db", "bw://login/db", "bw://login/db/email", "bw://item/api/token", "bw://unknown/x", "bw://api/attachment/cert.pem"} {
+			_, err := ResolveValue(context.Background(), c, ref)
+			assert.ErrorIs(t, err, ErrInvalidReference, ref)
+		}
+	})
+
+	t.Run("Should report empty login properties", func(t *testing.T) {
+		_, err := ResolveValue(context.Background(), c, "bw://login/db/totp")
+		assert.ErrorIs(t, err, bitwarden.ErrFieldNotFound)
+	})
+
+	t.Run("Should return client errors", func(t *testing.T) {
+		_, err := ResolveValue(context.Background(), c, "bw://login/missing/password")
+		assert.ErrorIs(t, err, bitwarden.ErrNotFound)
+	})
+}
+
+func TestResolve(t *testing.T) {
+	c := newFakeClient()
+
+	t.Run("Should only replace references", func(t *testing.T) {
+		env, err := Resolve(context.Background(), c, map[string]string{
+			"DB_USER":     "bw://login/db/username",
+			"DB_PASSWORD": "bw://login/db/password",
+			"DB_HOST":     "localhost",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"DB_USER": "admin", "DB_PASSWORD": "hunter2", "DB_HOST": "localhost"}, env)
+	})
+
+	t.Run("Should report every failing key", func(t *testing.T) {
+		_, err := Resolve(context.Background(), c, map[string]string{
+			"A": "bw://note/missing",
+			"B": "bw://login/db/email",
+		})
+
+		assert.ErrorIs(t, err, bitwarden.ErrNotFound)
+		assert.ErrorIs(t, err, ErrInvalidReference)
+		assert.ErrorContains(t, err, "A: ")
+		assert.ErrorContains(t, err, "B: ")
+	})
+}
+
+func TestLoad(t *testing.T) {
+	c := newFakeClient()
+
+	t.Run("Should replace references in the process environment", func(t *testing.T) {
+		t.Setenv("SECRETSENV_TEST_PASSWORD", "bw://login/db/password")
+		t.Setenv("SECRETSENV_TEST_PLAIN", "plain")
+
+		assert.NoError(t, Load(context.Background(), c))
+		assert.Equal(t, "hunter2", os.Getenv("SECRETSENV_TEST_PASSWORD"))
+		assert.Equal(t, "plain", os.Getenv("SECRETSENV_TEST_PLAIN"))
+	})
+
+	t.Run("Should leave the environment alone on errors", func(t *testing.T) {
+		t.Setenv("SECRETSENV_TEST_PASSWORD", "bw://login/db/password")
+		t.Setenv("SECRETSENV_TEST_MISSING", "bw://note/missing")
+
+		assert.Error(t, Load(context.Background(), c))
+		assert.Equal(t, "bw://login/db/password", os.Getenv("SECRETSENV_TEST_PASSWORD"))
+	})
+}
@@ -0,0 +1,55 @@
+package secretsmanager
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	"github.com/floriaanpost/go-bitwarden-client/internal/encstring"
+	"golang.org/x/crypto/hkdf"
+)
+
+// symmetricKey is an AES-256 key followed by its HMAC-SHA256 key, which
+// encrypts Secrets Manager data as encstring.TypeAesCbc256HmacSha256.
+type symmetricKey struct {
+	key []byte
+}
+
+func newSymmetricKey(b []byte) (*symmetricKey, error) {
+	if len(b) != encstring.KeySize {
+		return nil, fmt.Errorf("%w: key is %d bytes", ErrDecrypt, len(b))
+	}
+	return &symmetricKey{key: b}, nil
+}
+
+// deriveShareableKey derives the key that protects the token response from
+// the secret in an access token.
+func deriveShareableKey(secret []byte, name, info string) (*symmetricKey, error) {
+	h := hmac.New(sha256.New, []byte("bitwarden-"+name))
+	h.Write(secret)
+	key := make([]byte, encstring.KeySize)
+	if _, err := io.ReadFull(hkdf.Expand(sha256.New, h.Sum(nil), []byte(info)), key); err != nil {
+		return nil, err
+	}
+	return newSymmetricKey(key)
+}
+
+// decrypt decrypts an encrypted string of the form "2.<iv>|<data>|<mac>".
+func (k *symmetricKey) decrypt(s string) ([]byte, error) {
+	return encstring.Decrypt(k.key, s)
+}
+
+func (k *symmetricKey) decryptString(s string) (string, error) {
+	b, err := k.decrypt(s)
+	return string(b), err
+}
+
+// encrypt encrypts plain into an encrypted string.
+func (k *symmetricKey) encrypt(plain []byte) (string, error) {
+	return encstring.Encrypt(k.key, plain)
+}
+
+func (k *symmetricKey) encryptString(s string) (string, error) {
+	return k.encrypt([]byte(s))
+}
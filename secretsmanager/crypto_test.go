@@ -0,0 +1,67 @@
+package secretsmanager
+
+import (
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestKey(t *testing.T) *symmetricKey {
+	b := make([]byte, 64)
+	_, err := rand.Read(b)
+	assert.NoError(t, err)
+	key, err := newSymmetricKey(b)
+	assert.NoError(t, err)
+	return key
+}
+
+func TestSymmetricKey(t *testing.T) {
+	t.Run("Should decrypt what it encrypted", func(t *testing.T) {
+		key := newTestKey(t)
+
+		for _, plain := range []string{"", "hunter2", "exactly 16 bytes"} {
+			enc, err := key.encryptString(plain)
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(enc, "2."))
+			dec, err := key.decryptString(enc)
+			assert.NoError(t, err)
+			assert.Equal(t, plain, dec)
+		}
+	})
+
+	t.Run("Should reject data encrypted with another key", func(t *testing.T) {
+		enc, err := newTestKey(t).encryptString("hunter2")
+		assert.NoError(t, err)
+
+		_, err = newTestKey(t).decryptString(enc)
+
+		assert.ErrorIs(t, err, ErrDecrypt)
+	})
+
+	t.Run("Should reject unsupported and malformed strings", func(t *testing.T) {
+		key := newTestKey(t)
+
+		for _, s := range []string{"", "0.abc|def", "2.abc", "2.!|!|!"} {
+			_, err := key.decryptString(s)
+			assert.ErrorIs(t, err, ErrDecrypt, s)
+		}
+	})
+}
+
+func TestDeriveShareableKey(t *testing.T) {
+	t.Run("Should derive the same key for the same secret", func(t *testing.T) {
+		secret := []byte("&/$%F1a895g67HlX")
+
+		a, err := deriveShareableKey(secret, "accesstoken", "sm-access-token")
+		assert.NoError(t, err)
+		b, err := deriveShareableKey(secret, "accesstoken", "sm-access-token")
+		assert.NoError(t, err)
+		c, err := deriveShareableKey(secret, "accesstoken", "other")
+		assert.NoError(t, err)
+
+		assert.Equal(t, a, b)
+		assert.NotEqual(t, a, c)
+	})
+}
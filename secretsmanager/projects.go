@@ -0,0 +1,111 @@
+package secretsmanager
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type Project struct {
+	ID             string
+	OrganizationID string
+	Name           string
+	CreationDate   time.Time
+	RevisionDate   time.Time
+}
+
+type projectResponse struct {
+	ID             string    `json:"id"`
+	OrganizationID string    `json:"organizationId"`
+	Name           string    `json:"name"`
+	CreationDate   time.Time `json:"creationDate"`
+	RevisionDate   time.Time `json:"revisionDate"`
+}
+
+func (r *projectResponse) decrypt(key *symmetricKey) (*Project, error) {
+	name, err := key.decryptString(r.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &Project{ID: r.ID, OrganizationID: r.OrganizationID, Name: name, CreationDate: r.CreationDate, RevisionDate: r.RevisionDate}, nil
+}
+
+// ListProjects returns the projects the machine account can access.
+func (c *Client) ListProjects(ctx context.Context) ([]Project, error) {
+	orgID, key, err := c.session()
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Data []projectResponse `json:"data"`
+	}
+	if err := c.request(ctx, http.MethodGet, "/organizations/"+orgID+"/projects", nil, &resp); err != nil {
+		return nil, err
+	}
+	projects := make([]Project, 0, len(resp.Data))
+	for _, r := range resp.Data {
+		p, err := r.decrypt(key)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, *p)
+	}
+	return projects, nil
+}
+
+// GetProject returns a single project.
+func (c *Client) GetProject(ctx context.Context, id string) (*Project, error) {
+	_, key, err := c.session()
+	if err != nil {
+		return nil, err
+	}
+	var resp projectResponse
+	if err := c.request(ctx, http.MethodGet, "/projects/"+id, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.decrypt(key)
+}
+
+// CreateProject creates a project.
+func (c *Client) CreateProject(ctx context.Context, name string) (*Project, error) {
+	orgID, key, err := c.session()
+	if err != nil {
+		return nil, err
+	}
+	return c.saveProject(ctx, http.MethodPost, "/organizations/"+orgID+"/projects", key, name)
+}
+
+// UpdateProject renames a project.
+func (c *Client) UpdateProject(ctx context.Context, id string, name string) (*Project, error) {
+	_, key, err := c.session()
+	if err != nil {
+		return nil, err
+	}
+	return c.saveProject(ctx, http.MethodPut, "/projects/"+id, key, name)
+}
+
+func (c *Client) saveProject(ctx context.Context, method, endpoint string, key *symmetricKey, name string) (*Project, error) {
+	encName, err := key.encryptString(name)
+	if err != nil {
+		return nil, err
+	}
+	req := struct {
+		Name string `json:"name"`
+	}{Name: encName}
+
+	var resp projectResponse
+	if err := c.request(ctx, method, endpoint, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.decrypt(key)
+}
+
+// DeleteProjects deletes the projects. Projects that could not be deleted
+// are reported in the returned error.
+func (c *Client) DeleteProjects(ctx context.Context, ids ...string) error {
+	var resp bulkResult
+	if err := c.request(ctx, http.MethodPost, "/projects/delete", ids, &resp); err != nil {
+		return err
+	}
+	return resp.err()
+}
@@ -0,0 +1,85 @@
+package secretsmanager
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListProjects(t *testing.T) {
+	t.Run("Should list the projects with decrypted names", func(t *testing.T) {
+		var key *symmetricKey
+		c, key := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/organizations/"+testOrgID+"/projects", r.URL.Path)
+			json.NewEncoder(w).Encode(map[string]any{"data": []map[string]any{
+				{"id": "p1", "organizationId": testOrgID, "name": encrypt(t, key, "backend")},
+			}})
+		})
+
+		projects, err := c.ListProjects(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Project{{ID: "p1", OrganizationID: testOrgID, Name: "backend"}}, projects)
+	})
+}
+
+func TestGetProject(t *testing.T) {
+	t.Run("Should return the project", func(t *testing.T) {
+		var key *symmetricKey
+		c, key := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/projects/p1", r.URL.Path)
+			json.NewEncoder(w).Encode(map[string]any{"id": "p1", "name": encrypt(t, key, "backend")})
+		})
+
+		project, err := c.GetProject(context.Background(), "p1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "backend", project.Name)
+	})
+}
+
+func TestSaveProject(t *testing.T) {
+	echo := func(t *testing.T, method, path string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, method, r.Method)
+			assert.Equal(t, path, r.URL.Path)
+			var req map[string]string
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			json.NewEncoder(w).Encode(map[string]any{"id": "p2", "name": req["name"]})
+		}
+	}
+
+	t.Run("Should create the project", func(t *testing.T) {
+		c, _ := newTestClient(t, echo(t, http.MethodPost, "/organizations/"+testOrgID+"/projects"))
+
+		project, err := c.CreateProject(context.Background(), "frontend")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "frontend", project.Name)
+	})
+
+	t.Run("Should rename the project", func(t *testing.T) {
+		c, _ := newTestClient(t, echo(t, http.MethodPut, "/projects/p2"))
+
+		project, err := c.UpdateProject(context.Background(), "p2", "web")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "web", project.Name)
+	})
+}
+
+func TestDeleteProjects(t *testing.T) {
+	t.Run("Should delete the projects", func(t *testing.T) {
+		c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/projects/delete", r.URL.Path)
+			w.Write([]byte(`{"data":[{"id":"p1","error":null}]}`))
+		})
+
+		err := c.DeleteProjects(context.Background(), "p1")
+
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,212 @@
+package secretsmanager
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+)
+
+// SecretIdentifier is a secret as listed, without its value.
+type SecretIdentifier struct {
+	ID             string
+	OrganizationID string
+	Key            string
+}
+
+type Secret struct {
+	ID             string
+	OrganizationID string
+	Key            string
+	Value          string
+	Note           string
+	ProjectIDs     []string
+	CreationDate   time.Time
+	RevisionDate   time.Time
+}
+
+// SecretInput holds the fields of a secret to create or update.
+type SecretInput struct {
+	Key        string
+	Value      string
+	Note       string
+	ProjectIDs []string
+}
+
+type secretResponse struct {
+	ID             string    `json:"id"`
+	OrganizationID string    `json:"organizationId"`
+	Key            string    `json:"key"`
+	Value          string    `json:"value"`
+	Note           string    `json:"note"`
+	CreationDate   time.Time `json:"creationDate"`
+	RevisionDate   time.Time `json:"revisionDate"`
+	Projects       []struct {
+		ID string `json:"id"`
+	} `json:"projects"`
+}
+
+func (r *secretResponse) decrypt(key *symmetricKey) (*Secret, error) {
+	s := Secret{ID: r.ID, OrganizationID: r.OrganizationID, CreationDate: r.CreationDate, RevisionDate: r.RevisionDate}
+	for _, f := range []struct {
+		dst *string
+		src string
+	}{{&s.Key, r.Key}, {&s.Value, r.Value}, {&s.Note, r.Note}} {
+		if f.src == "" {
+			continue
+		}
+		v, err := key.decryptString(f.src)
+		if err != nil {
+			return nil, err
+		}
+		*f.dst = v
+	}
+	for _, p := range r.Projects {
+		s.ProjectIDs = append(s.ProjectIDs, p.ID)
+	}
+	return &s, nil
+}
+
+type secretRequest struct {
+	Key        string   `json:"key"`
+	Value      string   `json:"value"`
+	Note       string   `json:"note"`
+	ProjectIDs []string `json:"projectIds"`
+}
+
+func newSecretRequest(key *symmetricKey, in SecretInput) (*secretRequest, error) {
+	req := secretRequest{ProjectIDs: in.ProjectIDs}
+	for _, f := range []struct {
+		dst *string
+		src string
+	}{{&req.Key, in.Key}, {&req.Value, in.Value}, {&req.Note, in.Note}} {
+		v, err := key.encryptString(f.src)
+		if err != nil {
+			return nil, err
+		}
+		*f.dst = v
+	}
+	return &req, nil
+}
+
+// ListSecrets returns the secrets the machine account can access.
+func (c *Client) ListSecrets(ctx context.Context) ([]SecretIdentifier, error) {
+	orgID, key, err := c.session()
+	if err != nil {
+		return nil, err
+	}
+	return c.listSecrets(ctx, "/organizations/"+orgID+"/secrets", key)
+}
+
+func (c *Client) listSecrets(ctx context.Context, endpoint string, key *symmetricKey) ([]SecretIdentifier, error) {
+	var resp struct {
+		Secrets []secretResponse `json:"secrets"`
+	}
+	if err := c.request(ctx, http.MethodGet, endpoint, nil, &resp); err != nil {
+		return nil, err
+	}
+	secrets := make([]SecretIdentifier, 0, len(resp.Secrets))
+	for _, s := range resp.Secrets {
+		k, err := key.decryptString(s.Key)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, SecretIdentifier{ID: s.ID, OrganizationID: s.OrganizationID, Key: k})
+	}
+	return secrets, nil
+}
+
+// ListProjectSecrets returns the secrets in a project.
+func (c *Client) ListProjectSecrets(ctx context.Context, projectID string) ([]SecretIdentifier, error) {
+	_, key, err := c.session()
+	if err != nil {
+		return nil, err
+	}
+	return c.listSecrets(ctx, "/projects/"+projectID+"/secrets", key)
+}
+
+// GetSecretByKey returns the secret with the given key in a project, or in
+// any accessible project if projectID is empty. Keys are not unique, so it
+// returns ErrAmbiguousKey if more than one secret has the key.
+func (c *Client) GetSecretByKey(ctx context.Context, projectID, key string) (*Secret, error) {
+	var secrets []SecretIdentifier
+	var err error
+	if projectID == "" {
+		secrets, err = c.ListSecrets(ctx)
+	} else {
+		secrets, err = c.ListProjectSecrets(ctx, projectID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, s := range secrets {
+		if s.Key == key {
+			ids = append(ids, s.ID)
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return nil, fmt.Errorf("%w: %s", bitwarden.ErrNotFound, key)
+	case 1:
+		return c.GetSecret(ctx, ids[0])
+	}
+	return nil, fmt.Errorf("%w: %s matches %s", ErrAmbiguousKey, key, strings.Join(ids, ", "))
+}
+
+// GetSecret returns a secret with its value.
+func (c *Client) GetSecret(ctx context.Context, id string) (*Secret, error) {
+	_, key, err := c.session()
+	if err != nil {
+		return nil, err
+	}
+	var resp secretResponse
+	if err := c.request(ctx, http.MethodGet, "/secrets/"+id, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.decrypt(key)
+}
+
+// CreateSecret creates a secret.
+func (c *Client) CreateSecret(ctx context.Context, in SecretInput) (*Secret, error) {
+	orgID, key, err := c.session()
+	if err != nil {
+		return nil, err
+	}
+	return c.saveSecret(ctx, http.MethodPost, "/organizations/"+orgID+"/secrets", key, in)
+}
+
+// UpdateSecret replaces all fields of a secret.
+func (c *Client) UpdateSecret(ctx context.Context, id string, in SecretInput) (*Secret, error) {
+	_, key, err := c.session()
+	if err != nil {
+		return nil, err
+	}
+	return c.saveSecret(ctx, http.MethodPut, "/secrets/"+id, key, in)
+}
+
+func (c *Client) saveSecret(ctx context.Context, method, endpoint string, key *symmetricKey, in SecretInput) (*Secret, error) {
+	req, err := newSecretRequest(key, in)
+	if err != nil {
+		return nil, err
+	}
+	var resp secretResponse
+	if err := c.request(ctx, method, endpoint, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.decrypt(key)
+}
+
+// DeleteSecrets deletes the secrets. Secrets that could not be deleted are
+// reported in the returned error.
+func (c *Client) DeleteSecrets(ctx context.Context, ids ...string) error {
+	var resp bulkResult
+	if err := c.request(ctx, http.MethodPost, "/secrets/delete", ids, &resp); err != nil {
+		return err
+	}
+	return resp.err()
+}
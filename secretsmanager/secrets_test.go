@@ -0,0 +1,178 @@
+package secretsmanager
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListSecrets(t *testing.T) {
+	t.Run("Should list the secrets with decrypted keys", func(t *testing.T) {
+		var key *symmetricKey
+		c, key := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/organizations/"+testOrgID+"/secrets", r.URL.Path)
+			json.NewEncoder(w).Encode(map[string]any{"secrets": []map[string]any{
+				{"id": "s1", "organizationId": testOrgID, "key": encrypt(t, key, "DB_PASSWORD")},
+			}})
+		})
+
+		secrets, err := c.ListSecrets(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []SecretIdentifier{{ID: "s1", OrganizationID: testOrgID, Key: "DB_PASSWORD"}}, secrets)
+	})
+}
+
+func TestListProjectSecrets(t *testing.T) {
+	t.Run("Should list the secrets in the project", func(t *testing.T) {
+		var key *symmetricKey
+		c, key := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/projects/p1/secrets", r.URL.Path)
+			json.NewEncoder(w).Encode(map[string]any{"secrets": []map[string]any{
+				{"id": "s1", "organizationId": testOrgID, "key": encrypt(t, key, "DB_PASSWORD")},
+			}})
+		})
+
+		secrets, err := c.ListProjectSecrets(context.Background(), "p1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []SecretIdentifier{{ID: "s1", OrganizationID: testOrgID, Key: "DB_PASSWORD"}}, secrets)
+	})
+}
+
+func TestGetSecretByKey(t *testing.T) {
+	handler := func(t *testing.T, key **symmetricKey) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/projects/p1/secrets":
+				json.NewEncoder(w).Encode(map[string]any{"secrets": []map[string]any{
+					{"id": "s1", "key": encrypt(t, *key, "DB_PASSWORD")},
+					{"id": "s2", "key": encrypt(t, *key, "API_TOKEN")},
+				}})
+			case "/organizations/" + testOrgID + "/secrets":
+				json.NewEncoder(w).Encode(map[string]any{"secrets": []map[string]any{
+					{"id": "s1", "key": encrypt(t, *key, "DB_PASSWORD")},
+					{"id": "s3", "key": encrypt(t, *key, "DB_PASSWORD")},
+				}})
+			case "/secrets/s1":
+				json.NewEncoder(w).Encode(map[string]any{"id": "s1", "key": encrypt(t, *key, "DB_PASSWORD"), "value": encrypt(t, *key, "hunter2")})
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}
+	}
+
+	t.Run("Should return the secret with the key in the project", func(t *testing.T) {
+		var key *symmetricKey
+		c, key := newTestClient(t, handler(t, &key))
+
+		secret, err := c.GetSecretByKey(context.Background(), "p1", "DB_PASSWORD")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "hunter2", secret.Value)
+	})
+
+	t.Run("Should return not found for unknown keys", func(t *testing.T) {
+		var key *symmetricKey
+		c, key := newTestClient(t, handler(t, &key))
+
+		_, err := c.GetSecretByKey(context.Background(), "p1", "MISSING")
+
+		assert.ErrorIs(t, err, bitwarden.ErrNotFound)
+	})
+
+	t.Run("Should reject keys used by more than one secret", func(t *testing.T) {
+		var key *symmetricKey
+		c, key := newTestClient(t, handler(t, &key))
+
+		_, err := c.GetSecretByKey(context.Background(), "", "DB_PASSWORD")
+
+		assert.ErrorIs(t, err, ErrAmbiguousKey)
+		assert.EqualError(t, err, "more than one secret with key: DB_PASSWORD matches s1, s3")
+	})
+}
+
+func TestGetSecret(t *testing.T) {
+	t.Run("Should return the decrypted secret", func(t *testing.T) {
+		var key *symmetricKey
+		c, key := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/secrets/s1", r.URL.Path)
+			json.NewEncoder(w).Encode(map[string]any{
+				"id":             "s1",
+				"organizationId": testOrgID,
+				"key":            encrypt(t, key, "DB_PASSWORD"),
+				"value":          encrypt(t, key, "hunter2"),
+				"note":           encrypt(t, key, "rotated monthly"),
+				"projects":       []map[string]any{{"id": "p1", "name": encrypt(t, key, "backend")}},
+			})
+		})
+
+		secret, err := c.GetSecret(context.Background(), "s1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "DB_PASSWORD", secret.Key)
+		assert.Equal(t, "hunter2", secret.Value)
+		assert.Equal(t, "rotated monthly", secret.Note)
+		assert.Equal(t, []string{"p1"}, secret.ProjectIDs)
+	})
+}
+
+func TestCreateSecret(t *testing.T) {
+	t.Run("Should send the encrypted secret", func(t *testing.T) {
+		var key *symmetricKey
+		c, key := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/organizations/"+testOrgID+"/secrets", r.URL.Path)
+			var req secretRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			value, err := key.decryptString(req.Value)
+			assert.NoError(t, err)
+			assert.Equal(t, "hunter2", value)
+			assert.Equal(t, []string{"p1"}, req.ProjectIDs)
+			json.NewEncoder(w).Encode(map[string]any{"id": "s2", "key": req.Key, "value": req.Value, "note": req.Note})
+		})
+
+		secret, err := c.CreateSecret(context.Background(), SecretInput{Key: "DB_PASSWORD", Value: "hunter2", ProjectIDs: []string{"p1"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "s2", secret.ID)
+		assert.Equal(t, "DB_PASSWORD", secret.Key)
+	})
+}
+
+func TestUpdateSecret(t *testing.T) {
+	t.Run("Should replace the secret", func(t *testing.T) {
+		c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "/secrets/s1", r.URL.Path)
+			var req secretRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			json.NewEncoder(w).Encode(map[string]any{"id": "s1", "key": req.Key, "value": req.Value, "note": req.Note})
+		})
+
+		secret, err := c.UpdateSecret(context.Background(), "s1", SecretInput{Key: "DB_PASSWORD", Value: "correct horse"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "correct horse", secret.Value)
+	})
+}
+
+func TestDeleteSecrets(t *testing.T) {
+	t.Run("Should report secrets that were not deleted", func(t *testing.T) {
+		c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/secrets/delete", r.URL.Path)
+			var ids []string
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&ids))
+			assert.Equal(t, []string{"s1", "s2"}, ids)
+			w.Write([]byte(`{"data":[{"id":"s1","error":null},{"id":"s2","error":"access denied"}]}`))
+		})
+
+		err := c.DeleteSecrets(context.Background(), "s1", "s2")
+
+		assert.EqualError(t, err, "s2: access denied")
+	})
+}
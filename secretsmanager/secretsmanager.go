@@ -0,0 +1,208 @@
+// Package secretsmanager is a client for Bitwarden Secrets Manager. It
+// authenticates with a machine account access token and decrypts secrets
+// locally, just like the bws command line tool:
+//
+//	c, err := secretsmanager.New(os.Getenv("BWS_ACCESS_TOKEN"))
+//	...
+//	secret, err := c.GetSecret(ctx, id)
+//
+// Failed requests return a *bitwarden.APIError, like the vault client, so
+// bitwarden.ErrNotFound and friends can be checked with errors.Is for both.
+package secretsmanager
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/floriaanpost/go-bitwarden-client/internal/cloudapi"
+	"github.com/floriaanpost/go-bitwarden-client/internal/encstring"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	defaultAPIURL      = "https://api.bitwarden.com"
+	defaultIdentityURL = "https://identity.bitwarden.com"
+	scope              = "api.secrets"
+)
+
+var (
+	ErrInvalidAccessToken = errors.New("invalid access token")
+	ErrDecrypt            = encstring.ErrDecrypt // the same as bitwarden.ErrDecrypt
+	ErrAmbiguousKey       = errors.New("more than one secret with key")
+)
+
+type client interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type Client struct {
+	apiURL      string
+	identityURL string
+	httpClient  *http.Client
+	client      client
+	tokens      oauth2.TokenSource
+	tokenKey    *symmetricKey
+
+	mu    sync.Mutex
+	orgID string
+	key   *symmetricKey
+}
+
+// Option configures optional behaviour of a Client.
+type Option func(*Client)
+
+// WithServer points the client to a self-hosted server, which serves the API
+// under /api and the identity service under /identity.
+func WithServer(url string) Option {
+	url = strings.TrimSuffix(url, "/")
+	return WithURLs(url+"/api", url+"/identity")
+}
+
+// WithURLs sets the API and identity URLs separately, for example for the
+// EU cloud (https://api.bitwarden.eu and https://identity.bitwarden.eu).
+func WithURLs(apiURL, identityURL string) Option {
+	return func(c *Client) {
+		c.apiURL = strings.TrimSuffix(apiURL, "/")
+		c.identityURL = strings.TrimSuffix(identityURL, "/")
+	}
+}
+
+// WithHTTPClient sets the client used for API and token requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// New returns a client for the machine account the access token belongs to.
+// Nothing is requested until the first call.
+func New(accessToken string, opts ...Option) (*Client, error) {
+	id, secret, key, err := parseAccessToken(accessToken)
+	if err != nil {
+		return nil, err
+	}
+	tokenKey, err := deriveShareableKey(key, "accesstoken", "sm-access-token")
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{apiURL: defaultAPIURL, identityURL: defaultIdentityURL, tokenKey: tokenKey}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	cfg := clientcredentials.Config{
+		ClientID:     id,
+		ClientSecret: secret,
+		TokenURL:     c.identityURL + "/connect/token",
+		Scopes:       []string{scope},
+		AuthStyle:    oauth2.AuthStyleInParams,
+	}
+	ctx := context.Background()
+	if c.httpClient != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, c.httpClient)
+	}
+	c.tokens = cfg.TokenSource(ctx)
+	c.client = oauth2.NewClient(ctx, c.tokens)
+	return c, nil
+}
+
+// parseAccessToken splits an access token of the form
+// "0.<id>.<secret>:<key>".
+func parseAccessToken(token string) (id, secret string, key []byte, err error) {
+	creds, encodedKey, ok := strings.Cut(token, ":")
+	parts := strings.Split(creds, ".")
+	if !ok || len(parts) != 3 || parts[0] != "0" || parts[1] == "" || parts[2] == "" {
+		return "", "", nil, ErrInvalidAccessToken
+	}
+	key, err = base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil || len(key) != 16 {
+		return "", "", nil, ErrInvalidAccessToken
+	}
+	return parts[1], parts[2], key, nil
+}
+
+// session returns the organization of the machine account and its key, which
+// are sent encrypted along with the first access token.
+func (c *Client) session() (string, *symmetricKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.key != nil {
+		return c.orgID, c.key, nil
+	}
+	token, err := c.tokens.Token()
+	if err != nil {
+		return "", nil, err
+	}
+
+	payload, _ := token.Extra("encrypted_payload").(string)
+	plain, err := c.tokenKey.decrypt(payload)
+	if err != nil {
+		return "", nil, err
+	}
+	var p struct {
+		EncryptionKey []byte `json:"encryptionKey"`
+	}
+	if err := json.Unmarshal(plain, &p); err != nil {
+		return "", nil, fmt.Errorf("%w: %w", ErrDecrypt, err)
+	}
+	key, err := newSymmetricKey(p.EncryptionKey)
+	if err != nil {
+		return "", nil, err
+	}
+
+	orgID, err := organizationClaim(token.AccessToken)
+	if err != nil {
+		return "", nil, err
+	}
+	c.orgID, c.key = orgID, key
+	return orgID, key, nil
+}
+
+// organizationClaim reads the organization from the access token, a JWT that
+// is trusted as it comes straight from the identity server.
+func organizationClaim(jwt string) (string, error) {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		return "", ErrInvalidAccessToken
+	}
+	data, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", ErrInvalidAccessToken
+	}
+	var claims struct {
+		Organization string `json:"organization"`
+	}
+	if err := json.Unmarshal(data, &claims); err != nil || claims.Organization == "" {
+		return "", ErrInvalidAccessToken
+	}
+	return claims.Organization, nil
+}
+
+func (c *Client) request(ctx context.Context, method string, endpoint string, req any, resp any) error {
+	return cloudapi.Request(ctx, c.client, c.apiURL, method, endpoint, req, resp)
+}
+
+// bulkResult is the per ID result of bulk deletes.
+type bulkResult struct {
+	Data []struct {
+		ID    string  `json:"id"`
+		Error *string `json:"error"`
+	} `json:"data"`
+}
+
+func (r bulkResult) err() error {
+	var errs []error
+	for _, d := range r.Data {
+		if d.Error != nil && *d.Error != "" {
+			errs = append(errs, fmt.Errorf("%s: %s", d.ID, *d.Error))
+		}
+	}
+	return errors.Join(errs...)
+}
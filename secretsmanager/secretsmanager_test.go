@@ -0,0 +1,92 @@
+package secretsmanager
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+	"github.com/stretchr/testify/assert"
+)
+
+const testOrgID = "0b7f1c63-8a0d-4e57-a7c4-2f5b1d3e9c10"
+
+// newTestClient returns a client for a server that issues tokens for the
+// test organization and answers API requests with handler, which is called
+// with the path below /api. The returned key is the organization key.
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, *symmetricKey) {
+	secret := make([]byte, 16)
+	_, err := rand.Read(secret)
+	assert.NoError(t, err)
+	tokenKey, err := deriveShareableKey(secret, "accesstoken", "sm-access-token")
+	assert.NoError(t, err)
+	orgKey := newTestKey(t)
+	payload, err := json.Marshal(map[string][]byte{"encryptionKey": orgKey.key})
+	assert.NoError(t, err)
+	encPayload, err := tokenKey.encrypt(payload)
+	assert.NoError(t, err)
+	claims := base64.RawURLEncoding.EncodeToString([]byte(`{"organization":"` + testOrgID + `"}`))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/identity/connect/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("grant_type") != "client_credentials" || r.FormValue("client_id") != "machine" ||
+			r.FormValue("client_secret") != "secret" || r.FormValue("scope") != scope {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token":      "e30." + claims + ".sig",
+			"token_type":        "Bearer",
+			"expires_in":        3600,
+			"encrypted_payload": encPayload,
+		})
+	})
+	mux.Handle("/api/", http.StripPrefix("/api", handler))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	c, err := New("0.machine.secret:"+base64.StdEncoding.EncodeToString(secret), WithServer(server.URL))
+	assert.NoError(t, err)
+	return c, orgKey
+}
+
+func encrypt(t *testing.T, key *symmetricKey, s string) string {
+	enc, err := key.encryptString(s)
+	assert.NoError(t, err)
+	return enc
+}
+
+func TestNew(t *testing.T) {
+	t.Run("Should reject malformed access tokens", func(t *testing.T) {
+		for _, token := range []string{
+			"",
+			"0.machine.secret",
+			"1.machine.secret:AAAAAAAAAAAAAAAAAAAAAA==",
+			"0.machine:AAAAAAAAAAAAAAAAAAAAAA==",
+			"0.machine.secret:AAAA",
+		} {
+			_, err := New(token)
+			assert.ErrorIs(t, err, ErrInvalidAccessToken, token)
+		}
+	})
+}
+
+func TestRequest(t *testing.T) {
+	t.Run("Should map status codes to the vault client errors", func(t *testing.T) {
+		c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message":"Resource not found."}`)
+		})
+
+		_, err := c.GetSecret(context.Background(), "missing")
+
+		assert.ErrorIs(t, err, bitwarden.ErrNotFound)
+		assert.EqualError(t, err, "GET /secrets/missing: item not found: Resource not found.")
+	})
+}
@@ -0,0 +1,48 @@
+package secretsmanager
+
+import (
+	"context"
+	"fmt"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+)
+
+var _ bitwarden.SecretSource = (*Client)(nil)
+
+// GetLogin returns the secret as a login with the key as username and the
+// value as password, so a Client can be used as a bitwarden.SecretSource.
+func (c *Client) GetLogin(ctx context.Context, id string) (*bitwarden.Login, error) {
+	s, err := c.GetSecret(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &bitwarden.Login{Username: &s.Key, Password: &s.Value}, nil
+}
+
+// GetSecureNote returns the value of the secret. Secrets have no type, so
+// any secret can be read as a note.
+func (c *Client) GetSecureNote(ctx context.Context, id string) (string, error) {
+	s, err := c.GetSecret(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return s.Value, nil
+}
+
+// GetField returns the key, value or note of the secret. The vault names
+// username, password and notes are accepted as well.
+func (c *Client) GetField(ctx context.Context, id string, name string) (string, error) {
+	s, err := c.GetSecret(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	switch name {
+	case "key", "username":
+		return s.Key, nil
+	case "value", "password":
+		return s.Value, nil
+	case "note", "notes":
+		return s.Note, nil
+	}
+	return "", fmt.Errorf("%w: %s", bitwarden.ErrFieldNotFound, name)
+}
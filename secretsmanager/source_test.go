@@ -0,0 +1,50 @@
+package secretsmanager
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	bitwarden "github.com/floriaanpost/go-bitwarden-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretSource(t *testing.T) {
+	var key *symmetricKey
+	c, key := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/secrets/s1", r.URL.Path)
+		json.NewEncoder(w).Encode(map[string]any{
+			"id":    "s1",
+			"key":   encrypt(t, key, "DB_PASSWORD"),
+			"value": encrypt(t, key, "hunter2"),
+			"note":  encrypt(t, key, "rotated monthly"),
+		})
+	})
+
+	t.Run("Should read the secret as a login", func(t *testing.T) {
+		login, err := c.GetLogin(context.Background(), "s1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "DB_PASSWORD", *login.Username)
+		assert.Equal(t, "hunter2", *login.Password)
+	})
+
+	t.Run("Should read the value as a note", func(t *testing.T) {
+		note, err := c.GetSecureNote(context.Background(), "s1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "hunter2", note)
+	})
+
+	t.Run("Should read fields by secret and vault names", func(t *testing.T) {
+		for name, want := range map[string]string{"key": "DB_PASSWORD", "password": "hunter2", "notes": "rotated monthly"} {
+			value, err := c.GetField(context.Background(), "s1", name)
+			assert.NoError(t, err)
+			assert.Equal(t, want, value)
+		}
+
+		_, err := c.GetField(context.Background(), "s1", "totp")
+		assert.ErrorIs(t, err, bitwarden.ErrFieldNotFound)
+	})
+}
@@ -0,0 +1,83 @@
+package bitwarden
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// SecureString holds a secret such as a password, TOTP secret or note. It is
+// masked when printed with fmt, logged with slog or encoded as JSON, so it
+// can not leak by accident. Use Reveal to read it and Wipe to zero it once it
+// is no longer needed. Decode can fill SecureString fields.
+//
+// Item keeps its secrets as plain strings, like the JSON of bw serve, so
+// SecureString is taken at the point of use: PasswordSecret, TOTPSecret and
+// NotesSecret for the fields holding secrets, Item.Secret for any value and
+// Decode for structs. Wiping is therefore best effort: the JSON responses of
+// bw serve and the string fields of Item still hold copies until they are
+// garbage collected.
+type SecureString []byte
+
+// PasswordSecret returns the password as a SecureString, or nil if there is
+// none.
+func (l *Login) PasswordSecret() SecureString {
+	return secureString(l.Password)
+}
+
+// TOTPSecret returns the TOTP secret as a SecureString, or nil if there is
+// none.
+func (l *Login) TOTPSecret() SecureString {
+	return secureString(l.TOTP)
+}
+
+// NotesSecret returns the notes, such as the body of a secure note, as a
+// SecureString, or nil if there are none.
+func (item *Item) NotesSecret() SecureString {
+	return secureString(item.Notes)
+}
+
+func secureString(s *string) SecureString {
+	if s == nil {
+		return nil
+	}
+	return SecureString(*s)
+}
+
+// Secret returns a field of the item, looked up like Value, as a
+// SecureString.
+func (item *Item) Secret(field string) (SecureString, error) {
+	v, err := item.Value(field)
+	if err != nil {
+		return nil, err
+	}
+	return SecureString(v), nil
+}
+
+// Reveal returns the secret. The returned string can not be wiped.
+func (s SecureString) Reveal() string {
+	return string(s)
+}
+
+// Wipe overwrites the secret with zeros.
+func (s SecureString) Wipe() {
+	clear(s)
+}
+
+func (s SecureString) String() string {
+	return Redacted
+}
+
+// Format masks the secret for all verbs, including %x and %#v.
+func (s SecureString) Format(f fmt.State, _ rune) {
+	io.WriteString(f, Redacted)
+}
+
+func (s SecureString) LogValue() slog.Value {
+	return slog.StringValue(Redacted)
+}
+
+func (s SecureString) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Redacted)
+}
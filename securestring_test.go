@@ -0,0 +1,100 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSecureString(t *testing.T) {
+	t.Run("Should never print the secret", func(t *testing.T) {
+		s := SecureString("hunter2")
+		wrapped := struct{ Password SecureString }{s}
+
+		for _, format := range []string{"%v", "%+v", "%#v", "%s", "%q", "%x", "%d"} {
+			assert.NotContains(t, fmt.Sprintf(format, s), "hunter2", format)
+			assert.NotContains(t, fmt.Sprintf(format, &s), "hunter2", format)
+			assert.NotContains(t, fmt.Sprintf(format, wrapped), "hunter2", format)
+		}
+		data, err := json.Marshal(wrapped)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"Password":"[REDACTED]"}`, string(data))
+
+		var buf bytes.Buffer
+		slog.New(slog.NewTextHandler(&buf, nil)).Info("unlocking", "password", s)
+		assert.NotContains(t, buf.String(), "hunter2")
+	})
+
+	t.Run("Should reveal and wipe the secret", func(t *testing.T) {
+		s := SecureString("hunter2")
+		assert.Equal(t, "hunter2", s.Reveal())
+
+		s.Wipe()
+		assert.Equal(t, make([]byte, 7), []byte(s))
+	})
+
+	t.Run("Should get secrets of an item", func(t *testing.T) {
+		password := "hunter2"
+		item := Item{Type: TypeLogin, Login: &Login{Password: &password}, Fields: []Field{{Name: "token", Value: "t0k3n"}}}
+
+		s, err := item.Secret("password")
+		assert.NoError(t, err)
+		assert.Equal(t, "hunter2", s.Reveal())
+
+		s.Wipe()
+		assert.Equal(t, "hunter2", password, "wiping must not change the item")
+
+		s, err = item.Secret("token")
+		assert.NoError(t, err)
+		assert.Equal(t, "t0k3n", s.Reveal())
+
+		_, err = item.Secret("totp")
+		assert.ErrorIs(t, err, ErrFieldNotFound)
+	})
+
+	t.Run("Should be filled by Decode", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+		itemID := "1d4cf845-8012-4b2d-a924-f9d8c9b7c44a"
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"data":{"id":"` + itemID + `","type":1,"login":{"password":"hunter2"}}}`))}, nil).
+			Once()
+
+		var cfg struct {
+			Password SecureString `bitwarden:"item=1d4cf845-8012-4b2d-a924-f9d8c9b7c44a,field=password"`
+		}
+		err := bw.Decode(context.Background(), &cfg)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "hunter2", cfg.Password.Reveal())
+	})
+}
+
+func TestSecretAccessors(t *testing.T) {
+	t.Run("Should return the secret fields as SecureString", func(t *testing.T) {
+		password, totp, notes := "hunter2", "JBSWY3DPEHPK3PXP", "note"
+		item := &Item{Notes: &notes, Login: &Login{Password: &password, TOTP: &totp}}
+
+		assert.Equal(t, "hunter2", item.Login.PasswordSecret().Reveal())
+		assert.Equal(t, "JBSWY3DPEHPK3PXP", item.Login.TOTPSecret().Reveal())
+		assert.Equal(t, "note", item.NotesSecret().Reveal())
+	})
+
+	t.Run("Should return nil for missing fields", func(t *testing.T) {
+		item := &Item{Login: &Login{}}
+
+		assert.Nil(t, item.Login.PasswordSecret())
+		assert.Nil(t, item.Login.TOTPSecret())
+		assert.Nil(t, item.NotesSecret())
+	})
+}
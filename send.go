@@ -0,0 +1,108 @@
+package bitwarden
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// SendType is the kind of content of a Send.
+type SendType int
+
+const (
+	SendTypeText SendType = 0
+	SendTypeFile SendType = 1
+)
+
+// SendContent is a received Send. Text is set for text Sends; File is set
+// for file Sends and must be closed by the caller.
+type SendContent struct {
+	ID       string
+	Name     string
+	Type     SendType
+	Text     string
+	FileName string
+	File     io.ReadCloser
+}
+
+type sendAccess struct {
+	ID   string   `json:"id"`
+	Name string   `json:"name"`
+	Type SendType `json:"type"`
+	Text *struct {
+		Text string `json:"text"`
+	} `json:"text"`
+	File *struct {
+		FileName string `json:"fileName"`
+	} `json:"file"`
+}
+
+// sendPasswordEnv passes the password of a Send to bw, which reads it from
+// the variable named by --passwordenv.
+const sendPasswordEnv = "BW_SEND_PASSWORD"
+
+// AccessSend receives the Send at sendURL, the link that was shared,
+// including the key after the #. password is empty for Sends without one.
+// A file is downloaded to a temporary file that only the current user can
+// read and that is removed when File is closed. bw serve cannot receive
+// Sends, so this is only available through the CLI.
+func (c *CLI) AccessSend(ctx context.Context, sendURL string, password string) (*SendContent, error) {
+	var env []string
+	var passwordArgs []string
+	if password != "" {
+		env = []string{sendPasswordEnv + "=" + password}
+		passwordArgs = []string{"--passwordenv", sendPasswordEnv}
+	}
+
+	out, err := c.outputEnv(ctx, env, append([]string{"send", "receive", sendURL, "--obj"}, passwordArgs...)...)
+	if err != nil {
+		return nil, err
+	}
+	var access sendAccess
+	if err := c.payloads.unmarshal(out, &access); err != nil {
+		return nil, err
+	}
+	content := &SendContent{ID: access.ID, Name: access.Name, Type: access.Type}
+	if access.Text != nil {
+		content.Text = access.Text.Text
+	}
+	if access.Type != SendTypeFile {
+		return content, nil
+	}
+	if access.File != nil {
+		content.FileName = access.File.FileName
+	}
+
+	dir, err := os.MkdirTemp("", "bw-send-*")
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, "file")
+	args := append([]string{"send", "receive", sendURL, "--output", path}, passwordArgs...)
+	if _, err := c.outputEnv(ctx, env, args...); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	content.File = tempFile{File: f, dir: dir}
+	return content, nil
+}
+
+// tempFile removes its directory when it is closed.
+type tempFile struct {
+	*os.File
+	dir string
+}
+
+func (f tempFile) Close() error {
+	err := f.File.Close()
+	if removeErr := os.RemoveAll(f.dir); err == nil {
+		err = removeErr
+	}
+	return err
+}
@@ -0,0 +1,15 @@
+package bitwarden
+
+import "context"
+
+// SecretSource is the read side shared by the backends of this module: the
+// serve API (BitwardenServer), the bw command line (CLI) and Secrets Manager
+// (secretsmanager.Client). Code that only reads secrets can depend on it and
+// leave the choice of backend to configuration.
+type SecretSource interface {
+	GetLogin(ctx context.Context, id string) (*Login, error)
+	GetSecureNote(ctx context.Context, id string) (string, error)
+	GetField(ctx context.Context, id string, name string) (string, error)
+}
+
+var _ SecretSource = (*BitwardenServer)(nil)
@@ -0,0 +1,60 @@
+package bitwarden
+
+import (
+	"errors"
+	"io"
+	"net/http"
+)
+
+// staleConnRetry sends idempotent requests again when bw serve closed the
+// keep-alive connection they were sent on. The node server closes idle
+// connections without telling the client, so the next request on such a
+// connection fails with an unexpected EOF even though the server is fine.
+// It wraps the middleware, so retries are logged and seen by middleware like
+// any other attempt.
+type staleConnRetry struct {
+	client client
+	// transport is the client below the middleware, whose idle connections
+	// are closed before a retry.
+	transport client
+}
+
+func (s staleConnRetry) Do(req *http.Request) (*http.Response, error) {
+	resp, err := s.client.Do(req)
+	if err == nil || !staleConn(err) || !idempotent(req) || req.Context().Err() != nil {
+		return resp, err
+	}
+	retry := req.Clone(req.Context())
+	switch {
+	case req.GetBody != nil:
+		body, bodyErr := req.GetBody()
+		if bodyErr != nil {
+			return resp, err
+		}
+		retry.Body = body
+	case req.ContentLength != 0:
+		return resp, err // the body is consumed and cannot be sent again
+	}
+	// Make sure the retry does not pick another connection the server
+	// closed in the meantime.
+	if c, ok := s.transport.(interface{ CloseIdleConnections() }); ok {
+		c.CloseIdleConnections()
+	}
+	return s.client.Do(retry)
+}
+
+// staleConn reports whether err is the error of a request sent on a
+// connection the server had closed.
+func staleConn(err error) bool {
+	return errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+// idempotent reports whether sending the request twice has the same effect
+// as sending it once.
+func idempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
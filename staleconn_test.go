@@ -0,0 +1,109 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestStaleConnRetry(t *testing.T) {
+	itemID := "382a9d7b-f6b5-4eaa-92a1-1f3c7d89e48f"
+	stale := func(method, endpoint string) error {
+		return &url.Error{Op: method, URL: "http://localhost" + endpoint, Err: fmt.Errorf("read: %w", io.ErrUnexpectedEOF)}
+	}
+
+	t.Run("Should send idempotent requests again on a closed connection", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))).
+			Return(nil, stale(http.MethodGet, "/object/item/"+itemID)).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))).
+			Return(itemResponse(itemID), nil).
+			Once()
+
+		_, err := bw.GetItem(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should send the body again", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+		name := "ENV"
+
+		client.
+			On("Do", mock.MatchedBy(checkRequestJSON(http.MethodPut, "http://localhost/object/item/"+itemID, func(body map[string]any) bool { return body["name"] == "ENV" }))).
+			Return(nil, stale(http.MethodPut, "/object/item/"+itemID)).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequestJSON(http.MethodPut, "http://localhost/object/item/"+itemID, func(body map[string]any) bool { return body["name"] == "ENV" }))).
+			Return(itemResponse(itemID), nil).
+			Once()
+
+		_, err := bw.EditItem(context.Background(), &Item{ID: itemID, Type: TypeSecureNote, Name: &name})
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should log the retry", func(t *testing.T) {
+		var logs bytes.Buffer
+		bw, client := newTestBitwarden(WithLogger(slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+
+		client.
+			On("Do", mock.Anything).
+			Return(nil, stale(http.MethodGet, "/object/item/"+itemID)).
+			Once()
+		client.
+			On("Do", mock.Anything).
+			Return(itemResponse(itemID), nil).
+			Once()
+
+		_, err := bw.GetItem(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Contains(t, logs.String(), `"msg":"bitwarden request failed"`)
+		assert.Contains(t, logs.String(), `"retries":1`)
+	})
+
+	t.Run("Should retry only once", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.Anything).
+			Return(nil, stale(http.MethodGet, "/object/item/"+itemID)).
+			Twice()
+
+		_, err := bw.GetItem(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+
+	t.Run("Should not send other requests again", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+		name := "ENV"
+
+		client.
+			On("Do", mock.Anything).
+			Return(nil, stale(http.MethodPost, "/object/item")).
+			Once()
+
+		_, err := bw.CreateItem(context.Background(), &Item{Type: TypeSecureNote, Name: &name})
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+}
@@ -0,0 +1,311 @@
+package bitwarden
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Strength is the score of ScorePassword, like the 0 to 4 score of zxcvbn.
+type Strength int
+
+const (
+	// StrengthVeryWeak passwords fall to fewer than a thousand guesses.
+	StrengthVeryWeak Strength = iota
+	// StrengthWeak passwords fall to fewer than a million guesses.
+	StrengthWeak
+	// StrengthFair passwords fall to fewer than 10^8 guesses, which
+	// protects against throttled online attacks.
+	StrengthFair
+	// StrengthStrong passwords fall to fewer than 10^10 guesses.
+	StrengthStrong
+	// StrengthVeryStrong passwords resist offline attacks on slow hashes.
+	StrengthVeryStrong
+)
+
+// strengthThresholds are the log10 guesses where each strength above
+// StrengthVeryWeak starts.
+var strengthThresholds = [...]float64{3, 6, 8, 10}
+
+// ScorePassword estimates how many guesses an attacker needs for the
+// password and scores it, in the style of zxcvbn. The estimate looks for
+// common passwords and words, also reversed and with l33t substitutions,
+// keyboard rows, sequences such as abc or 987, repeats and years, and counts
+// the rest as random characters. The word lists are small, so the score is
+// an upper bound that is meant for policies on machine credentials rather
+// than for judging human passphrases. Only the first 100 characters are
+// scored.
+func ScorePassword(password string) Strength {
+	guesses := estimateGuesses(password)
+	s := StrengthVeryWeak
+	for _, threshold := range strengthThresholds {
+		if guesses >= threshold {
+			s++
+		}
+	}
+	return s
+}
+
+// MinStrength reports passwords as weak if ScorePassword scores them below
+// s, for WithWeaknessChecker.
+func MinStrength(s Strength) WeaknessChecker {
+	return WeaknessFunc(func(password string) bool {
+		return ScorePassword(password) < s
+	})
+}
+
+// match is a part of the password with the log10 of the guesses to find it.
+type match struct {
+	start, end int
+	guesses    float64
+}
+
+// maxScoredRunes bounds the work of estimateGuesses, which only looks at the
+// start of longer passwords, as zxcvbn does.
+const maxScoredRunes = 100
+
+// estimateGuesses returns the log10 of the guesses for the password, the
+// cheapest way to cover it with matches and random characters.
+func estimateGuesses(password string) float64 {
+	runes := []rune(password)
+	if len(runes) > maxScoredRunes {
+		runes = runes[:maxScoredRunes]
+		password = string(runes)
+	}
+	n := len(runes)
+	if n == 0 {
+		return 0
+	}
+	perChar := math.Log10(float64(max(charsetSize(password), 10)))
+
+	matches := make([][]match, n+1) // by end
+	for _, m := range findMatches(runes) {
+		matches[m.end] = append(matches[m.end], m)
+	}
+
+	// best[i] is the cheapest cover of the first i runes; every match
+	// beyond the first also costs a guess at how the parts are combined.
+	best := make([]float64, n+1)
+	for i := 1; i <= n; i++ {
+		best[i] = best[i-1] + perChar
+		for _, m := range matches[i] {
+			best[i] = min(best[i], best[m.start]+m.guesses+math.Log10(2))
+		}
+	}
+	return best[n]
+}
+
+// charsetSize returns the number of characters in the character classes the
+// password uses, for both ScorePassword and MinEntropy.
+func charsetSize(password string) int {
+	var lower, upper, digit, other bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+	size := 0
+	for _, class := range []struct {
+		used bool
+		size int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {other, 33}} {
+		if class.used {
+			size += class.size
+		}
+	}
+	return size
+}
+
+func findMatches(runes []rune) []match {
+	var matches []match
+	matches = append(matches, dictionaryMatches(runes)...)
+	matches = append(matches, sequenceMatches(runes)...)
+	matches = append(matches, keyboardMatches(runes)...)
+	matches = append(matches, repeatMatches(runes)...)
+	matches = append(matches, yearMatches(runes)...)
+	return matches
+}
+
+// commonPasswords are frequent passwords and words, ranked by frequency.
+var commonPasswords = strings.Fields(`
+	password 123456 qwerty admin welcome letmein monkey dragon login master
+	hello secret shadow sunshine princess football baseball iloveyou trustno1
+	superman batman starwars whatever freedom ninja mustang access flower
+	passw0rd root toor changeme default guest test user demo server database
+	summer winter spring autumn love god money office company secure system
+	abc azerty solo michael charlie jordan hunter killer pepper ginger cookie
+	cheese chocolate orange banana apple computer internet google samsung
+	soccer hockey tigger buster thomas robert daniel jessica ashley bailey
+`)
+
+var dictionaryRank = func() map[string]int {
+	ranks := make(map[string]int, len(commonPasswords))
+	for i, w := range commonPasswords {
+		ranks[w] = i + 1
+	}
+	return ranks
+}()
+
+// l33t maps substitutions back to the letters they replace.
+var l33t = map[rune]rune{'@': 'a', '4': 'a', '8': 'b', '(': 'c', '3': 'e', '6': 'g', '1': 'i', '!': 'i', '|': 'l', '0': 'o', '$': 's', '5': 's', '7': 't', '+': 't', '2': 'z'}
+
+func dictionaryMatches(runes []rune) []match {
+	lower := make([]rune, len(runes))
+	plain := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+		plain[i] = lower[i]
+		if p, ok := l33t[lower[i]]; ok {
+			plain[i] = p
+		}
+	}
+
+	var matches []match
+	for i := range runes {
+		for j := i + 3; j <= len(runes); j++ {
+			for _, reversed := range []bool{false, true} {
+				word := string(plain[i:j])
+				if reversed {
+					word = reverse(word)
+				}
+				rank, ok := dictionaryRank[word]
+				if !ok {
+					continue
+				}
+				guesses := math.Log10(float64(rank)) + casing(runes[i:j])
+				if string(plain[i:j]) != string(lower[i:j]) {
+					guesses += math.Log10(2) // substituted
+				}
+				if reversed {
+					guesses += math.Log10(2)
+				}
+				matches = append(matches, match{start: i, end: j, guesses: guesses})
+			}
+		}
+	}
+	return matches
+}
+
+func reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
+
+// casing returns the log10 guesses for the upper case letters of a word:
+// none, only the first or all of them are obvious, others are not.
+func casing(word []rune) float64 {
+	upper := 0
+	for _, r := range word {
+		if unicode.IsUpper(r) {
+			upper++
+		}
+	}
+	switch {
+	case upper == 0:
+		return 0
+	case upper == len(word) || (upper == 1 && unicode.IsUpper(word[0])):
+		return math.Log10(2)
+	}
+	return float64(len(word)) * math.Log10(2) // which letters
+}
+
+// sequenceMatches finds runs like abc, 246 or zyx.
+func sequenceMatches(runes []rune) []match {
+	var matches []match
+	for i := 0; i+2 < len(runes); {
+		delta := runes[i+1] - runes[i]
+		j := i + 1
+		for j+1 < len(runes) && runes[j+1]-runes[j] == delta {
+			j++
+		}
+		if length := j - i + 1; length >= 3 && delta != 0 && delta >= -5 && delta <= 5 {
+			start := 26.0
+			switch r := unicode.ToLower(runes[i]); {
+			case r == 'a' || r == 'z' || r == '0' || r == '1' || r == '9':
+				start = 4
+			case unicode.IsDigit(r):
+				start = 10
+			}
+			if delta < 0 {
+				start *= 2
+			}
+			matches = append(matches, match{start: i, end: j + 1, guesses: math.Log10(start * float64(length))})
+		}
+		i = j
+	}
+	return matches
+}
+
+// keyboardRows are the rows of a qwerty keyboard.
+var keyboardRows = []string{"`1234567890-=", "qwertyuiop[]\\", "asdfghjkl;'", "zxcvbnm,./"}
+
+// keyboardMatches finds runs of at least four adjacent keys of a row.
+func keyboardMatches(runes []rune) []match {
+	var matches []match
+	lower := strings.ToLower(string(runes))
+	lowerRunes := []rune(lower)
+	for i := range lowerRunes {
+		for j := i + 4; j <= len(lowerRunes); j++ {
+			s := string(lowerRunes[i:j])
+			for _, row := range keyboardRows {
+				if strings.Contains(row, s) || strings.Contains(row, reverse(s)) {
+					matches = append(matches, match{start: i, end: j, guesses: math.Log10(float64(len(keyboardRows)*10*(j-i))) + casing(runes[i:j])})
+					break
+				}
+			}
+		}
+	}
+	return matches
+}
+
+// repeatMatches finds repeated runs like aaa or abcabc.
+func repeatMatches(runes []rune) []match {
+	var matches []match
+	for i := range runes {
+		for unit := 1; i+2*unit <= len(runes); unit++ {
+			count := 1
+			for i+(count+1)*unit <= len(runes) && string(runes[i+count*unit:i+(count+1)*unit]) == string(runes[i:i+unit]) {
+				count++
+			}
+			if count < 2 || (unit == 1 && count < 3) {
+				continue
+			}
+			// Brute force the unit rather than estimating it, which would
+			// recurse for every unit at every offset.
+			base := float64(unit) * math.Log10(float64(max(charsetSize(string(runes[i:i+unit])), 10)))
+			matches = append(matches, match{start: i, end: i + count*unit, guesses: base + math.Log10(float64(count))})
+		}
+	}
+	return matches
+}
+
+// yearMatches finds years from 1900 to 2099.
+func yearMatches(runes []rune) []match {
+	var matches []match
+	for i := 0; i+4 <= len(runes); i++ {
+		s := string(runes[i : i+4])
+		if (strings.HasPrefix(s, "19") || strings.HasPrefix(s, "20")) && isDigits(s) {
+			matches = append(matches, match{start: i, end: i + 4, guesses: math.Log10(200)})
+		}
+	}
+	return matches
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
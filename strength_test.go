@@ -0,0 +1,56 @@
+package bitwarden
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScorePassword(t *testing.T) {
+	for password, expected := range map[string]Strength{
+		"":                          StrengthVeryWeak,
+		"password":                  StrengthVeryWeak,
+		"P@ssw0rd":                  StrengthVeryWeak,
+		"drowssap":                  StrengthVeryWeak,
+		"qwerty123":                 StrengthVeryWeak,
+		"asdfghjkl":                 StrengthVeryWeak,
+		"aaaaaaaaaaaa":              StrengthVeryWeak,
+		"abcabcabcabc":              StrengthWeak,
+		"Password1!":                StrengthWeak,
+		"monkey2019dragon":          StrengthWeak,
+		"zQ8nW4xR":                  StrengthVeryStrong,
+		"correcthorsebatterystaple": StrengthVeryStrong,
+		"hT7$kq9Lz!3vRmP2":          StrengthVeryStrong,
+	} {
+		t.Run("Should score "+password, func(t *testing.T) {
+			assert.Equal(t, expected, ScorePassword(password))
+		})
+	}
+
+	t.Run("Should score predictable passwords below random ones of the same length", func(t *testing.T) {
+		assert.Less(t, estimateGuesses("Summer2024!!"), estimateGuesses("x7Qm2pLr9KzT"))
+	})
+
+	t.Run("Should score long repetitive passwords quickly", func(t *testing.T) {
+		done := make(chan []Strength)
+		go func() {
+			done <- []Strength{ScorePassword(strings.Repeat("a", 128)), ScorePassword(strings.Repeat("ab1x", 32))}
+		}()
+		select {
+		case scores := <-done:
+			assert.Equal(t, []Strength{StrengthWeak, StrengthFair}, scores)
+		case <-time.After(time.Second):
+			t.Fatal("scoring did not finish")
+		}
+	})
+}
+
+func TestMinStrength(t *testing.T) {
+	checker := MinStrength(StrengthStrong)
+
+	assert.True(t, checker.Weak("Password1!"))
+	assert.False(t, checker.Weak("hT7$kq9Lz!3vRmP2"))
+	assert.Equal(t, "StrengthStrong", StrengthStrong.String())
+}
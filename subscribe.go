@@ -0,0 +1,60 @@
+package bitwarden
+
+import "sync"
+
+type subscription struct {
+	itemID string
+	fn     func(*Item)
+}
+
+type subscriptions struct {
+	mu   sync.Mutex
+	next int
+	subs map[int]subscription
+}
+
+// OnItemChange registers fn to be called whenever a running Watch detects a
+// change to the item with the given ID. fn receives the new state of the
+// item, or nil if the item was deleted. Callbacks run on the watch goroutine,
+// so long-running work should be handed off. The returned function removes
+// the subscription.
+func (b *BitwardenServer) OnItemChange(id string, fn func(*Item)) (unsubscribe func()) {
+	s := b.subs
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.subs == nil {
+		s.subs = map[int]subscription{}
+	}
+	key := s.next
+	s.next++
+	s.subs[key] = subscription{itemID: id, fn: fn}
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.subs, key)
+	}
+}
+
+// notify calls the callbacks for the item of e and reports whether they
+// were given the item, which is not the case for deletions.
+func (s *subscriptions) notify(e ChangeEvent) bool {
+	s.mu.Lock()
+	var fns []func(*Item)
+	for _, sub := range s.subs {
+		if sub.itemID == e.ItemID {
+			fns = append(fns, sub.fn)
+		}
+	}
+	s.mu.Unlock()
+
+	item := e.Item
+	if e.Type == ChangeDeleted {
+		item = nil
+	}
+	for _, fn := range fns {
+		fn(item)
+	}
+	return item != nil && len(fns) > 0
+}
@@ -0,0 +1,68 @@
+package bitwarden
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOnItemChange(t *testing.T) {
+	t.Run("Should only call callbacks for the subscribed item", func(t *testing.T) {
+		bw, _ := newTestBitwarden()
+
+		var got []*Item
+		bw.OnItemChange("a", func(i *Item) { got = append(got, i) })
+
+		bw.subs.notify(ChangeEvent{Type: ChangeUpdated, ItemID: "b", Item: &Item{ID: "b"}})
+		bw.subs.notify(ChangeEvent{Type: ChangeUpdated, ItemID: "a", Item: &Item{ID: "a"}})
+		bw.subs.notify(ChangeEvent{Type: ChangeDeleted, ItemID: "a", Item: &Item{ID: "a"}})
+
+		assert.Len(t, got, 2)
+		assert.Equal(t, "a", got[0].ID)
+		assert.Nil(t, got[1])
+	})
+
+	t.Run("Should stop calling after unsubscribe", func(t *testing.T) {
+		bw, _ := newTestBitwarden()
+
+		calls := 0
+		unsubscribe := bw.OnItemChange("a", func(*Item) { calls++ })
+		bw.subs.notify(ChangeEvent{Type: ChangeUpdated, ItemID: "a", Item: &Item{ID: "a"}})
+		unsubscribe()
+		bw.subs.notify(ChangeEvent{Type: ChangeUpdated, ItemID: "a", Item: &Item{ID: "a"}})
+
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Should be called by a running watch", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodPost, "http://localhost/sync", `{}`))).
+			Return(func(*http.Request) (*http.Response, error) { return &http.Response{StatusCode: 200}, nil })
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items", ``))).
+			Return(listResponse(`{"id":"db","revisionDate":"2023-01-01T00:00:00Z","login":{"password":"old"}}`), nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items", ``))).
+			Return(func(*http.Request) (*http.Response, error) {
+				return listResponse(`{"id":"db","revisionDate":"2023-02-01T00:00:00Z","login":{"password":"new"}}`), nil
+			})
+
+		rotated := make(chan string, 1)
+		bw.OnItemChange("db", func(i *Item) { rotated <- *i.Login.Password })
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		events, err := bw.Watch(ctx, time.Millisecond)
+		assert.NoError(t, err)
+		<-events
+
+		assert.Equal(t, "new", <-rotated)
+	})
+}
@@ -0,0 +1,114 @@
+package bitwarden
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidTOTP = errors.New("invalid totp secret")
+
+const steamAlphabet = "23456789BCDFGHJKMNPQRTVWXY"
+
+type totpParams struct {
+	secret    []byte
+	period    int64
+	digits    int
+	algorithm func() hash.Hash
+	steam     bool
+}
+
+// ComputeTOTP returns the TOTP code at the given time for a base32 secret,
+// an otpauth://totp URI, whose period, digits and algorithm parameters are
+// honoured, or a steam:// secret, like the Bitwarden clients do.
+func ComputeTOTP(secretOrOtpauthURI string, at time.Time) (string, error) {
+	p, err := parseTOTP(secretOrOtpauthURI)
+	if err != nil {
+		return "", err
+	}
+
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(at.Unix()/p.period))
+	mac := hmac.New(p.algorithm, p.secret)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	if p.steam {
+		out := make([]byte, 5)
+		for i := range out {
+			out[i] = steamAlphabet[code%uint32(len(steamAlphabet))]
+			code /= uint32(len(steamAlphabet))
+		}
+		return string(out), nil
+	}
+	mod := uint64(1) // 10^10 does not fit in a uint32
+	for i := 0; i < p.digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", p.digits, uint64(code)%mod), nil
+}
+
+// CurrentTOTP returns the current TOTP code of the login, computed locally.
+func (l *Login) CurrentTOTP() (string, error) {
+	if l.TOTP == nil || *l.TOTP == "" {
+		return "", fmt.Errorf("%w: totp", ErrFieldNotFound)
+	}
+	return ComputeTOTP(*l.TOTP, time.Now())
+}
+
+func parseTOTP(s string) (totpParams, error) {
+	p := totpParams{period: 30, digits: 6, algorithm: sha1.New}
+	secret := strings.TrimSpace(s)
+
+	switch {
+	case strings.HasPrefix(strings.ToLower(secret), "otpauth://"):
+		u, err := url.Parse(secret)
+		if err != nil || u.Host != "totp" {
+			return p, fmt.Errorf("%w: not an otpauth://totp uri", ErrInvalidTOTP)
+		}
+		q := u.Query()
+		secret = q.Get("secret")
+		if v := q.Get("period"); v != "" {
+			if p.period, err = strconv.ParseInt(v, 10, 64); err != nil || p.period <= 0 {
+				return p, fmt.Errorf("%w: period %q", ErrInvalidTOTP, v)
+			}
+		}
+		if v := q.Get("digits"); v != "" {
+			if p.digits, err = strconv.Atoi(v); err != nil || p.digits < 1 || p.digits > 10 {
+				return p, fmt.Errorf("%w: digits %q", ErrInvalidTOTP, v)
+			}
+		}
+		switch v := strings.ToUpper(q.Get("algorithm")); v {
+		case "", "SHA1":
+		case "SHA256":
+			p.algorithm = sha256.New
+		case "SHA512":
+			p.algorithm = sha512.New
+		default:
+			return p, fmt.Errorf("%w: algorithm %q", ErrInvalidTOTP, v)
+		}
+	case strings.HasPrefix(strings.ToLower(secret), "steam://"):
+		p.steam = true
+		secret = secret[len("steam://"):]
+	}
+
+	secret = strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(secret))
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(secret, "="))
+	if err != nil || len(key) == 0 {
+		return p, fmt.Errorf("%w: secret is not base32", ErrInvalidTOTP)
+	}
+	p.secret = key
+	return p, nil
+}
@@ -0,0 +1,89 @@
+package bitwarden
+
+import (
+	"encoding/base32"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeTOTP(t *testing.T) {
+	// Test vectors from RFC 6238, appendix B.
+	sha1Secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+	sha256Secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890123456789012"))
+	sha512Secret := base32.StdEncoding.EncodeToString([]byte("1234567890123456789012345678901234567890123456789012345678901234"))
+
+	t.Run("Should compute a 6 digit code from a base32 secret", func(t *testing.T) {
+		code, err := ComputeTOTP(sha1Secret, time.Unix(59, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, "287082", code)
+	})
+
+	t.Run("Should accept lowercase secrets with spaces and without padding", func(t *testing.T) {
+		code, err := ComputeTOTP("gezd gnbv gy3t qojq gezd gnbv gy3t qojq", time.Unix(1111111109, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, "081804", code)
+	})
+
+	t.Run("Should honour the otpauth parameters", func(t *testing.T) {
+		for _, tc := range []struct {
+			uri  string
+			want string
+		}{
+			{"otpauth://totp/Example:alice?secret=" + sha1Secret + "&digits=8", "94287082"},
+			{"otpauth://totp/Example:alice?secret=" + sha1Secret + "&digits=10", "1094287082"},
+			{"otpauth://totp/Example:alice?secret=" + sha256Secret + "&digits=8&algorithm=SHA256", "46119246"},
+			{"otpauth://totp/Example:alice?secret=" + sha512Secret + "&digits=8&algorithm=sha512", "90693936"},
+		} {
+			code, err := ComputeTOTP(tc.uri, time.Unix(59, 0))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, code, tc.uri)
+		}
+	})
+
+	t.Run("Should use the period of the uri", func(t *testing.T) {
+		code, err := ComputeTOTP("otpauth://totp/Example:alice?secret="+sha1Secret+"&digits=8&period=60", time.Unix(119, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, "94287082", code)
+	})
+
+	t.Run("Should compute steam codes", func(t *testing.T) {
+		code, err := ComputeTOTP("steam://"+sha1Secret, time.Unix(59, 0))
+		assert.NoError(t, err)
+		assert.Len(t, code, 5)
+		for _, c := range code {
+			assert.Contains(t, steamAlphabet, string(c))
+		}
+	})
+
+	t.Run("Should return ErrInvalidTOTP for invalid input", func(t *testing.T) {
+		for _, s := range []string{
+			"",
+			"not base32!",
+			"otpauth://hotp/Example?secret=" + sha1Secret,
+			"otpauth://totp/Example?secret=" + sha1Secret + "&algorithm=MD5",
+			"otpauth://totp/Example?secret=" + sha1Secret + "&period=0",
+			"otpauth://totp/Example?secret=" + sha1Secret + "&digits=x",
+		} {
+			_, err := ComputeTOTP(s, time.Now())
+			assert.True(t, errors.Is(err, ErrInvalidTOTP), s)
+		}
+	})
+}
+
+func TestLoginCurrentTOTP(t *testing.T) {
+	t.Run("Should compute the code of the login", func(t *testing.T) {
+		secret := "JBSWY3DPEHPK3PXP"
+		login := Login{TOTP: &secret}
+		code, err := login.CurrentTOTP()
+		assert.NoError(t, err)
+		assert.Len(t, code, 6)
+	})
+
+	t.Run("Should return ErrFieldNotFound without a secret", func(t *testing.T) {
+		_, err := (&Login{}).CurrentTOTP()
+		assert.True(t, errors.Is(err, ErrFieldNotFound))
+	})
+}
@@ -0,0 +1,42 @@
+package bitwarden
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const instrumentationName = "github.com/floriaanpost/go-bitwarden-client"
+
+// WithTracerProvider records an OpenTelemetry span for every vault
+// operation, named after the method, for example "bitwarden.GetItem". Spans
+// carry the endpoint and response status of the request to bw serve and
+// whether the item came from the cache. Secrets are never recorded.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(b *BitwardenServer) { b.tracer = tp.Tracer(instrumentationName) }
+}
+
+// startSpan starts the span of the operation op.
+func (b *BitwardenServer) startSpan(ctx context.Context, op string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	tracer := b.tracer
+	if tracer == nil {
+		tracer = noop.Tracer{}
+	}
+	if caller := CallerFromContext(ctx); caller != "" {
+		attrs = append(attrs, attribute.String("bitwarden.caller", caller))
+	}
+	return tracer.Start(ctx, "bitwarden."+op, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+// endSpan records err on the span, ends it and returns err.
+func endSpan(span trace.Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+	return err
+}
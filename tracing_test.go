@@ -0,0 +1,81 @@
+package bitwarden
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestWithTracerProvider(t *testing.T) {
+	itemID := "382a9d7b-f6b5-4eaa-92a1-1f3c7d89e48f"
+	attrs := func(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+		m := map[attribute.Key]attribute.Value{}
+		for _, kv := range span.Attributes() {
+			m[kv.Key] = kv.Value
+		}
+		return m
+	}
+
+	t.Run("Should record a span per operation with cache hits and misses", func(t *testing.T) {
+		recorder := tracetest.NewSpanRecorder()
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		bw, client := newTestBitwarden(WithTracerProvider(tp), WithCache(time.Minute))
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))).
+			Return(itemResponse(itemID), nil).
+			Once()
+
+		_, err := bw.GetItem(context.Background(), itemID)
+		assert.NoError(t, err)
+		_, err = bw.GetItem(context.Background(), itemID)
+		assert.NoError(t, err)
+
+		client.AssertExpectations(t)
+		spans := recorder.Ended()
+		if assert.Len(t, spans, 2) {
+			assert.Equal(t, "bitwarden.GetItem", spans[0].Name())
+			miss := attrs(spans[0])
+			assert.False(t, miss["bitwarden.cache.hit"].AsBool())
+			assert.Equal(t, "/object/item/"+itemID, miss["bitwarden.endpoint"].AsString())
+			assert.Equal(t, int64(200), miss["http.response.status_code"].AsInt64())
+
+			hit := attrs(spans[1])
+			assert.True(t, hit["bitwarden.cache.hit"].AsBool())
+			assert.NotContains(t, hit, attribute.Key("bitwarden.endpoint"))
+		}
+	})
+
+	t.Run("Should record errors", func(t *testing.T) {
+		recorder := tracetest.NewSpanRecorder()
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		bw, client := newTestBitwarden(WithTracerProvider(tp))
+
+		client.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: 400}, nil).
+			Once()
+
+		err := bw.Unlock(context.Background(), "hunter2")
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrWrongPassword)
+		spans := recorder.Ended()
+		if assert.Len(t, spans, 1) {
+			assert.Equal(t, "bitwarden.Unlock", spans[0].Name())
+			assert.Equal(t, codes.Error, spans[0].Status().Code)
+			assert.Equal(t, int64(400), attrs(spans[0])["http.response.status_code"].AsInt64())
+			for _, kv := range spans[0].Attributes() {
+				assert.NotContains(t, kv.Value.Emit(), "hunter2")
+			}
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package bitwarden
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func gzipResponse(t *testing.T, body string) *http.Response {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte(body))
+	assert.NoError(t, err)
+	assert.NoError(t, gz.Close())
+	return &http.Response{StatusCode: 200, Header: http.Header{"Content-Encoding": {"gzip"}}, Body: io.NopCloser(&buf)}
+}
+
+func TestCompression(t *testing.T) {
+	acceptsGzip := func(req *http.Request) bool { return req.Header.Get("Accept-Encoding") == "gzip" }
+
+	t.Run("Should decompress list responses", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithCompression())
+
+		client.
+			On("Do", mock.MatchedBy(func(req *http.Request) bool {
+				return checkRequest(http.MethodGet, "http://localhost/list/object/items", ``)(req) && acceptsGzip(req)
+			})).
+			Return(gzipResponse(t, `{"success":true,"data":{"object":"list","data":[{"id":"a","type":1}]}}`), nil).
+			Once()
+
+		items, err := bw.ListItems(context.Background())
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+	})
+
+	t.Run("Should only ask for compression of lists", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithCompression())
+
+		client.
+			On("Do", mock.MatchedBy(func(req *http.Request) bool { return !acceptsGzip(req) })).
+			Return(itemResponse("a"), nil).
+			Once()
+
+		_, err := bw.GetItem(context.Background(), "a")
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should not ask for compression by default", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(func(req *http.Request) bool { return !acceptsGzip(req) })).
+			Return(listResponse(``), nil).
+			Once()
+
+		_, err := bw.ListItems(context.Background())
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+}
+
+func TestMaxIdleConns(t *testing.T) {
+	t.Run("Should keep more idle connections to bw serve", func(t *testing.T) {
+		transport := func(b *BitwardenServer) *http.Transport {
+			return b.client.(staleConnRetry).client.(*http.Client).Transport.(*http.Transport)
+		}
+
+		assert.Equal(t, defaultMaxIdleConns, transport(NewFromURL("http://localhost:8087")).MaxIdleConnsPerHost)
+		assert.Equal(t, 64, transport(NewFromURL("http://localhost:8087", WithMaxIdleConns(64))).MaxIdleConnsPerHost)
+	})
+}
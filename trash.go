@@ -0,0 +1,46 @@
+package bitwarden
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ListTrash returns the deleted items that are still in the trash.
+func (b *BitwardenServer) ListTrash(ctx context.Context) ([]Item, error) {
+	return b.ListItems(ctx, InTrash())
+}
+
+// EmptyTrash permanently deletes the items that were moved to the trash more
+// than olderThan ago; zero deletes all of them. If some items could not be
+// deleted, it returns a BulkError.
+func (b *BitwardenServer) EmptyTrash(ctx context.Context, olderThan time.Duration) error {
+	ctx, span := b.startSpan(ctx, "EmptyTrash")
+	items, err := b.listItems(ctx, InTrash())
+	if err != nil {
+		return endSpan(span, err)
+	}
+	cutoff := b.clock.Now().Add(-olderThan)
+	var ids []string
+	for _, item := range items {
+		if item.DeletedDate != nil && !item.DeletedDate.After(cutoff) {
+			ids = append(ids, item.ID)
+		}
+	}
+	span.SetAttributes(attribute.Int("bitwarden.items", len(ids)))
+	return endSpan(span, bulk(ctx, ids, b.purgeItem))
+}
+
+// purgeItem permanently deletes an item, skipping the trash.
+func (b *BitwardenServer) purgeItem(ctx context.Context, id string) error {
+	ctx, span := b.startSpan(ctx, "PurgeItem", attribute.String("bitwarden.item_id", id))
+	err := endSpan(span, b.request(ctx, http.MethodDelete, "/object/item/"+id+"?permanent=true", nil, nil))
+	b.record(ctx, AuditDelete, id, nil, err)
+	if err != nil {
+		return err
+	}
+	b.cache.remove(id)
+	return nil
+}
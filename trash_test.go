@@ -0,0 +1,78 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTrash(t *testing.T) {
+	old := time.Now().Add(-60 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	recent := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	trash := `{"id":"old","type":2,"name":"old","deletedDate":"` + old + `"},{"id":"recent","type":2,"name":"recent","deletedDate":"` + recent + `"}`
+
+	t.Run("Should list the trash", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items?trash=true", ``))).
+			Return(listResponse(trash), nil).
+			Once()
+
+		items, err := bw.ListTrash(context.Background())
+
+		assert.NoError(t, err)
+		assert.Len(t, items, 2)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("Should permanently delete items trashed before the cutoff", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items?trash=true", ``))).
+			Return(listResponse(trash), nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodDelete, "http://localhost/object/item/old?permanent=true", ``))).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"success":true}`))}, nil).
+			Once()
+
+		err := bw.EmptyTrash(context.Background(), 30*24*time.Hour)
+
+		assert.NoError(t, err)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("Should report failed deletions", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items?trash=true", ``))).
+			Return(listResponse(trash), nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodDelete, "http://localhost/object/item/old?permanent=true", ``))).
+			Return(&http.Response{StatusCode: 404, Body: io.NopCloser(bytes.NewBufferString(`{"success":false,"message":"Not found."}`))}, nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodDelete, "http://localhost/object/item/recent?permanent=true", ``))).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"success":true}`))}, nil).
+			Once()
+
+		err := bw.EmptyTrash(context.Background(), 0)
+
+		var bulkErr BulkError
+		if assert.ErrorAs(t, err, &bulkErr) {
+			assert.ErrorIs(t, bulkErr["old"], ErrNotFound)
+			assert.NotContains(t, bulkErr, "recent")
+		}
+		client.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,91 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrUnlockRequiresInteraction = errors.New("unlocking the vault requires interaction")
+
+// PasswordProvider supplies the master password when a request finds the
+// vault locked, see WithPasswordProvider.
+type PasswordProvider interface {
+	Password(ctx context.Context) (string, error)
+}
+
+// PasswordFunc is a PasswordProvider that is a function, such as one that
+// reads the password from a secret store.
+type PasswordFunc func(ctx context.Context) (string, error)
+
+func (f PasswordFunc) Password(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// InteractivePasswordProvider is a PasswordProvider that asks a person, such
+// as a terminal prompt or a push notification. PromptTime is the least time
+// the person needs to answer.
+type InteractivePasswordProvider interface {
+	PasswordProvider
+	PromptTime() time.Duration
+}
+
+// Prompt returns an InteractivePasswordProvider that asks for the password
+// with f and needs at least d.
+func Prompt(f PasswordFunc, d time.Duration) InteractivePasswordProvider {
+	return prompt{f: f, d: d}
+}
+
+type prompt struct {
+	f PasswordFunc
+	d time.Duration
+}
+
+func (p prompt) Password(ctx context.Context) (string, error) { return p.f(ctx) }
+
+func (p prompt) PromptTime() time.Duration { return p.d }
+
+// WithPasswordProvider unlocks the vault with the password of p when a
+// request finds it locked, instead of failing with ErrVaultLocked. Concurrent
+// requests share a single unlock.
+//
+// If p is an InteractivePasswordProvider and the context of the request has
+// less time left than its PromptTime, the request fails right away with an
+// error that matches both ErrUnlockRequiresInteraction and ErrVaultLocked,
+// rather than holding a request scoped context on a prompt nobody can answer
+// in time. A request without a deadline waits for the prompt.
+func WithPasswordProvider(p PasswordProvider) Option {
+	return func(b *BitwardenServer) {
+		b.passwords = p
+		b.unlocking = make(chan struct{}, 1)
+	}
+}
+
+// autoUnlock unlocks the locked vault with the password provider, or returns
+// locked if there is none.
+func (b *BitwardenServer) autoUnlock(ctx context.Context, locked error) error {
+	if b.passwords == nil {
+		return locked
+	}
+	if p, ok := b.passwords.(InteractivePasswordProvider); ok {
+		if deadline, ok := ctx.Deadline(); ok && deadline.Sub(b.clock.Now()) < p.PromptTime() {
+			return fmt.Errorf("%w: %w", ErrUnlockRequiresInteraction, locked)
+		}
+	}
+
+	select {
+	case b.unlocking <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-b.unlocking }()
+	if b.unlocked.Load() {
+		return nil // unlocked by a concurrent request
+	}
+	password, err := b.passwords.Password(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %w", err, locked)
+	}
+	return b.Unlock(ctx, password)
+}
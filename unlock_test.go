@@ -0,0 +1,101 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWithPasswordProvider(t *testing.T) {
+	itemID := "382a9d7b-f6b5-4eaa-92a1-1f3c7d89e48f"
+	itemRequest := mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+itemID, ``))
+	unlockRequest := mock.MatchedBy(checkRequest(http.MethodPost, "http://localhost/unlock", `{"password":"password"}`))
+
+	t.Run("Should unlock a locked vault before the request", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithPasswordProvider(PasswordFunc(func(context.Context) (string, error) {
+			return "password", nil
+		})))
+		bw.unlocked.Store(false)
+
+		client.On("Do", statusRequest).Return(statusResponse("locked"), nil).Once()
+		client.On("Do", unlockRequest).Return(&http.Response{StatusCode: 200}, nil).Once()
+		client.On("Do", itemRequest).Return(itemResponse(itemID), nil).Once()
+
+		item, err := bw.GetItem(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, itemID, item.ID)
+		assert.True(t, bw.unlocked.Load())
+	})
+
+	t.Run("Should fail fast if an interactive prompt cannot be answered in time", func(t *testing.T) {
+		asked := false
+		bw, client := newTestBitwarden(WithPasswordProvider(Prompt(func(context.Context) (string, error) {
+			asked = true
+			return "password", nil
+		}, time.Minute)))
+		bw.unlocked.Store(false)
+
+		client.On("Do", statusRequest).Return(statusResponse("locked"), nil).Once()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_, err := bw.GetItem(ctx, itemID)
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrUnlockRequiresInteraction)
+		assert.ErrorIs(t, err, ErrVaultLocked)
+		assert.False(t, asked)
+	})
+
+	t.Run("Should prompt without a deadline", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithPasswordProvider(Prompt(func(context.Context) (string, error) {
+			return "password", nil
+		}, time.Minute)))
+		bw.unlocked.Store(false)
+
+		client.On("Do", statusRequest).Return(statusResponse("locked"), nil).Once()
+		client.On("Do", unlockRequest).Return(&http.Response{StatusCode: 200}, nil).Once()
+		client.On("Do", itemRequest).Return(itemResponse(itemID), nil).Once()
+
+		_, err := bw.GetItem(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should return errors of the provider", func(t *testing.T) {
+		providerErr := errors.New("no password")
+		bw, client := newTestBitwarden(WithPasswordProvider(PasswordFunc(func(context.Context) (string, error) {
+			return "", providerErr
+		})))
+		bw.unlocked.Store(false)
+
+		client.On("Do", statusRequest).Return(statusResponse("locked"), nil).Once()
+
+		_, err := bw.GetItem(context.Background(), itemID)
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, providerErr)
+		assert.ErrorIs(t, err, ErrVaultLocked)
+	})
+
+	t.Run("Should not unlock again once a concurrent request unlocked the vault", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithPasswordProvider(PasswordFunc(func(context.Context) (string, error) {
+			t.Error("should not ask for the password")
+			return "", nil
+		})))
+		bw.unlocked.Store(true)
+
+		err := bw.autoUnlock(context.Background(), ErrVaultLocked)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+}
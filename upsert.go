@@ -0,0 +1,91 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var ErrDuplicateName = errors.New("more than one item has the name")
+
+// UpsertItemByName makes sure the folder, or no folder if folderID is empty,
+// has an item with the given name, so provisioning can run repeatedly
+// without creating duplicates. If there is none, build is called on a new
+// item that is then created; otherwise build is called on the existing item,
+// which is only written if build changed it. The name and folder are set
+// after build. It returns the item and whether it was created, or
+// ErrDuplicateName if several items in the folder have the name.
+func (b *BitwardenServer) UpsertItemByName(ctx context.Context, folderID, name string, build func(*Item)) (*Item, bool, error) {
+	ctx, span := b.startSpan(ctx, "UpsertItemByName")
+	item, created, err := b.upsertItemByName(ctx, folderID, name, build)
+	return item, created, endSpan(span, err)
+}
+
+func (b *BitwardenServer) upsertItemByName(ctx context.Context, folderID, name string, build func(*Item)) (*Item, bool, error) {
+	folder := "null"
+	if folderID != "" {
+		folder = folderID
+	}
+	items, err := b.ListItems(ctx, InFolder(folder), Search(name))
+	if err != nil {
+		return nil, false, err
+	}
+	var matches []Item
+	for _, i := range items {
+		if i.Name != nil && *i.Name == name { // search also matches other fields
+			matches = append(matches, i)
+		}
+	}
+	if len(matches) > 1 {
+		return nil, false, fmt.Errorf("%w: %q", ErrDuplicateName, name)
+	}
+
+	place := func(item *Item) {
+		item.Name = &name
+		item.FolderID = nil
+		if folderID != "" {
+			item.FolderID = &folderID
+		}
+	}
+	if len(matches) == 0 {
+		item := &Item{}
+		build(item)
+		place(item)
+		created, err := b.CreateItem(ctx, item)
+		if err != nil {
+			return nil, false, err
+		}
+		return created, true, nil
+	}
+
+	current, err := b.fetchItem(ctx, matches[0].ID)
+	if err != nil {
+		return nil, false, err
+	}
+	// Build on a copy made through JSON, so changes can be detected by
+	// comparing the JSON.
+	before, err := json.Marshal(current)
+	if err != nil {
+		return nil, false, err
+	}
+	var item Item
+	if err := json.Unmarshal(before, &item); err != nil {
+		return nil, false, err
+	}
+	build(&item)
+	place(&item)
+	after, err := json.Marshal(&item)
+	if err != nil {
+		return nil, false, err
+	}
+	if bytes.Equal(before, after) {
+		return current, false, nil
+	}
+	edited, err := b.EditItem(ctx, &item)
+	if err != nil {
+		return nil, false, err
+	}
+	return edited, false, nil
+}
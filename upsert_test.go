@@ -0,0 +1,92 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpsertItemByName(t *testing.T) {
+	folderID := "6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a"
+	listRequest := mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items?folderid="+folderID+"&search=db", ``))
+	setPassword := func(password string) func(*Item) {
+		return func(i *Item) {
+			i.Type = TypeLogin
+			if i.Login == nil {
+				i.Login = &Login{}
+			}
+			i.Login.Password = &password
+		}
+	}
+	stored := func() *http.Response {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"data":{"id":"1","type":1,"name":"db","folderId":"` + folderID + `","login":{"password":"hunter2"}}}`))}
+	}
+
+	t.Run("Should create a missing item", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.On("Do", listRequest).Return(listResponse(`{"id":"2","type":1,"name":"db-replica"}`), nil).Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequestJSON(http.MethodPost, "http://localhost/object/item", func(body map[string]any) bool {
+				return body["name"] == "db" && body["folderId"] == folderID && body["login"].(map[string]any)["password"] == "hunter2"
+			}))).
+			Return(stored(), nil).
+			Once()
+
+		item, created, err := bw.UpsertItemByName(context.Background(), folderID, "db", setPassword("hunter2"))
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.True(t, created)
+		assert.Equal(t, "1", item.ID)
+	})
+
+	t.Run("Should update an existing item", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.On("Do", listRequest).Return(listResponse(`{"id":"1","type":1,"name":"db"}`), nil).Once()
+		client.On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/1", ``))).Return(stored(), nil).Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequestJSON(http.MethodPut, "http://localhost/object/item/1", func(body map[string]any) bool {
+				return body["login"].(map[string]any)["password"] == "s3cr3t"
+			}))).
+			Return(stored(), nil).
+			Once()
+
+		_, created, err := bw.UpsertItemByName(context.Background(), folderID, "db", setPassword("s3cr3t"))
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.False(t, created)
+	})
+
+	t.Run("Should not write an unchanged item", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.On("Do", listRequest).Return(listResponse(`{"id":"1","type":1,"name":"db"}`), nil).Once()
+		client.On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/1", ``))).Return(stored(), nil).Once()
+
+		item, created, err := bw.UpsertItemByName(context.Background(), folderID, "db", setPassword("hunter2"))
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.False(t, created)
+		assert.Equal(t, "hunter2", *item.Login.Password)
+	})
+
+	t.Run("Should refuse duplicate names", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.On("Do", listRequest).Return(listResponse(`{"id":"1","type":1,"name":"db"},{"id":"2","type":1,"name":"db"}`), nil).Once()
+
+		_, _, err := bw.UpsertItemByName(context.Background(), folderID, "db", setPassword("hunter2"))
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrDuplicateName)
+	})
+}
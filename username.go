@@ -0,0 +1,120 @@
+package bitwarden
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+type UsernameType int
+
+const (
+	// UsernameWord is a random word from the passphrase word list.
+	UsernameWord UsernameType = iota
+	// UsernameCatchAll is a random address at a catch-all domain.
+	UsernameCatchAll
+	// UsernamePlusAddressed is an email address with a random + suffix.
+	UsernamePlusAddressed
+	// UsernameForwarded is an alias created by an email forwarding service.
+	UsernameForwarded
+)
+
+// Forwarder creates email aliases at a forwarding service such as
+// SimpleLogin or addy.io. bw has no forwarder integrations, so they are
+// provided by the caller.
+type Forwarder interface {
+	CreateAlias(ctx context.Context, website string) (string, error)
+}
+
+// UsernameOptions configures a generated username. The zero value generates
+// a random lower case word.
+type UsernameOptions struct {
+	Type UsernameType
+
+	// Capitalize and IncludeNumber apply to UsernameWord. IncludeNumber
+	// appends four digits.
+	Capitalize    bool
+	IncludeNumber bool
+
+	// Domain is the catch-all domain of UsernameCatchAll.
+	Domain string
+	// Email is the address that UsernamePlusAddressed adds a suffix to.
+	Email string
+
+	// Forwarder creates the alias of UsernameForwarded for Website.
+	Forwarder Forwarder
+	Website   string
+}
+
+var ErrInvalidUsernameOptions = errors.New("invalid username options")
+
+// usernameChars are the characters of random email addresses, like the
+// Bitwarden clients use.
+const usernameChars = "abcdefghijklmnopqrstuvwxyz1234567890"
+
+// GenerateUsername returns a new username, the other half of a credential
+// made with Generate. bw serve only generates passwords, so words are taken
+// from a generated passphrase and email addresses are generated locally.
+func (b *BitwardenServer) GenerateUsername(ctx context.Context, opts UsernameOptions) (string, error) {
+	switch opts.Type {
+	case UsernameWord:
+		// bw generates passphrases of at least three words.
+		phrase, err := b.Generate(ctx, GenerateOptions{Passphrase: true, Words: 3, Separator: "-"})
+		if err != nil {
+			return "", err
+		}
+		word, _, _ := strings.Cut(phrase, "-")
+		if opts.Capitalize && word != "" {
+			word = strings.ToUpper(word[:1]) + word[1:]
+		}
+		if opts.IncludeNumber {
+			n, err := rand.Int(rand.Reader, big.NewInt(10000))
+			if err != nil {
+				return "", err
+			}
+			word += fmt.Sprintf("%04d", n)
+		}
+		return word, nil
+	case UsernameCatchAll:
+		if opts.Domain == "" {
+			return "", fmt.Errorf("%w: a catch-all username needs a domain", ErrInvalidUsernameOptions)
+		}
+		random, err := randomString(8)
+		if err != nil {
+			return "", err
+		}
+		return random + "@" + opts.Domain, nil
+	case UsernamePlusAddressed:
+		local, domain, ok := strings.Cut(opts.Email, "@")
+		if !ok || local == "" || domain == "" {
+			return "", fmt.Errorf("%w: a plus addressed username needs an email address", ErrInvalidUsernameOptions)
+		}
+		random, err := randomString(8)
+		if err != nil {
+			return "", err
+		}
+		return local + "+" + random + "@" + domain, nil
+	case UsernameForwarded:
+		if opts.Forwarder == nil {
+			return "", fmt.Errorf("%w: a forwarded username needs a forwarder", ErrInvalidUsernameOptions)
+		}
+		return opts.Forwarder.CreateAlias(ctx, opts.Website)
+	}
+	return "", fmt.Errorf("%w: unknown type %d", ErrInvalidUsernameOptions, opts.Type)
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	max := big.NewInt(int64(len(usernameChars)))
+	for i := range b {
+		c, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b[i] = usernameChars[c.Int64()]
+	}
+	return string(b), nil
+}
@@ -0,0 +1,71 @@
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type fakeForwarder struct{}
+
+func (fakeForwarder) CreateAlias(_ context.Context, website string) (string, error) {
+	return "alias." + website + "@relay.example", nil
+}
+
+func TestGenerateUsername(t *testing.T) {
+	t.Run("Should take a word of a generated passphrase", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/generate?passphrase=&separator=-&words=3", ``))).
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"success":true,"data":{"object":"string","data":"unsaddle-mascot-ripeness"}}`))}, nil).
+			Once()
+
+		username, err := bw.GenerateUsername(context.Background(), UsernameOptions{Capitalize: true, IncludeNumber: true})
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Regexp(t, regexp.MustCompile(`^Unsaddle\d{4}$`), username)
+	})
+
+	t.Run("Should generate email addresses locally", func(t *testing.T) {
+		bw, _ := newTestBitwarden()
+
+		username, err := bw.GenerateUsername(context.Background(), UsernameOptions{Type: UsernameCatchAll, Domain: "example.com"})
+		assert.NoError(t, err)
+		assert.Regexp(t, regexp.MustCompile(`^[a-z0-9]{8}@example\.com$`), username)
+
+		username, err = bw.GenerateUsername(context.Background(), UsernameOptions{Type: UsernamePlusAddressed, Email: "ops@example.com"})
+		assert.NoError(t, err)
+		assert.Regexp(t, regexp.MustCompile(`^ops\+[a-z0-9]{8}@example\.com$`), username)
+	})
+
+	t.Run("Should create aliases with the forwarder", func(t *testing.T) {
+		bw, _ := newTestBitwarden()
+
+		username, err := bw.GenerateUsername(context.Background(), UsernameOptions{Type: UsernameForwarded, Forwarder: fakeForwarder{}, Website: "github.com"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "alias.github.com@relay.example", username)
+	})
+
+	t.Run("Should refuse incomplete options", func(t *testing.T) {
+		bw, _ := newTestBitwarden()
+
+		for _, opts := range []UsernameOptions{
+			{Type: UsernameCatchAll},
+			{Type: UsernamePlusAddressed, Email: "ops"},
+			{Type: UsernameForwarded},
+			{Type: 42},
+		} {
+			_, err := bw.GenerateUsername(context.Background(), opts)
+			assert.ErrorIs(t, err, ErrInvalidUsernameOptions)
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package bitwarden
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+var ErrValidation = errors.New("invalid item")
+
+// ValidationError describes why a field of an item was refused. It matches
+// ErrValidation with errors.Is.
+type ValidationError struct {
+	Field  string
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", ErrValidation, e.Field, e.Reason)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
+
+// ValidationLimits are the maximum lengths of item values once encrypted,
+// which is how the Bitwarden server measures them. Zero means no limit.
+type ValidationLimits struct {
+	Name  int
+	Notes int
+	// Field applies to the names and values of custom fields.
+	Field int
+}
+
+// DefaultValidationLimits are the limits of the Bitwarden server.
+var DefaultValidationLimits = ValidationLimits{Name: 1000, Notes: 10000, Field: 5000}
+
+// WithValidationLimits sets the limits CreateItem and EditItem check before
+// sending an item, for servers with other limits. Defaults to
+// DefaultValidationLimits.
+func WithValidationLimits(l ValidationLimits) Option {
+	return func(b *BitwardenServer) { b.limits = &l }
+}
+
+// encryptedLen returns the length of n bytes once encrypted, as
+// "2.<iv>|<data>|<mac>" with AES-CBC padded data and base64 encoding.
+func encryptedLen(n int) int {
+	b64 := func(n int) int { return (n + 2) / 3 * 4 }
+	return len("2.") + b64(16) + len("|") + b64((n/16+1)*16) + len("|") + b64(32)
+}
+
+// validate checks the item before it is sent, because bw answers invalid
+// items with a generic bad request.
+func (l ValidationLimits) validate(item *Item) error {
+	var errs []error
+	check := func(field string, s *string, max int) {
+		switch {
+		case s == nil:
+		case !utf8.ValidString(*s):
+			errs = append(errs, &ValidationError{Field: field, Reason: "not valid UTF-8"})
+		case max > 0 && encryptedLen(len(*s)) > max:
+			errs = append(errs, &ValidationError{Field: field, Reason: fmt.Sprintf("%d characters once encrypted, the limit is %d", encryptedLen(len(*s)), max)})
+		}
+	}
+
+	if item.Name == nil || *item.Name == "" {
+		errs = append(errs, &ValidationError{Field: "name", Reason: "required"})
+	}
+	check("name", item.Name, l.Name)
+	check("notes", item.Notes, l.Notes)
+	for i := range item.Fields {
+		f := &item.Fields[i]
+		check(fmt.Sprintf("fields[%d].name", i), &f.Name, l.Field)
+		check(fmt.Sprintf("fields[%d].value", i), &f.Value, l.Field)
+	}
+	if item.Login != nil {
+		check("login.username", item.Login.Username, 0)
+		check("login.password", item.Login.Password, 0)
+	}
+	return errors.Join(errs...)
+}
+
+func (b *BitwardenServer) validate(item *Item) error {
+	if b.limits == nil {
+		return DefaultValidationLimits.validate(item)
+	}
+	return b.limits.validate(item)
+}
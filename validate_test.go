@@ -0,0 +1,62 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidation(t *testing.T) {
+	name := "ENV"
+
+	t.Run("Should refuse invalid items without a request", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+		long := strings.Repeat("x", 7500)
+		invalid := string([]byte{0xff, 0xfe})
+
+		for field, item := range map[string]*Item{
+			"name":            {Type: TypeSecureNote},
+			"notes":           {Type: TypeSecureNote, Name: &name, Notes: &long},
+			"fields[0].value": {Type: TypeSecureNote, Name: &name, Fields: []Field{{Name: "token", Value: invalid}}},
+			"login.password":  {Type: TypeLogin, Name: &name, Login: &Login{Password: &invalid}},
+		} {
+			_, err := bw.CreateItem(context.Background(), item)
+
+			var validationErr *ValidationError
+			assert.ErrorIs(t, err, ErrValidation, field)
+			if assert.ErrorAs(t, err, &validationErr, field) {
+				assert.Equal(t, field, validationErr.Field)
+			}
+		}
+		client.AssertExpectations(t)
+	})
+
+	t.Run("Should report every invalid field", func(t *testing.T) {
+		bw, _ := newTestBitwarden()
+		long := strings.Repeat("x", 7500)
+
+		_, err := bw.EditItem(context.Background(), &Item{ID: "id", Notes: &long})
+
+		assert.EqualError(t, err, "invalid item: name: required\ninvalid item: notes: 10080 characters once encrypted, the limit is 10000")
+	})
+
+	t.Run("Should use the configured limits", func(t *testing.T) {
+		bw, _ := newTestBitwarden(WithValidationLimits(ValidationLimits{Name: 100}))
+		long := strings.Repeat("x", 100)
+
+		_, err := bw.CreateItem(context.Background(), &Item{Type: TypeSecureNote, Name: &long, Notes: &long})
+
+		var validationErr *ValidationError
+		assert.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, "name", validationErr.Field)
+	})
+
+	t.Run("Should compute the encrypted length", func(t *testing.T) {
+		assert.Equal(t, 96, encryptedLen(0))
+		assert.Equal(t, 96, encryptedLen(15))
+		assert.Equal(t, 116, encryptedLen(16))
+	})
+}
@@ -0,0 +1,131 @@
+package bitwarden
+
+import (
+	"context"
+	"crypto/sha256"
+	"math"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+// WeaknessChecker decides whether a password is too weak.
+type WeaknessChecker interface {
+	Weak(password string) bool
+}
+
+// WeaknessFunc makes a function a WeaknessChecker.
+type WeaknessFunc func(password string) bool
+
+func (f WeaknessFunc) Weak(password string) bool {
+	return f(password)
+}
+
+// MinEntropy reports passwords as weak if their estimated entropy is below
+// bits. The estimate is the length times the bits per character of the
+// character classes used (lower and upper case letters, digits and other
+// characters), so it overrates predictable passwords such as Password1!;
+// MinStrength does not.
+func MinEntropy(bits float64) WeaknessChecker {
+	return WeaknessFunc(func(password string) bool {
+		return entropy(password) < bits
+	})
+}
+
+func entropy(password string) float64 {
+	charset := charsetSize(password)
+	if charset == 0 {
+		return 0
+	}
+	return float64(utf8.RuneCountInString(password)) * math.Log2(float64(charset))
+}
+
+// AuditReport lists the IDs of the logins with each kind of problem.
+type AuditReport struct {
+	// Reused holds groups of logins that share a password.
+	Reused [][]string
+	Empty  []string
+	// Old holds logins whose password was not changed within the maximum
+	// age.
+	Old  []string
+	Weak []string
+}
+
+type auditVaultOptions struct {
+	maxAge  time.Duration
+	checker WeaknessChecker
+	now     func() time.Time
+}
+
+// AuditVaultOption configures AuditVault.
+type AuditVaultOption func(*auditVaultOptions)
+
+// WithMaxPasswordAge reports passwords older than d. Defaults to a year.
+func WithMaxPasswordAge(d time.Duration) AuditVaultOption {
+	return func(o *auditVaultOptions) { o.maxAge = d }
+}
+
+// WithWeaknessChecker reports the passwords c considers weak, for example
+// MinStrength(StrengthStrong) or MinEntropy(60). Without a checker no
+// passwords are reported as weak.
+func WithWeaknessChecker(c WeaknessChecker) AuditVaultOption {
+	return func(o *auditVaultOptions) { o.checker = c }
+}
+
+// AuditVault checks the passwords of all logins in the vault for reuse,
+// emptiness, age and, with WithWeaknessChecker, weakness. The age is taken
+// from the password revision date, or the creation date of logins whose
+// password never changed. The report only holds item IDs, never passwords.
+func (b *BitwardenServer) AuditVault(ctx context.Context, opts ...AuditVaultOption) (*AuditReport, error) {
+	o := auditVaultOptions{maxAge: 365 * 24 * time.Hour, now: b.clock.Now}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	items, err := b.ListItems(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return auditVault(items, o), nil
+}
+
+func auditVault(items []Item, o auditVaultOptions) *AuditReport {
+	report := &AuditReport{}
+	byPassword := map[[sha256.Size]byte][]string{}
+	cutoff := o.now().Add(-o.maxAge)
+
+	for _, item := range items {
+		if item.Type != TypeLogin || item.Login == nil {
+			continue
+		}
+		password := ""
+		if item.Login.Password != nil {
+			password = *item.Login.Password
+		}
+		if password == "" {
+			report.Empty = append(report.Empty, item.ID)
+			continue
+		}
+
+		hash := sha256.Sum256([]byte(password))
+		byPassword[hash] = append(byPassword[hash], item.ID)
+
+		changed := item.CreationDate
+		if item.Login.PasswordRevisionDate != nil {
+			changed = *item.Login.PasswordRevisionDate
+		}
+		if changed.Before(cutoff) {
+			report.Old = append(report.Old, item.ID)
+		}
+		if o.checker != nil && o.checker.Weak(password) {
+			report.Weak = append(report.Weak, item.ID)
+		}
+	}
+
+	for _, ids := range byPassword {
+		if len(ids) > 1 {
+			report.Reused = append(report.Reused, ids)
+		}
+	}
+	sort.Slice(report.Reused, func(i, j int) bool { return report.Reused[i][0] < report.Reused[j][0] })
+	return report
+}
@@ -0,0 +1,77 @@
+package bitwarden
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuditVault(t *testing.T) {
+	t.Run("Should report reused, empty, old and weak passwords", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		recent := time.Now().Add(-24 * time.Hour).UTC().Format(time.RFC3339)
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items", ``))).
+			Return(listResponse(`
+				{"id":"a","type":1,"creationDate":"`+recent+`","login":{"password":"correct horse battery staple"}},
+				{"id":"b","type":1,"creationDate":"2020-01-01T00:00:00Z","login":{"password":"correct horse battery staple","passwordRevisionDate":"`+recent+`"}},
+				{"id":"c","type":1,"creationDate":"`+recent+`","login":{"password":""}},
+				{"id":"d","type":1,"creationDate":"`+recent+`","login":{}},
+				{"id":"e","type":1,"creationDate":"2020-01-01T00:00:00Z","login":{"password":"abc"}},
+				{"id":"f","type":2,"creationDate":"2020-01-01T00:00:00Z","notes":"not a login"}`), nil).
+			Once()
+
+		report, err := bw.AuditVault(context.Background(), WithWeaknessChecker(MinEntropy(60)))
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, &AuditReport{
+			Reused: [][]string{{"a", "b"}},
+			Empty:  []string{"c", "d"},
+			Old:    []string{"e"},
+			Weak:   []string{"e"},
+		}, report)
+	})
+
+	t.Run("Should use the maximum age", func(t *testing.T) {
+		now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+		x, y := "x", "y"
+		items := []Item{
+			{ID: "a", Type: TypeLogin, CreationDate: now.Add(-48 * time.Hour), Login: &Login{Password: &x}},
+			{ID: "b", Type: TypeLogin, CreationDate: now.Add(-12 * time.Hour), Login: &Login{Password: &y}},
+		}
+
+		report := auditVault(items, auditVaultOptions{maxAge: 24 * time.Hour, now: func() time.Time { return now }})
+
+		assert.Equal(t, []string{"a"}, report.Old)
+	})
+
+	t.Run("Should report passwords below the minimum strength", func(t *testing.T) {
+		weak, strong := "Password1!", "hT7$kq9Lz!3vRmP2"
+		items := []Item{
+			{ID: "a", Type: TypeLogin, Login: &Login{Password: &weak}},
+			{ID: "b", Type: TypeLogin, Login: &Login{Password: &strong}},
+		}
+
+		report := auditVault(items, auditVaultOptions{checker: MinStrength(StrengthStrong), now: time.Now})
+
+		assert.Equal(t, []string{"a"}, report.Weak)
+	})
+}
+
+func TestMinEntropy(t *testing.T) {
+	t.Run("Should estimate entropy from length and character classes", func(t *testing.T) {
+		assert.InDelta(t, 0, entropy(""), 0.01)
+		assert.InDelta(t, 37.60, entropy("password"), 0.01)
+		assert.InDelta(t, 78.84, entropy("Tr0ub4dor&3x"), 0.01)
+
+		checker := MinEntropy(60)
+		assert.True(t, checker.Weak("password"))
+		assert.False(t, checker.Weak("Tr0ub4dor&3x"))
+	})
+}
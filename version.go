@@ -0,0 +1,142 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrRequiresCLIVersion = errors.New("requires a newer bw")
+	ErrUnknownCLIVersion  = errors.New("bw version is unknown")
+)
+
+// Version is a version of bw, which uses year.month.patch.
+type Version struct {
+	Major, Minor, Patch int
+}
+
+// ParseVersion parses versions like 2024.9.0, as printed by bw --version.
+func ParseVersion(s string) (Version, error) {
+	parts := strings.Split(strings.TrimPrefix(strings.TrimSpace(s), "v"), ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Version{}, fmt.Errorf("invalid version %q", s)
+	}
+	var v Version
+	for i, dst := range []*int{&v.Major, &v.Minor, &v.Patch}[:len(parts)] {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid version %q", s)
+		}
+		*dst = n
+	}
+	return v, nil
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Less reports whether v is older than o.
+func (v Version) Less(o Version) bool {
+	if v.Major != o.Major {
+		return v.Major < o.Major
+	}
+	if v.Minor != o.Minor {
+		return v.Minor < o.Minor
+	}
+	return v.Patch < o.Patch
+}
+
+// WithCLIVersion sets the version of bw behind bw serve, for clients created
+// with NewFromURL, whose version cannot be detected.
+func WithCLIVersion(v Version) Option {
+	return func(b *BitwardenServer) { b.version = &cliVersion{v: &v} }
+}
+
+// cliVersion detects the version of bw once.
+type cliVersion struct {
+	mu   sync.Mutex
+	path string // bw to run, or empty if the version cannot be detected
+	v    *Version
+}
+
+func (c *cliVersion) get(ctx context.Context) (Version, error) {
+	if c == nil {
+		return Version{}, ErrUnknownCLIVersion
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.v != nil {
+		return *c.v, nil
+	}
+	if c.path == "" {
+		return Version{}, ErrUnknownCLIVersion
+	}
+	out, err := exec.CommandContext(ctx, c.path, "--version").Output()
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) || errors.Is(err, fs.ErrNotExist) {
+			c.path = "" // don't try again, but do after a timeout or cancellation
+		}
+		return Version{}, fmt.Errorf("%w: %w", ErrUnknownCLIVersion, err)
+	}
+	v, err := ParseVersion(string(out))
+	if err != nil {
+		c.path = ""
+		return Version{}, fmt.Errorf("%w: %w", ErrUnknownCLIVersion, err)
+	}
+	c.v = &v
+	return v, nil
+}
+
+// CLIVersion returns the version of bw. It is detected with bw --version
+// for clients created with New, and must be set with WithCLIVersion for
+// others; otherwise it returns ErrUnknownCLIVersion.
+func (b *BitwardenServer) CLIVersion(ctx context.Context) (Version, error) {
+	return b.version.get(ctx)
+}
+
+// CLIVersion returns the version of bw, see BitwardenServer.CLIVersion.
+func (c *CLI) CLIVersion(ctx context.Context) (Version, error) {
+	out, err := c.output(ctx, "--version")
+	if err != nil {
+		return Version{}, err
+	}
+	return ParseVersion(string(out))
+}
+
+// itemFeatures lists the item features that need a recent bw, which older
+// versions drop or refuse with a confusing error.
+var itemFeatures = []struct {
+	name string
+	min  Version
+	used func(*Item) bool
+}{
+	{"passkeys", Version{2023, 10, 0}, func(i *Item) bool { return i.Login != nil && len(i.Login.Fido2Credentials) > 0 }},
+	{"SSH key items", Version{2025, 1, 0}, func(i *Item) bool { return i.Type == TypeSSHKey || i.SSHKey != nil }},
+}
+
+// checkItemFeatures returns ErrRequiresCLIVersion if the item uses a feature
+// that v does not support.
+func checkItemFeatures(v Version, item *Item) error {
+	for _, f := range itemFeatures {
+		if f.used(item) && v.Less(f.min) {
+			return fmt.Errorf("%w: %s need bw %s, have %s", ErrRequiresCLIVersion, f.name, f.min, v)
+		}
+	}
+	return nil
+}
+
+// checkFeatures checks the item against the version of bw, if known.
+func (b *BitwardenServer) checkFeatures(ctx context.Context, item *Item) error {
+	v, err := b.version.get(ctx)
+	if err != nil {
+		return nil // gate only when the version is known
+	}
+	return checkItemFeatures(v, item)
+}
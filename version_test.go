@@ -0,0 +1,116 @@
+package bitwarden
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseVersion(t *testing.T) {
+	t.Run("Should parse bw versions", func(t *testing.T) {
+		for s, want := range map[string]Version{
+			"2024.9.0\n": {2024, 9, 0},
+			"v2023.12.1": {2023, 12, 1},
+			"2025.1":     {2025, 1, 0},
+		} {
+			v, err := ParseVersion(s)
+			assert.NoError(t, err)
+			assert.Equal(t, want, v)
+		}
+	})
+
+	t.Run("Should reject invalid versions", func(t *testing.T) {
+		for _, s := range []string{"", "2024", "2024.x.0", "1.2.3.4"} {
+			_, err := ParseVersion(s)
+			assert.Error(t, err, s)
+		}
+	})
+
+	t.Run("Should compare versions", func(t *testing.T) {
+		assert.True(t, Version{2024, 9, 0}.Less(Version{2024, 10, 0}))
+		assert.True(t, Version{2024, 9, 0}.Less(Version{2024, 9, 1}))
+		assert.False(t, Version{2025, 1, 0}.Less(Version{2024, 12, 0}))
+		assert.Equal(t, "2024.9.0", Version{2024, 9, 0}.String())
+	})
+}
+
+func TestCLIVersionGating(t *testing.T) {
+	name := "deploy"
+	sshKey := &Item{Type: TypeSSHKey, Name: &name, SSHKey: &SSHKey{}}
+
+	t.Run("Should refuse features the version does not support", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithCLIVersion(Version{2024, 9, 0}))
+
+		_, err := bw.CreateItem(context.Background(), sshKey)
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrRequiresCLIVersion)
+		assert.Contains(t, err.Error(), "need bw 2025.1.0, have 2024.9.0")
+	})
+
+	t.Run("Should send items the version supports", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithCLIVersion(Version{2025, 2, 0}))
+
+		client.
+			On("Do", mock.MatchedBy(checkRequestJSON(http.MethodPost, "http://localhost/object/item", func(body map[string]any) bool { return body["type"] == float64(5) }))).
+			Return(itemResponse("new-id"), nil).
+			Once()
+
+		_, err := bw.CreateItem(context.Background(), sshKey)
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should not gate when the version is unknown", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", mock.Anything).
+			Return(itemResponse("id"), nil).
+			Once()
+
+		_, err := bw.CLIVersion(context.Background())
+		assert.ErrorIs(t, err, ErrUnknownCLIVersion)
+		_, err = bw.EditItem(context.Background(), &Item{ID: "id", Type: TypeLogin, Name: &name, Login: &Login{Fido2Credentials: []Fido2Credential{{CredentialID: "c"}}}})
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+	})
+}
+
+func TestCLIVersionDetection(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs a POSIX shell")
+	}
+
+	t.Run("Should try again after a cancelled detection", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "bw")
+		assert.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\necho 2024.6.0\n"), 0o700))
+		c := &cliVersion{path: path}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := c.get(ctx)
+		assert.ErrorIs(t, err, ErrUnknownCLIVersion)
+		v, err := c.get(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, Version{Major: 2024, Minor: 6}, v)
+	})
+
+	t.Run("Should stop trying when bw does not exist", func(t *testing.T) {
+		c := &cliVersion{path: filepath.Join(t.TempDir(), "bw")}
+
+		_, err := c.get(context.Background())
+
+		assert.ErrorIs(t, err, ErrUnknownCLIVersion)
+		assert.Empty(t, c.path)
+	})
+}
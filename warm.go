@@ -0,0 +1,57 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const warmConcurrency = 8
+
+var ErrCacheDisabled = errors.New("cache is disabled")
+
+// Warm fetches the items with the given IDs concurrently so later lookups
+// are served from the cache. Items that could not be fetched are reported in
+// the returned error; the others are cached regardless.
+func (b *BitwardenServer) Warm(ctx context.Context, ids ...string) error {
+	if b.cache == nil {
+		return ErrCacheDisabled
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	sem := make(chan struct{}, warmConcurrency)
+	for _, id := range ids {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(id string) {
+			defer func() { <-sem; wg.Done() }()
+			if _, err := b.GetItem(ctx, id); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("warming %s: %w", id, err))
+				mu.Unlock()
+			}
+		}(id)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// WarmByFolder caches all items in the folder with the given ID using a
+// single list request.
+func (b *BitwardenServer) WarmByFolder(ctx context.Context, folderID string) error {
+	if b.cache == nil {
+		return ErrCacheDisabled
+	}
+
+	items, err := b.ListItems(ctx, InFolder(folderID))
+	if err != nil {
+		return err
+	}
+	b.cache.putAll(items)
+	return nil
+}
@@ -0,0 +1,79 @@
+package bitwarden
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWarm(t *testing.T) {
+	t.Run("Should require the cache", func(t *testing.T) {
+		bw, _ := newTestBitwarden()
+
+		assert.ErrorIs(t, bw.Warm(context.Background(), "a"), ErrCacheDisabled)
+		assert.ErrorIs(t, bw.WarmByFolder(context.Background(), "6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a"), ErrCacheDisabled)
+	})
+
+	t.Run("Should fetch all items once", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithCache(time.Minute))
+
+		for _, id := range []string{"a", "b", "c"} {
+			client.
+				On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/"+id, ``))).
+				Return(itemResponse(id), nil).
+				Once()
+		}
+
+		err := bw.Warm(context.Background(), "a", "b", "c")
+		assert.NoError(t, err)
+		for _, id := range []string{"a", "b", "c"} {
+			item, err := bw.GetItem(context.Background(), id)
+			assert.NoError(t, err)
+			assert.Equal(t, id, item.ID)
+		}
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("Should report items that failed", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithCache(time.Minute))
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/a", ``))).
+			Return(itemResponse("a"), nil).
+			Once()
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/object/item/b", ``))).
+			Return(&http.Response{StatusCode: 404}, nil).
+			Once()
+
+		err := bw.Warm(context.Background(), "a", "b")
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.ErrorContains(t, err, "b")
+		_, ok := bw.cache.get("a")
+		assert.True(t, ok)
+	})
+
+	t.Run("Should cache all items in a folder", func(t *testing.T) {
+		bw, client := newTestBitwarden(WithCache(time.Minute))
+
+		client.
+			On("Do", mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items?folderid=6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a", ``))).
+			Return(listResponse(`{"id":"a","type":2,"notes":"note a"},{"id":"b","type":2,"notes":"note b"}`), nil).
+			Once()
+
+		err := bw.WarmByFolder(context.Background(), "6a1f3e2d-9c4b-4e8a-b7d5-0f1e2d3c4b5a")
+		assert.NoError(t, err)
+		note, err := bw.GetSecureNote(context.Background(), "b")
+
+		client.AssertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, "note b", note)
+	})
+}
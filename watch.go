@@ -0,0 +1,204 @@
+package bitwarden
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+type ChangeType int
+
+const (
+	ChangeCreated ChangeType = iota + 1
+	ChangeUpdated
+	ChangeDeleted
+)
+
+func (t ChangeType) String() string {
+	switch t {
+	case ChangeCreated:
+		return "created"
+	case ChangeUpdated:
+		return "updated"
+	case ChangeDeleted:
+		return "deleted"
+	}
+	return "unknown"
+}
+
+// ChangeEvent describes a change to a single item. For deleted items Item
+// holds the last known state.
+type ChangeEvent struct {
+	Type   ChangeType
+	ItemID string
+	Item   *Item
+}
+
+// Watch syncs the vault every interval and emits an event for each item that
+// was created, updated or deleted since the previous sync. Items are compared
+// by their revision date. The channel is closed when ctx is done. Failed syncs
+// are skipped and retried on the next tick. Callbacks registered with
+// OnItemChange are called before the corresponding event is sent.
+//
+// A slow reader does not hold up syncing. Events that have not been received
+// yet are coalesced per item: a later change replaces an earlier one, an item
+// created and deleted before either event was received is dropped, and an
+// item deleted and created again is reported as updated. So at most one event
+// per item is ever pending, and it always carries the latest known state.
+func (b *BitwardenServer) Watch(ctx context.Context, interval time.Duration) (<-chan ChangeEvent, error) {
+	known, err := b.snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan ChangeEvent)
+	ticker := b.clock.NewTicker(interval)
+	go func() {
+		defer close(events)
+		defer ticker.Stop()
+
+		var pending changeQueue
+		for {
+			var out chan<- ChangeEvent
+			next, ok := pending.peek()
+			if ok {
+				out = events
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case out <- next:
+				pending.pop()
+				b.record(ctx, AuditRead, next.ItemID, next.Item, nil)
+				continue
+			case <-ticker.C():
+			}
+
+			current, err := b.snapshot(ctx)
+			if err != nil {
+				continue
+			}
+			for _, e := range diffItems(known, current) {
+				b.applyChange(e)
+				if b.subs.notify(e) {
+					b.record(ctx, AuditRead, e.ItemID, e.Item, nil)
+				}
+				pending.push(e)
+			}
+			known = current
+		}
+	}()
+	return events, nil
+}
+
+// changeQueue holds the events a Watch reader has not received yet, at most
+// one per item, in the order the items first changed.
+type changeQueue struct {
+	order  []string
+	events map[string]ChangeEvent
+}
+
+func (q *changeQueue) push(e ChangeEvent) {
+	if q.events == nil {
+		q.events = map[string]ChangeEvent{}
+	}
+	prev, ok := q.events[e.ItemID]
+	if !ok {
+		q.order = append(q.order, e.ItemID)
+		q.events[e.ItemID] = e
+		return
+	}
+	switch {
+	case prev.Type == ChangeCreated && e.Type == ChangeDeleted:
+		q.remove(e.ItemID) // the reader never knew about it
+		return
+	case prev.Type == ChangeCreated:
+		e.Type = ChangeCreated
+	case prev.Type == ChangeDeleted && e.Type == ChangeCreated:
+		e.Type = ChangeUpdated
+	}
+	q.events[e.ItemID] = e
+}
+
+func (q *changeQueue) peek() (ChangeEvent, bool) {
+	if len(q.order) == 0 {
+		return ChangeEvent{}, false
+	}
+	return q.events[q.order[0]], true
+}
+
+func (q *changeQueue) pop() {
+	q.remove(q.order[0])
+}
+
+func (q *changeQueue) remove(id string) {
+	delete(q.events, id)
+	for i, o := range q.order {
+		if o == id {
+			q.order = append(q.order[:i], q.order[i+1:]...)
+			break
+		}
+	}
+}
+
+func (b *BitwardenServer) snapshot(ctx context.Context) (map[string]Item, error) {
+	if err := b.sync(ctx); err != nil {
+		return nil, err
+	}
+	items, err := b.listItems(ctx)
+	if err != nil {
+		return nil, err
+	}
+	b.cache.refresh(items)
+	m := make(map[string]Item, len(items))
+	for _, i := range items {
+		m[i.ID] = i
+	}
+	return m, nil
+}
+
+// applyChange keeps the cache in line with the vault so watchers never read
+// a stale item after being notified. Items that did not change stay cached,
+// as snapshot refreshed them.
+func (b *BitwardenServer) applyChange(e ChangeEvent) {
+	if e.Type != ChangeDeleted {
+		b.misses.remove(e.ItemID)
+	}
+	if b.cache == nil {
+		return
+	}
+	if e.Type == ChangeDeleted {
+		b.cache.remove(e.ItemID)
+		return
+	}
+	b.cache.put(e.ItemID, e.Item)
+}
+
+func diffItems(old, current map[string]Item) []ChangeEvent {
+	var events []ChangeEvent
+	for id, i := range current {
+		i := i
+		prev, ok := old[id]
+		switch {
+		case !ok:
+			events = append(events, ChangeEvent{Type: ChangeCreated, ItemID: id, Item: &i})
+		case !sameRevision(prev.RevisionDate, i.RevisionDate):
+			events = append(events, ChangeEvent{Type: ChangeUpdated, ItemID: id, Item: &i})
+		}
+	}
+	for id, i := range old {
+		i := i
+		if _, ok := current[id]; !ok {
+			events = append(events, ChangeEvent{Type: ChangeDeleted, ItemID: id, Item: &i})
+		}
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].ItemID < events[j].ItemID })
+	return events
+}
+
+func sameRevision(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,191 @@
+package bitwarden
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWatch(t *testing.T) {
+	syncRequest := mock.MatchedBy(checkRequest(http.MethodPost, "http://localhost/sync", `{}`))
+	listRequest := mock.MatchedBy(checkRequest(http.MethodGet, "http://localhost/list/object/items", ``))
+
+	t.Run("Should return the initial sync error", func(t *testing.T) {
+		bw, client := newTestBitwarden()
+
+		client.
+			On("Do", syncRequest).
+			Return(&http.Response{StatusCode: 500}, nil).
+			Once()
+
+		_, err := bw.Watch(context.Background(), time.Millisecond)
+
+		client.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrUnexpectedStatusCode)
+	})
+
+	t.Run("Should emit created, updated and deleted items", func(t *testing.T) {
+		clock := newFakeClock()
+		bw, client := newTestBitwarden(WithCache(time.Hour), WithClock(clock))
+
+		client.
+			On("Do", syncRequest).
+			Return(func(*http.Request) (*http.Response, error) { return &http.Response{StatusCode: 200}, nil })
+		client.
+			On("Do", listRequest).
+			Return(listResponse(`{"id":"a","revisionDate":"2023-01-01T00:00:00Z"},{"id":"b","revisionDate":"2023-01-01T00:00:00Z"}`), nil).
+			Once()
+		client.
+			On("Do", listRequest).
+			Return(func(*http.Request) (*http.Response, error) {
+				return listResponse(`{"id":"a","revisionDate":"2023-02-01T00:00:00Z","notes":"rotated"},{"id":"c","revisionDate":"2023-01-01T00:00:00Z"}`), nil
+			})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		events, err := bw.Watch(ctx, time.Minute)
+		assert.NoError(t, err)
+		clock.Advance(time.Minute)
+
+		var got []ChangeEvent
+		for i := 0; i < 3; i++ {
+			got = append(got, <-events)
+		}
+		cancel()
+		for range events {
+		}
+
+		assert.Equal(t, ChangeUpdated, got[0].Type)
+		assert.Equal(t, "a", got[0].ItemID)
+		assert.Equal(t, ChangeDeleted, got[1].Type)
+		assert.Equal(t, "b", got[1].ItemID)
+		assert.Equal(t, ChangeCreated, got[2].Type)
+		assert.Equal(t, "c", got[2].ItemID)
+
+		cached, ok := bw.cache.get("a")
+		assert.True(t, ok)
+		assert.Equal(t, "rotated", *cached.Notes)
+	})
+
+	t.Run("Should keep unchanged items cached", func(t *testing.T) {
+		clock := newFakeClock()
+		bw, client := newTestBitwarden(WithCache(time.Hour), WithClock(clock))
+		notes := "cached"
+		bw.cache.put("a", &Item{ID: "a", Notes: &notes})
+
+		client.
+			On("Do", syncRequest).
+			Return(func(*http.Request) (*http.Response, error) { return &http.Response{StatusCode: 200}, nil })
+		client.
+			On("Do", listRequest).
+			Return(listResponse(`{"id":"a","revisionDate":"2023-01-01T00:00:00Z","notes":"listed"}`), nil).
+			Once()
+		client.
+			On("Do", listRequest).
+			Return(func(*http.Request) (*http.Response, error) {
+				return listResponse(`{"id":"a","revisionDate":"2023-01-01T00:00:00Z","notes":"listed"},{"id":"b","revisionDate":"2023-01-01T00:00:00Z"}`), nil
+			})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		events, err := bw.Watch(ctx, time.Minute)
+		assert.NoError(t, err)
+		clock.Advance(time.Minute)
+		e := <-events
+		cancel()
+		for range events {
+		}
+
+		assert.Equal(t, "b", e.ItemID)
+		cached, ok := bw.cache.get("a")
+		if assert.True(t, ok) {
+			assert.Equal(t, "listed", *cached.Notes)
+		}
+	})
+
+	t.Run("Should keep syncing and coalesce events for a slow reader", func(t *testing.T) {
+		clock := newFakeClock()
+		bw, client := newTestBitwarden(WithClock(clock))
+
+		listed := make(chan struct{}, 1)
+		list := func(body string) func(*http.Request) (*http.Response, error) {
+			return func(*http.Request) (*http.Response, error) {
+				listed <- struct{}{}
+				return listResponse(body), nil
+			}
+		}
+		client.
+			On("Do", syncRequest).
+			Return(func(*http.Request) (*http.Response, error) { return &http.Response{StatusCode: 200}, nil })
+		client.
+			On("Do", listRequest).
+			Return(list(`{"id":"a","revisionDate":"2023-01-01T00:00:00Z"}`)).
+			Once()
+		client.
+			On("Do", listRequest).
+			Return(list(`{"id":"a","revisionDate":"2023-01-01T00:00:00Z"},{"id":"b","revisionDate":"2023-01-01T00:00:00Z"}`)).
+			Once()
+		client.
+			On("Do", listRequest).
+			Return(list(`{"id":"a","revisionDate":"2023-02-01T00:00:00Z"}`)).
+			Once()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		events, err := bw.Watch(ctx, time.Minute)
+		assert.NoError(t, err)
+		<-listed
+		clock.Advance(time.Minute)
+		<-listed
+		clock.Advance(time.Minute)
+		<-listed
+
+		got := <-events
+		cancel()
+		var rest []ChangeEvent
+		for e := range events {
+			rest = append(rest, e)
+		}
+
+		client.AssertExpectations(t)
+		assert.Equal(t, ChangeUpdated, got.Type)
+		assert.Equal(t, "a", got.ItemID)
+		assert.Empty(t, rest, "b was created and deleted before it was received")
+	})
+}
+
+func TestChangeQueue(t *testing.T) {
+	item := func(notes string) *Item { return &Item{Notes: &notes} }
+
+	var q changeQueue
+	q.push(ChangeEvent{Type: ChangeUpdated, ItemID: "a", Item: item("first")})
+	q.push(ChangeEvent{Type: ChangeCreated, ItemID: "b", Item: item("first")})
+	q.push(ChangeEvent{Type: ChangeDeleted, ItemID: "c", Item: item("first")})
+	q.push(ChangeEvent{Type: ChangeUpdated, ItemID: "a", Item: item("second")})
+	q.push(ChangeEvent{Type: ChangeUpdated, ItemID: "b", Item: item("second")})
+	q.push(ChangeEvent{Type: ChangeCreated, ItemID: "c", Item: item("second")})
+
+	var got []ChangeEvent
+	for e, ok := q.peek(); ok; e, ok = q.peek() {
+		got = append(got, e)
+		q.pop()
+	}
+
+	assert.Len(t, got, 3)
+	assert.Equal(t, ChangeEvent{Type: ChangeUpdated, ItemID: "a", Item: item("second")}, got[0])
+	assert.Equal(t, ChangeEvent{Type: ChangeCreated, ItemID: "b", Item: item("second")}, got[1])
+	assert.Equal(t, ChangeEvent{Type: ChangeUpdated, ItemID: "c", Item: item("second")}, got[2])
+
+	q.push(ChangeEvent{Type: ChangeCreated, ItemID: "d"})
+	q.push(ChangeEvent{Type: ChangeDeleted, ItemID: "d"})
+	_, ok := q.peek()
+	assert.False(t, ok)
+}
+
+func TestChangeTypeString(t *testing.T) {
+	assert.Equal(t, "created", ChangeCreated.String())
+	assert.Equal(t, "updated", ChangeUpdated.String())
+	assert.Equal(t, "deleted", ChangeDeleted.String())
+	assert.Equal(t, "unknown", ChangeType(0).String())
+}